@@ -54,13 +54,27 @@ func run(logger *slog.Logger) error {
 		c.HealthHandler,
 		c.RoomHandler,
 		c.MessageHandler,
+		c.ExportHandler,
+		c.RetentionHandler,
+		c.ReportHandler,
+		c.AuditHandler,
+		c.WorkspaceHandler,
+		c.WebhookHandler,
+		c.BotHandler,
+		c.CommandHandler,
 		c.AuthMiddleware,
 	)
 	handler := router.SetupRoutes(cfg, logger)
 
-	// Start the upload worker in a separate goroutine
+	// Start the background workers in separate goroutines
 	ctx := context.Background()
 	go c.UploadWorker.Start(ctx)
+	go c.ExportWorker.Start(ctx)
+	go c.RetentionWorker.Start(ctx)
+	go c.AuditWorker.Start(ctx)
+	go c.RoomWorker.Start(ctx)
+	go c.OutboxWorker.Start(ctx)
+	go c.WebhookWorker.Start(ctx)
 
 	// Create and run the server, which handles its own lifecycle.
 	srv := httpTransport.NewServer(cfg, logger, handler)
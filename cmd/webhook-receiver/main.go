@@ -0,0 +1,68 @@
+// Command webhook-receiver is a local endpoint for testing outgoing webhooks.
+// It verifies each delivery's signature and logs the event. Run the server
+// with WEBHOOK_ALLOW_LOCAL_TARGETS=true and register http://localhost:9090/
+// as the webhook URL, passing the webhook's secret with -secret.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/pkg/utils/signutil"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "Address to listen on")
+	secret := flag.String("secret", "", "The webhook's signing secret; signatures are not checked when empty")
+	tolerance := flag.Duration("tolerance", 5*time.Minute, "How old a delivery's timestamp may be")
+	failWith := flag.Int("fail-with", 0, "Answer every delivery with this status code, to exercise retries")
+	flag.Parse()
+
+	if *secret == "" {
+		log.Println("Warning: no -secret given, signatures will not be verified")
+	}
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		delivery := r.Header.Get("X-Gochat-Delivery")
+		event := r.Header.Get("X-Gochat-Event")
+		if *secret != "" {
+			err := signutil.Verify(*secret, r.Header.Get("X-Gochat-Signature"), r.Header.Get("X-Gochat-Timestamp"), body, *tolerance)
+			if err != nil {
+				log.Printf("REJECTED %s %s: %v", event, delivery, err)
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, body, "", "  "); err != nil {
+			pretty.Write(body)
+		}
+		log.Printf("%s %s\n%s", event, delivery, pretty.String())
+
+		if *failWith != 0 {
+			http.Error(w, fmt.Sprintf("failing on purpose with %d", *failWith), *failWith)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("Webhook receiver listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
@@ -10,6 +10,9 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/database"
+	"github.com/purushothdl/gochat-backend/internal/domain/bot"
+	"github.com/purushothdl/gochat-backend/internal/infrastructure/postgres"
 	"github.com/purushothdl/gochat-backend/internal/infrastructure/redis"
 	"github.com/purushothdl/gochat-backend/internal/websocket"
 )
@@ -40,12 +43,26 @@ func main() {
 		log.Fatalf("Failed to create presence manager: %v", err)
 	}
 
+	eventLog, err := redis.NewEventLog(&cfg.Redis, &cfg.EventLog)
+	if err != nil {
+		log.Fatalf("Failed to create event log: %v", err)
+	}
+
+	// Bot tokens and room subscriptions are checked against the database; user access tokens are not.
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
 	// Create and start WebSocket hub
-	hub := websocket.NewHub(logger, pubsubProvider, presenceManager)
+	hub := websocket.NewHub(logger, pubsubProvider, presenceManager, eventLog, postgres.NewRoomRepository(db), cfg.EventLog.ReplayLimit)
 	go hub.Run()
 
-	// The Handler now has fewer dependencies.
-	wsHandler := websocket.NewHandler(hub, cfg, logger)
+	userRepo := postgres.NewUserRepository(db)
+	botService := bot.NewService(postgres.NewBotRepository(db), userRepo, postgres.NewWorkspaceRepository(db), cfg, logger)
+
+	wsHandler := websocket.NewHandler(hub, botService, cfg, logger)
 
 	http.HandleFunc("/ws", wsHandler.ServeWs)
 
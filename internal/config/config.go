@@ -8,16 +8,27 @@ import (
 )
 
 type Config struct {
-	App      AppConfig
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Security SecurityConfig
-	CORS     CORSConfig
-	Resend   ResendConfig
-	AWS      AWSConfig
-	Redis    RedisConfig
-	Upload   UploadConfig
+	App        AppConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	JWT        JWTConfig
+	Security   SecurityConfig
+	CORS       CORSConfig
+	Resend     ResendConfig
+	AWS        AWSConfig
+	Redis      RedisConfig
+	Upload     UploadConfig
+	Export     ExportConfig
+	Retention  RetentionConfig
+	RateLimit  RateLimitConfig
+	Moderation ModerationConfig
+	Outbox     OutboxConfig
+	EventLog   EventLogConfig
+	Room       RoomConfig
+	Audit      AuditConfig
+	Webhook    WebhookConfig
+	Bot        BotConfig
+	Command    CommandConfig
 }
 
 // AppConfig holds general application settings.
@@ -91,10 +102,112 @@ type UploadConfig struct {
 	MaxFileSize      int64
 	AllowedTypes     []string
 	ProfileImagePath string
+	RoomAvatarPath   string
 	StagingPath      string
 	QueueName        string
 }
 
+// ExportConfig holds settings for asynchronous room history exports.
+type ExportConfig struct {
+	QueueName   string
+	StoragePath string
+	LinkExpiry  time.Duration // How long a finished export stays downloadable
+	BatchSize   int           // Messages fetched per page while building an export
+}
+
+// RetentionConfig holds settings for the scheduled message retention purge.
+type RetentionConfig struct {
+	DefaultDays int           // Retention for rooms without their own policy; 0 keeps messages forever
+	Interval    time.Duration // How often the purge job runs
+	BatchSize   int           // Messages deleted per transaction
+	DryRun      bool          // Log what would be purged without deleting anything
+}
+
+// RateLimitConfig holds the per-user ceilings enforced across all API instances.
+type RateLimitConfig struct {
+	MessagesPerWindow int // Messages a user may send across all rooms per window
+	MessageWindow     time.Duration
+}
+
+// ModerationConfig holds settings for the built-in content moderation hooks.
+type ModerationConfig struct {
+	BlockedWords       []string
+	BlockedPatterns    []string // Regular expressions matched case-insensitively
+	WordFilterAction   string   // MASK, REJECT or FLAG
+	AllowedLinkDomains []string // When set, links to any other domain are rejected
+	BlockedLinkDomains []string
+	WebhookURL         string // Optional external moderation service
+	WebhookTimeout     time.Duration
+	FailOpen           bool // Allow content through when a hook errors
+}
+
+// OutboxConfig holds settings for the worker that relays stored events to Pub/Sub.
+type OutboxConfig struct {
+	PollInterval time.Duration // Fallback poll when no insert notification arrives
+	BatchSize    int
+	MinBackoff   time.Duration // Delay before the first retry; doubles on each failure
+	MaxBackoff   time.Duration
+	Lease        time.Duration // How long a claimed batch is hidden from other relays
+	Retention    time.Duration // How long published events are kept before cleanup
+}
+
+// EventLogConfig bounds the per-channel history kept for replaying missed events.
+type EventLogConfig struct {
+	MaxLen      int64         // Approximate number of events kept per channel
+	Retention   time.Duration // Idle time after which a channel's history is dropped
+	ReplayLimit int           // Most events replayed on resume before asking the client to resync
+}
+
+// RoomConfig holds settings for room membership flows.
+type RoomConfig struct {
+	InvitationTTL  time.Duration // How long an invitation can be accepted after it was last sent
+	RestoreWindow  time.Duration // How long a deleted room can be restored before it is purged
+	PurgeInterval  time.Duration // How often the purge job looks for rooms past their restore window
+	PurgeBatchSize int           // Messages deleted per statement while purging a room
+
+	JoinRequestsPerWindow int // Join requests a user may file across all rooms per window
+	JoinRequestWindow     time.Duration
+}
+
+// AuditConfig holds settings for the audit log retention purge.
+type AuditConfig struct {
+	RetentionDays  int           // How long audit entries are kept; 0 keeps them forever
+	PurgeInterval  time.Duration // How often the purge job runs
+	PurgeBatchSize int           // Entries deleted per statement
+}
+
+// WebhookConfig holds settings for incoming webhooks and outgoing webhook delivery.
+type WebhookConfig struct {
+	IncomingPerWindow int // Messages a single incoming webhook may post per window
+	IncomingWindow    time.Duration
+
+	DeliveryQueueName string
+	DeliveryTimeout   time.Duration // How long a receiver has to answer a delivery
+	MaxAttempts       int           // Attempts before a delivery is dead-lettered
+	MinBackoff        time.Duration
+	MaxBackoff        time.Duration
+	DeliveryLease     time.Duration // How long a claimed delivery is hidden from other workers
+	RetryInterval     time.Duration // How often due retries are put back on the queue
+	DeliveryRetention time.Duration // How long finished deliveries and their attempts are kept
+	AllowLocalTargets bool          // Allow plain HTTP and private or loopback addresses, for local testing
+}
+
+// BotConfig holds settings for bot accounts and their tokens.
+type BotConfig struct {
+	MaxPerOwner        int           // Bots a single user may own
+	MaxTokensPerBot    int           // Active tokens a single bot may have
+	TokenTouchInterval time.Duration // How often a token's last_used_at is refreshed while in use
+}
+
+// CommandConfig holds settings for slash commands.
+type CommandConfig struct {
+	MaxPerScope          int           // Custom commands a single room or workspace may have
+	ResponseTTL          time.Duration // How long a custom command's handler may reply to an invocation
+	MaxResponses         int           // Replies a handler may send to one invocation
+	InvocationsPerWindow int           // Commands a single user may run per window
+	InvocationWindow     time.Duration
+}
+
 func Load() (*Config, error) {
 	return &Config{
 		App: AppConfig{
@@ -167,9 +280,100 @@ func Load() (*Config, error) {
 			MaxFileSize:      int64(parseInt("UPLOAD_MAX_FILE_SIZE", 5*1024*1024)), // 5MB default
 			AllowedTypes:     strings.Split(getEnv("UPLOAD_ALLOWED_TYPES", "image/jpeg,image/png,image/webp"), ","),
 			ProfileImagePath: getEnv("UPLOAD_PROFILE_PATH", "profiles"),
+			RoomAvatarPath:   getEnv("UPLOAD_ROOM_AVATAR_PATH", "room-avatars"),
 			StagingPath:      getEnv("UPLOAD_STAGING_PATH", "staging"),
 			QueueName:        getEnv("UPLOAD_QUEUE_NAME", "profile_upload_queue"),
 		},
+
+		Export: ExportConfig{
+			QueueName:   getEnv("EXPORT_QUEUE_NAME", "room_export_queue"),
+			StoragePath: getEnv("EXPORT_STORAGE_PATH", "exports"),
+			LinkExpiry:  parseDuration("EXPORT_LINK_EXPIRY", "24h"),
+			BatchSize:   parseInt("EXPORT_BATCH_SIZE", 500),
+		},
+
+		Retention: RetentionConfig{
+			DefaultDays: parseInt("RETENTION_DEFAULT_DAYS", 0),
+			Interval:    parseDuration("RETENTION_PURGE_INTERVAL", "1h"),
+			BatchSize:   parseInt("RETENTION_BATCH_SIZE", 1000),
+			DryRun:      getEnvAsBool("RETENTION_DRY_RUN", false),
+		},
+
+		RateLimit: RateLimitConfig{
+			MessagesPerWindow: parseInt("RATE_LIMIT_MESSAGES", 30),
+			MessageWindow:     parseDuration("RATE_LIMIT_MESSAGE_WINDOW", "1m"),
+		},
+
+		Moderation: ModerationConfig{
+			BlockedWords:       getEnvAsList("MODERATION_BLOCKED_WORDS"),
+			BlockedPatterns:    getEnvAsList("MODERATION_BLOCKED_PATTERNS"),
+			WordFilterAction:   getEnv("MODERATION_WORD_FILTER_ACTION", "MASK"),
+			AllowedLinkDomains: getEnvAsList("MODERATION_ALLOWED_LINK_DOMAINS"),
+			BlockedLinkDomains: getEnvAsList("MODERATION_BLOCKED_LINK_DOMAINS"),
+			WebhookURL:         getEnv("MODERATION_WEBHOOK_URL", ""),
+			WebhookTimeout:     parseDuration("MODERATION_WEBHOOK_TIMEOUT", "2s"),
+			FailOpen:           getEnvAsBool("MODERATION_FAIL_OPEN", true),
+		},
+
+		Outbox: OutboxConfig{
+			PollInterval: parseDuration("OUTBOX_POLL_INTERVAL", "1s"),
+			BatchSize:    parseInt("OUTBOX_BATCH_SIZE", 100),
+			MinBackoff:   parseDuration("OUTBOX_MIN_BACKOFF", "1s"),
+			MaxBackoff:   parseDuration("OUTBOX_MAX_BACKOFF", "5m"),
+			Lease:        parseDuration("OUTBOX_LEASE", "30s"),
+			Retention:    parseDuration("OUTBOX_RETENTION", "24h"),
+		},
+
+		EventLog: EventLogConfig{
+			MaxLen:      int64(parseInt("EVENT_LOG_MAX_LEN", 1000)),
+			Retention:   parseDuration("EVENT_LOG_RETENTION", "24h"),
+			ReplayLimit: parseInt("EVENT_LOG_REPLAY_LIMIT", 500),
+		},
+
+		Room: RoomConfig{
+			InvitationTTL:  parseDuration("ROOM_INVITATION_TTL", "168h"),
+			RestoreWindow:  parseDuration("ROOM_RESTORE_WINDOW", "720h"),
+			PurgeInterval:  parseDuration("ROOM_PURGE_INTERVAL", "1h"),
+			PurgeBatchSize: parseInt("ROOM_PURGE_BATCH_SIZE", 1000),
+
+			JoinRequestsPerWindow: parseInt("ROOM_JOIN_REQUESTS_PER_WINDOW", 10),
+			JoinRequestWindow:     parseDuration("ROOM_JOIN_REQUEST_WINDOW", "1h"),
+		},
+
+		Audit: AuditConfig{
+			RetentionDays:  parseInt("AUDIT_RETENTION_DAYS", 365),
+			PurgeInterval:  parseDuration("AUDIT_PURGE_INTERVAL", "24h"),
+			PurgeBatchSize: parseInt("AUDIT_PURGE_BATCH_SIZE", 1000),
+		},
+
+		Webhook: WebhookConfig{
+			IncomingPerWindow: parseInt("WEBHOOK_INCOMING_PER_WINDOW", 30),
+			IncomingWindow:    parseDuration("WEBHOOK_INCOMING_WINDOW", "1m"),
+
+			DeliveryQueueName: getEnv("WEBHOOK_DELIVERY_QUEUE_NAME", "webhook_delivery_queue"),
+			DeliveryTimeout:   parseDuration("WEBHOOK_DELIVERY_TIMEOUT", "10s"),
+			MaxAttempts:       parseInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			MinBackoff:        parseDuration("WEBHOOK_MIN_BACKOFF", "30s"),
+			MaxBackoff:        parseDuration("WEBHOOK_MAX_BACKOFF", "1h"),
+			DeliveryLease:     parseDuration("WEBHOOK_DELIVERY_LEASE", "1m"),
+			RetryInterval:     parseDuration("WEBHOOK_RETRY_INTERVAL", "15s"),
+			DeliveryRetention: parseDuration("WEBHOOK_DELIVERY_RETENTION", "720h"),
+			AllowLocalTargets: getEnvAsBool("WEBHOOK_ALLOW_LOCAL_TARGETS", false),
+		},
+
+		Bot: BotConfig{
+			MaxPerOwner:        parseInt("BOT_MAX_PER_OWNER", 10),
+			MaxTokensPerBot:    parseInt("BOT_MAX_TOKENS_PER_BOT", 10),
+			TokenTouchInterval: parseDuration("BOT_TOKEN_TOUCH_INTERVAL", "1m"),
+		},
+
+		Command: CommandConfig{
+			MaxPerScope:          parseInt("COMMAND_MAX_PER_SCOPE", 50),
+			ResponseTTL:          parseDuration("COMMAND_RESPONSE_TTL", "30m"),
+			MaxResponses:         parseInt("COMMAND_MAX_RESPONSES", 5),
+			InvocationsPerWindow: parseInt("COMMAND_INVOCATIONS_PER_WINDOW", 20),
+			InvocationWindow:     parseDuration("COMMAND_INVOCATION_WINDOW", "1m"),
+		},
 	}, nil
 
 }
@@ -208,3 +412,14 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// getEnvAsList splits a comma-separated variable, dropping empty entries.
+func getEnvAsList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
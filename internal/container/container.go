@@ -7,14 +7,24 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/purushothdl/gochat-backend/internal/config"
 	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
 	"github.com/purushothdl/gochat-backend/internal/domain/auth"
+	"github.com/purushothdl/gochat-backend/internal/domain/bot"
+	"github.com/purushothdl/gochat-backend/internal/domain/command"
+	"github.com/purushothdl/gochat-backend/internal/domain/export"
 	"github.com/purushothdl/gochat-backend/internal/domain/health"
 	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/domain/outbox"
+	"github.com/purushothdl/gochat-backend/internal/domain/report"
+	"github.com/purushothdl/gochat-backend/internal/domain/retention"
 	"github.com/purushothdl/gochat-backend/internal/domain/room"
 	"github.com/purushothdl/gochat-backend/internal/domain/upload"
 	"github.com/purushothdl/gochat-backend/internal/domain/user"
+	"github.com/purushothdl/gochat-backend/internal/domain/webhook"
+	"github.com/purushothdl/gochat-backend/internal/domain/workspace"
 	"github.com/purushothdl/gochat-backend/internal/infrastructure/email"
 	"github.com/purushothdl/gochat-backend/internal/infrastructure/imageproc"
+	"github.com/purushothdl/gochat-backend/internal/infrastructure/moderation"
 	"github.com/purushothdl/gochat-backend/internal/infrastructure/postgres"
 	"github.com/purushothdl/gochat-backend/internal/infrastructure/redis"
 	"github.com/purushothdl/gochat-backend/internal/infrastructure/s3"
@@ -34,32 +44,70 @@ type Container struct {
 	PasswordResetRepo *postgres.PasswordResetRepository
 	RoomRepo          *postgres.RoomRepository
 	MessageRepo       *postgres.MessageRepository
+	ExportRepo        *postgres.ExportRepository
+	AuditRepo         *postgres.AuditRepository
+	RetentionRepo     *postgres.RetentionRepository
+	ReportRepo        *postgres.ReportRepository
+	OutboxRepo        *postgres.OutboxRepository
+	WorkspaceRepo     *postgres.WorkspaceRepository
+	WebhookRepo       *postgres.WebhookRepository
+	BotRepo           *postgres.BotRepository
+	CommandRepo       *postgres.CommandRepository
 
 	// Infrastructure Providers (implementing contracts)
 	QueueProvider    contracts.Queue
 	PubSubProvider   contracts.PubSub
 	StorageProvider  contracts.FileStorage
 	PresenceProvider contracts.PresenceManager
+	RateLimiter      contracts.RateLimiter
+	EventLog         contracts.EventLog
 	EmailService     *email.ResendService
 	ImageProcessor   *imageproc.Processor
+	ModerationHooks  []message.ModerationHook
 
 	// Domain Services
-	AuthService    *auth.Service
-	UserService    *user.Service
-	HealthService  *health.Service
-	RoomService    *room.Service
-	MessageService *message.Service
-	UploadService  *upload.Service
+	AuthService      *auth.Service
+	UserService      *user.Service
+	HealthService    *health.Service
+	RoomService      *room.Service
+	MessageService   *message.Service
+	UploadService    *upload.Service
+	ExportService    *export.Service
+	AuditService     *audit.Service
+	RetentionService *retention.Service
+	ReportService    *report.Service
+	WorkspaceService *workspace.Service
+	WebhookService   *webhook.Service
+	BotService       *bot.Service
+	CommandService   *command.Service
+
+	// WebhookDispatcher is built before the services that report events to
+	// outgoing webhooks, as WebhookService itself posts through MessageService.
+	WebhookDispatcher *webhook.Dispatcher
 
 	// Workers
-	UploadWorker *upload.Worker
+	UploadWorker    *upload.Worker
+	ExportWorker    *export.Worker
+	RetentionWorker *retention.Worker
+	AuditWorker     *audit.Worker
+	RoomWorker      *room.Worker
+	OutboxWorker    *outbox.Worker
+	WebhookWorker   *webhook.Worker
 
 	// Handlers
-	AuthHandler    *auth.Handler
-	UserHandler    *user.Handler
-	HealthHandler  *health.Handler
-	RoomHandler    *room.Handler
-	MessageHandler *message.Handler
+	AuthHandler      *auth.Handler
+	UserHandler      *user.Handler
+	HealthHandler    *health.Handler
+	RoomHandler      *room.Handler
+	MessageHandler   *message.Handler
+	ExportHandler    *export.Handler
+	RetentionHandler *retention.Handler
+	ReportHandler    *report.Handler
+	AuditHandler     *audit.Handler
+	WorkspaceHandler *workspace.Handler
+	WebhookHandler   *webhook.Handler
+	BotHandler       *bot.Handler
+	CommandHandler   *command.Handler
 
 	// Middleware
 	AuthMiddleware *middleware.AuthMiddleware
@@ -83,6 +131,15 @@ func (c *Container) Build() error {
 	c.PasswordResetRepo = postgres.NewPasswordResetRepository(c.DB)
 	c.RoomRepo = postgres.NewRoomRepository(c.DB)
 	c.MessageRepo = postgres.NewMessageRepository(c.DB)
+	c.ExportRepo = postgres.NewExportRepository(c.DB)
+	c.AuditRepo = postgres.NewAuditRepository(c.DB)
+	c.RetentionRepo = postgres.NewRetentionRepository(c.DB)
+	c.ReportRepo = postgres.NewReportRepository(c.DB)
+	c.OutboxRepo = postgres.NewOutboxRepository(c.DB)
+	c.WorkspaceRepo = postgres.NewWorkspaceRepository(c.DB)
+	c.WebhookRepo = postgres.NewWebhookRepository(c.DB)
+	c.BotRepo = postgres.NewBotRepository(c.DB)
+	c.CommandRepo = postgres.NewCommandRepository(c.DB)
 
 	// Build Infrastructure Providers
 	c.StorageProvider, err = s3.NewClient(&c.Config.AWS)
@@ -101,31 +158,67 @@ func (c *Container) Build() error {
 	if err != nil {
 		return fmt.Errorf("failed to create presence provider: %w", err)
 	}
+	c.RateLimiter, err = redis.NewRateLimiter(&c.Config.Redis)
+	if err != nil {
+		return fmt.Errorf("failed to create rate limiter: %w", err)
+	}
+	c.EventLog, err = redis.NewEventLog(&c.Config.Redis, &c.Config.EventLog)
+	if err != nil {
+		return fmt.Errorf("failed to create event log: %w", err)
+	}
 	c.EmailService = email.NewResendService(&c.Config.Resend)
 	c.ImageProcessor = imageproc.NewProcessor(c.Config.Upload.AllowedTypes)
+	c.ModerationHooks, err = moderation.NewPipeline(&c.Config.Moderation)
+	if err != nil {
+		return fmt.Errorf("failed to build moderation pipeline: %w", err)
+	}
 
 	// Build Domain Services
-	c.AuthService = auth.NewService(c.AuthRepo, c.UserRepo, c.PasswordResetRepo, c.EmailService, c.Config, c.Logger)
+	c.WorkspaceService = workspace.NewService(c.WorkspaceRepo, c.UserRepo, c.Config, c.Logger)
+	c.AuthService = auth.NewService(c.AuthRepo, c.UserRepo, c.PasswordResetRepo, c.EmailService, c.WorkspaceService, c.Config, c.Logger)
 	c.UserService = user.NewService(c.UserRepo, c.Config, c.Logger)
 	c.HealthService = health.NewService(c.DB, c.Logger)
-	c.RoomService = room.NewService(c.RoomRepo, c.UserRepo, c.Config, c.Logger)
-	c.MessageService = message.NewService(c.MessageRepo, c.RoomRepo, c.UserRepo, c.PresenceProvider, c.PubSubProvider, c.Config, c.Logger)
+	c.AuditService = audit.NewService(c.AuditRepo, c.RoomRepo, c.Logger)
+	c.WebhookDispatcher = webhook.NewDispatcher(c.WebhookRepo, c.QueueProvider, c.Config, c.Logger)
+	c.MessageService = message.NewService(c.MessageRepo, c.RoomRepo, c.UserRepo, c.PresenceProvider, c.RateLimiter, c.ModerationHooks, c.AuditService, c.WebhookDispatcher, c.Config, c.Logger)
 
-	// The upload.Service fulfills the user.ProfileImageUploader interface implicitly.
+	// The upload.Service fulfills the user.ProfileImageUploader and room.AvatarUploader interfaces implicitly.
 	c.UploadService = upload.NewService(c.StorageProvider, c.QueueProvider, c.ImageProcessor, c.Config, c.Logger)
+	c.RoomService = room.NewService(c.RoomRepo, c.UserRepo, c.WorkspaceRepo, c.MessageService, c.UploadService, c.AuditService, c.WebhookDispatcher, c.RateLimiter, c.Config, c.Logger)
+	c.ExportService = export.NewService(c.ExportRepo, c.RoomRepo, c.StorageProvider, c.QueueProvider, c.Config, c.Logger)
+	c.RetentionService = retention.NewService(c.RetentionRepo, c.RoomRepo, c.AuditService, c.Config, c.Logger)
+	c.WebhookService = webhook.NewService(c.WebhookRepo, c.RoomRepo, c.WorkspaceRepo, c.MessageService, c.WebhookDispatcher, c.AuditService, c.RateLimiter, c.Config, c.Logger)
+	c.ReportService = report.NewService(c.ReportRepo, c.MessageRepo, c.RoomRepo, c.RoomService, c.UserRepo, c.AuthRepo, c.AuditService, c.WebhookDispatcher, c.Config, c.Logger)
+	c.BotService = bot.NewService(c.BotRepo, c.UserRepo, c.WorkspaceRepo, c.Config, c.Logger)
+	c.CommandService = command.NewService(c.CommandRepo, c.RoomRepo, c.RoomService, c.MessageService, c.UserRepo, c.WebhookService, c.WebhookDispatcher, c.RateLimiter, c.Config, c.Logger)
+	c.MessageService.SetCommandRouter(c.CommandService)
 
 	// Build Workers
-	c.UploadWorker = upload.NewWorker(c.QueueProvider, c.StorageProvider, c.UserRepo, c.ImageProcessor, c.Config, c.Logger, c.PubSubProvider)
+	c.UploadWorker = upload.NewWorker(c.QueueProvider, c.StorageProvider, c.UserRepo, c.RoomRepo, c.ImageProcessor, c.Config, c.Logger)
+	c.ExportWorker = export.NewWorker(c.QueueProvider, c.StorageProvider, c.ExportRepo, c.RoomRepo, c.UserRepo, c.EmailService, c.Config, c.Logger)
+	c.RetentionWorker = retention.NewWorker(c.RetentionRepo, c.MessageService, c.AuditService, c.Config, c.Logger)
+	c.AuditWorker = audit.NewWorker(c.AuditRepo, c.Config, c.Logger)
+	c.RoomWorker = room.NewWorker(c.RoomRepo, c.StorageProvider, c.Config, c.Logger)
+	c.OutboxWorker = outbox.NewWorker(c.OutboxRepo, c.EventLog, c.PubSubProvider, c.Config, c.Logger)
+	c.WebhookWorker = webhook.NewWorker(c.WebhookRepo, c.QueueProvider, c.Config, c.Logger)
 
 	// Build Handlers
 	c.AuthHandler = auth.NewHandler(c.AuthService, c.Logger, c.Validator)
 	c.UserHandler = user.NewHandler(c.UserService, c.Logger, c.Validator, c.Config, c.UploadService)
 	c.HealthHandler = health.NewHandler(c.HealthService, c.Logger)
-	c.RoomHandler = room.NewHandler(c.RoomService, c.Logger, c.Validator)
+	c.RoomHandler = room.NewHandler(c.RoomService, c.Logger, c.Validator, c.Config)
 	c.MessageHandler = message.NewHandler(c.MessageService, c.Logger, c.Validator)
+	c.ExportHandler = export.NewHandler(c.ExportService, c.Logger, c.Validator)
+	c.RetentionHandler = retention.NewHandler(c.RetentionService, c.Logger, c.Validator)
+	c.ReportHandler = report.NewHandler(c.ReportService, c.Logger, c.Validator)
+	c.AuditHandler = audit.NewHandler(c.AuditService, c.Logger)
+	c.WorkspaceHandler = workspace.NewHandler(c.WorkspaceService, c.Logger, c.Validator)
+	c.WebhookHandler = webhook.NewHandler(c.WebhookService, c.Logger, c.Validator)
+	c.BotHandler = bot.NewHandler(c.BotService, c.Logger, c.Validator)
+	c.CommandHandler = command.NewHandler(c.CommandService, c.Logger, c.Validator)
 
 	// Build Middleware
-	c.AuthMiddleware = middleware.NewAuthMiddleware(c.Config, c.UserRepo)
+	c.AuthMiddleware = middleware.NewAuthMiddleware(c.Config, c.UserRepo, c.BotService)
 
 	return nil
 }
@@ -0,0 +1,26 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrEventLogGap is returned when a reader asks to resume from a position the
+// log no longer covers, so some events in between can no longer be replayed.
+var ErrEventLogGap = errors.New("event log no longer covers the requested position")
+
+// LoggedEvent is an entry read back from an event log.
+type LoggedEvent struct {
+	Position string // Log-assigned, monotonically increasing within a channel
+	Payload  string
+}
+
+// EventLog is a bounded, replayable history of the events published on each
+// channel, kept alongside Pub/Sub so that reconnecting clients can catch up.
+type EventLog interface {
+	// Append records payload on channel and returns its position in the log.
+	Append(ctx context.Context, channel, payload string) (string, error)
+	// ReadAfter returns up to limit entries that follow position, oldest first.
+	// It returns ErrEventLogGap if entries after position have been trimmed.
+	ReadAfter(ctx context.Context, channel, position string, limit int) ([]*LoggedEvent, error)
+}
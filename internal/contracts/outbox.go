@@ -0,0 +1,14 @@
+package contracts
+
+import "time"
+
+// OutboxEvent is a real-time event stored in the same transaction as the
+// change it describes and relayed to Pub/Sub afterwards. The ID is carried in
+// the published envelope so clients can drop redeliveries.
+type OutboxEvent struct {
+	ID        string
+	Channel   string
+	Payload   string
+	Attempts  int
+	CreatedAt time.Time
+}
@@ -0,0 +1,20 @@
+package contracts
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimiter defines the contract for limits that must hold across every API
+// instance, so implementations keep their state in shared storage.
+type RateLimiter interface {
+	// AcquireSlot claims key for the given window. If the key is already held it
+	// reports how long remains until it can be claimed again.
+	AcquireSlot(ctx context.Context, key string, window time.Duration) (allowed bool, retryAfter time.Duration, err error)
+	// Allow counts a hit against a fixed window and reports whether the limit still holds.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (allowed bool, retryAfter time.Duration, err error)
+	// ReleaseSlot frees a slot claimed with AcquireSlot before its window ends.
+	ReleaseSlot(ctx context.Context, key string) error
+	// Refund takes back a hit counted by Allow, for work that was not done.
+	Refund(ctx context.Context, key string) error
+}
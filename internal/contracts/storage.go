@@ -3,6 +3,7 @@ package contracts
 import (
 "context"
 "io"
+"time"
 )
 
 // FileStorage defines the interface for a cloud file storage system.
@@ -11,5 +12,6 @@ type FileStorage interface {
 	Download(ctx context.Context, key string) ([]byte, error)
 	Delete(ctx context.Context, key string) error
 	GetPublicURL(key string) string
+	GetPresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
 	FileExists(ctx context.Context, key string) (bool, error)
 }
\ No newline at end of file
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionRetentionPolicyUpdated = "retention.policy_updated"
+	ActionRetentionPurge         = "retention.purge"
+	ActionReportCreated          = "report.created"
+	ActionReportAssigned         = "report.assigned"
+	ActionReportResolved         = "report.resolved"
+	ActionMessageDeleted         = "message.deleted"
+	ActionMemberRemoved          = "member.removed"
+	ActionMemberRoleChanged      = "member.role_changed"
+	ActionOwnershipTransferred   = "member.ownership_transferred"
+	ActionMemberBanned           = "member.banned"
+	ActionMemberUnbanned         = "member.unbanned"
+	ActionMemberMuted            = "member.muted"
+	ActionMemberUnmuted          = "member.unmuted"
+	ActionRoomSettingsUpdated    = "room.settings_updated"
+	ActionRoomArchived           = "room.archived"
+	ActionRoomUnarchived         = "room.unarchived"
+	ActionRoomDeleted            = "room.deleted"
+	ActionRoomRestored           = "room.restored"
+	ActionInviteLinkCreated      = "invite_link.created"
+	ActionInviteLinkRevoked      = "invite_link.revoked"
+	ActionWebhookCreated         = "webhook.created"
+	ActionWebhookUpdated         = "webhook.updated"
+	ActionWebhookDeleted         = "webhook.deleted"
+	ActionWebhookSecretRotated   = "webhook.secret_rotated"
+	ActionUserSuspended          = "user.suspended"
+)
+
+// Entry is a single record of an administrative or automated action. Entries
+// are append-only; they are only ever removed by the retention purge.
+type Entry struct {
+	ID         string
+	RoomID     *string
+	ActorID    *string // Nil when the action was performed by the system
+	Action     string
+	TargetType string
+	TargetID   string
+	Metadata   map[string]any
+	Before     map[string]any // Values the action changed, as they were
+	After      map[string]any // Values the action changed, as they became
+	IPAddress  string         // Client IP of the request that caused the action; empty for system actions
+	CreatedAt  time.Time
+	Actor      *types.BasicUser // Populated when listing entries
+}
+
+// NewEntry creates an audit entry for an action on a target. An empty actorID
+// marks the entry as a system action.
+func NewEntry(roomID, actorID, action, targetType, targetID string, metadata map[string]any) *Entry {
+	e := &Entry{
+		ID:         uuid.NewString(),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Metadata:   metadata,
+	}
+	if roomID != "" {
+		e.RoomID = &roomID
+	}
+	if actorID != "" {
+		e.ActorID = &actorID
+	}
+	return e
+}
+
+// WithChanges records the before and after values of an update, keeping only
+// the keys whose value actually changed.
+func (e *Entry) WithChanges(before, after map[string]any) *Entry {
+	e.Before = map[string]any{}
+	e.After = map[string]any{}
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !reflect.DeepEqual(old, value) {
+			e.Before[key] = before[key]
+			e.After[key] = value
+		}
+	}
+	for key, old := range before {
+		if _, ok := after[key]; !ok {
+			e.Before[key] = old
+		}
+	}
+	return e
+}
+
+// HasChanges reports whether WithChanges found anything that changed.
+func (e *Entry) HasChanges() bool {
+	return len(e.Before) > 0 || len(e.After) > 0
+}
+
+// ListFilter narrows and pages a room's audit log.
+type ListFilter struct {
+	RoomID   string
+	Action   string
+	ActorID  string
+	TargetID string
+	Since    *time.Time
+	Until    *time.Time
+	Cursor   *response.Cursor
+	Limit    int
+}
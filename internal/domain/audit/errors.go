@@ -0,0 +1,7 @@
+package audit
+
+import "github.com/purushothdl/gochat-backend/pkg/errors"
+
+var (
+	ErrInvalidTimeRange = errors.New("INVALID_TIME_RANGE", "since and until must be RFC 3339 timestamps, with since before until", 400)
+)
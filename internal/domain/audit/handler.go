@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	authMiddleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+type Handler struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+func NewHandler(service *Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListRoomAudit handles GET /api/rooms/{room_id}/audit?action=&actor_id=&target_id=&since=&until=&cursor=&limit=
+func (h *Handler) ListRoomAudit(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	cursor, err := response.DecodeCursor(query.Get("cursor"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	filter := ListFilter{
+		RoomID:   chi.URLParam(r, "room_id"),
+		Action:   query.Get("action"),
+		ActorID:  query.Get("actor_id"),
+		TargetID: query.Get("target_id"),
+		Cursor:   cursor,
+		Limit:    response.ParseLimit(r, 25, 100),
+	}
+	if filter.Since, err = parseOptionalTime(query.Get("since")); err != nil {
+		response.Error(w, 0, ErrInvalidTimeRange)
+		return
+	}
+	if filter.Until, err = parseOptionalTime(query.Get("until")); err != nil {
+		response.Error(w, 0, ErrInvalidTimeRange)
+		return
+	}
+
+	page, err := h.service.ListRoomEntries(r.Context(), actorID, filter)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, page)
+}
+
+func parseOptionalTime(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,12 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// RoomProvider defines the methods the audit service needs about rooms.
+type RoomProvider interface {
+	GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error)
+}
@@ -0,0 +1,13 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	CreateEntry(ctx context.Context, entry *Entry) error
+	ListEntries(ctx context.Context, filter ListFilter) ([]*Entry, error)
+	// PurgeEntriesBatch deletes up to limit entries created before cutoff, returning how many were deleted.
+	PurgeEntriesBatch(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type EntryResponse struct {
+	ID         string           `json:"id"`
+	RoomID     *string          `json:"room_id,omitempty"`
+	ActorID    *string          `json:"actor_id"`
+	Actor      *types.BasicUser `json:"actor,omitempty"`
+	Action     string           `json:"action"`
+	TargetType string           `json:"target_type"`
+	TargetID   string           `json:"target_id"`
+	Metadata   map[string]any   `json:"metadata"`
+	Before     map[string]any   `json:"before,omitempty"`
+	After      map[string]any   `json:"after,omitempty"`
+	IPAddress  string           `json:"ip_address,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+func toEntryResponse(e *Entry) *EntryResponse {
+	return &EntryResponse{
+		ID:         e.ID,
+		RoomID:     e.RoomID,
+		ActorID:    e.ActorID,
+		Actor:      e.Actor,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		Metadata:   e.Metadata,
+		Before:     e.Before,
+		After:      e.After,
+		IPAddress:  e.IPAddress,
+		CreatedAt:  e.CreatedAt,
+	}
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/authz"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/pkg/utils/httputil"
+)
+
+type Service struct {
+	auditRepo Repository
+	roomProv  RoomProvider
+	logger    *slog.Logger
+}
+
+func NewService(auditRepo Repository, roomProv RoomProvider, logger *slog.Logger) *Service {
+	return &Service{
+		auditRepo: auditRepo,
+		roomProv:  roomProv,
+		logger:    logger,
+	}
+}
+
+// Record persists an audit entry. Failures are logged rather than returned so
+// that an audit outage never blocks the action being audited. Entries caused
+// by an HTTP request are stamped with the request's client IP.
+func (s *Service) Record(ctx context.Context, entry *Entry) {
+	if entry.IPAddress == "" {
+		entry.IPAddress = httputil.ClientIPFromContext(ctx)
+	}
+	if err := s.auditRepo.CreateEntry(ctx, entry); err != nil {
+		s.logger.Error("failed to record audit entry", "error", err, "action", entry.Action, "target_id", entry.TargetID)
+	}
+}
+
+// ListRoomEntries returns a page of a room's audit log, newest first, to a
+// member allowed to view it.
+func (s *Service) ListRoomEntries(ctx context.Context, actorID string, filter ListFilter) (*response.CursorPage[*EntryResponse], error) {
+	membership, err := s.roomProv.GetMembershipInfo(ctx, filter.RoomID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authz.Require(membership.Role, nil, authz.ViewAuditLog); err != nil {
+		return nil, err
+	}
+	if filter.Since != nil && filter.Until != nil && !filter.Since.Before(*filter.Until) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	entries, err := s.auditRepo.ListEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*EntryResponse, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, toEntryResponse(e))
+	}
+	return response.NewCursorPage(items, limit, func(e *EntryResponse) response.Cursor {
+		return response.Cursor{CreatedAt: e.CreatedAt, ID: e.ID}
+	}), nil
+}
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+)
+
+// Worker periodically deletes audit entries older than the configured retention.
+type Worker struct {
+	auditRepo Repository
+	config    *config.Config
+	logger    *slog.Logger
+}
+
+func NewWorker(auditRepo Repository, config *config.Config, logger *slog.Logger) *Worker {
+	return &Worker{
+		auditRepo: auditRepo,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	if w.config.Audit.RetentionDays <= 0 {
+		w.logger.Info("audit retention disabled; entries are kept forever")
+		return
+	}
+	w.logger.Info("starting audit retention worker...", "interval", w.config.Audit.PurgeInterval, "retention_days", w.config.Audit.RetentionDays)
+
+	ticker := time.NewTicker(w.config.Audit.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("audit retention worker shutting down")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce deletes expired entries in batches so that no single statement
+// holds locks on the audit table for long.
+func (w *Worker) runOnce(ctx context.Context) {
+	cutoff := time.Now().AddDate(0, 0, -w.config.Audit.RetentionDays)
+
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := w.auditRepo.PurgeEntriesBatch(ctx, cutoff, w.config.Audit.PurgeBatchSize)
+		if err != nil {
+			w.logger.Error("failed to purge audit entries", "error", err, "deleted_so_far", total)
+			return
+		}
+		total += deleted
+		if deleted < int64(w.config.Audit.PurgeBatchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.Info("purged expired audit entries", "deleted", total, "cutoff", cutoff)
+	}
+}
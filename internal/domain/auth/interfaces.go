@@ -32,6 +32,11 @@ type NotificationService interface {
     SendPasswordResetEmail(ctx context.Context, userID, email string) error
 }
 
+// WorkspaceJoiner enrolls new accounts in the default workspace and its default rooms.
+type WorkspaceJoiner interface {
+	JoinDefaultWorkspace(ctx context.Context, userID string) error
+}
+
 type EmailService interface {
 	SendPasswordResetEmail(ctx context.Context, recipientEmail, recipientName, resetLink string) error
 }
\ No newline at end of file
@@ -23,6 +23,7 @@ type Service struct {
 	logger            *slog.Logger
 	passwordResetRepo PasswordResetRepository
 	emailService      EmailService
+	workspaceJoiner   WorkspaceJoiner
 }
 
 func NewService(
@@ -30,6 +31,7 @@ func NewService(
 	userRepo UserRepository,
 	passwordResetRepo PasswordResetRepository,
 	emailService EmailService,
+	workspaceJoiner WorkspaceJoiner,
 	cfg *config.Config,
 	logger *slog.Logger,
 ) *Service {
@@ -38,6 +40,7 @@ func NewService(
 		userRepo:          userRepo,
 		passwordResetRepo: passwordResetRepo,
 		emailService:      emailService,
+		workspaceJoiner:   workspaceJoiner,
 		config:            cfg,
 		logger:            logger,
 	}
@@ -53,6 +56,10 @@ func (s *Service) Login(ctx context.Context, req LoginRequest, w http.ResponseWr
 	if err != nil {
 		return nil, ErrInvalidCredentials
 	}
+	// Bots authenticate with bot tokens only.
+	if user.IsBot {
+		return nil, ErrInvalidCredentials
+	}
 
 	// Get password hash and verify
 	hashedPassword, err := s.userRepo.GetPasswordHash(ctx, user.ID)
@@ -64,6 +71,10 @@ func (s *Service) Login(ctx context.Context, req LoginRequest, w http.ResponseWr
 		return nil, ErrInvalidCredentials
 	}
 
+	if user.IsSuspended(time.Now()) {
+		return nil, ErrAccountSuspended
+	}
+
 	// Update last login
 	s.userRepo.UpdateLastLogin(ctx, user.ID)
 
@@ -99,6 +110,11 @@ func (s *Service) Register(ctx context.Context, req RegisterRequest, w http.Resp
 		return nil, err
 	}
 
+	// The account is usable without a workspace, so a failure here does not fail sign-up.
+	if err := s.workspaceJoiner.JoinDefaultWorkspace(ctx, user.ID); err != nil {
+		s.logger.Error("failed to join default workspace", "user_id", user.ID, "error", err)
+	}
+
 	return s.generateAuthResponse(ctx, user, w, deviceInfo, ipAddress, userAgent)
 }
 
@@ -123,6 +139,10 @@ func (s *Service) RefreshAccessToken(ctx context.Context, refreshTokenString, de
 	}
 	s.logger.Info("Retrieved user info", "userID", user.ID, "email", user.Email)
 
+	if user.IsSuspended(time.Now()) {
+		return nil, ErrAccountSuspended
+	}
+
 	// Update token usage
 	if err := s.authRepo.UpdateRefreshTokenUsage(ctx, tokenHash); err != nil {
 		s.logger.Warn("Failed to update refresh token usage", "tokenHash", tokenHash[:8]+"...", "error", err)
@@ -171,7 +191,7 @@ func (s *Service) GetMe(ctx context.Context, userID string) (*MeResponse, error)
 // ForgotPassword generates a reset token and sends it via email.
 func (s *Service) ForgotPassword(ctx context.Context, email string) error {
 	user, err := s.userRepo.GetByEmailShared(ctx, email)
-	if err != nil {
+	if err != nil || user.IsBot {
 		// Do not leak whether the user exists. Log internally and return success.
 		s.logger.Info("password reset requested for non-existent user", "email", email)
 		return nil
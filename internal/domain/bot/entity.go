@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// Bot is a user account created and owned by another user. A bot cannot sign
+// in; it authenticates with bot tokens, and each token only reaches the API
+// routes its scopes allow.
+type Bot struct {
+	ID        string
+	OwnerID   string
+	Name      string
+	ImageURL  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewBot creates a new Bot entity owned by ownerID.
+func NewBot(ownerID, name, imageURL string) *Bot {
+	return &Bot{
+		ID:       uuid.NewString(),
+		OwnerID:  ownerID,
+		Name:     name,
+		ImageURL: imageURL,
+	}
+}
+
+// Token is a long-lived credential for a bot. Only its hash is stored.
+type Token struct {
+	ID         string
+	BotID      string
+	OwnerID    string // The bot's owner; populated by FindTokenByHash
+	Name       string
+	TokenHash  string
+	Scopes     []types.BotScope
+	CreatedBy  *string
+	ExpiresAt  *time.Time // Tokens without an expiry last until they are revoked
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// NewToken creates a token for a bot with the hash of its plaintext.
+func NewToken(botID, createdBy, name, tokenHash string, scopes []types.BotScope, expiresAt *time.Time) *Token {
+	return &Token{
+		ID:        uuid.NewString(),
+		BotID:     botID,
+		Name:      name,
+		TokenHash: tokenHash,
+		Scopes:    scopes,
+		CreatedBy: &createdBy,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// IsActive reports whether the token can still be used at the given time.
+func (t *Token) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
@@ -0,0 +1,15 @@
+package bot
+
+import "github.com/purushothdl/gochat-backend/pkg/errors"
+
+var (
+	ErrBotNotFound     = errors.New("BOT_NOT_FOUND", "The requested bot was not found", 404)
+	ErrTokenNotFound   = errors.New("BOT_TOKEN_NOT_FOUND", "The requested bot token was not found", 404)
+	ErrInvalidToken    = errors.New("INVALID_BOT_TOKEN", "The bot token is invalid, expired or revoked", 401)
+	ErrInvalidImageURL = errors.New("INVALID_IMAGE_URL", "The image URL must be an absolute http or https URL", 400)
+	ErrInvalidScope    = errors.New("INVALID_BOT_SCOPE", "The token asks for an unknown scope", 400)
+	ErrTooManyBots     = errors.New("TOO_MANY_BOTS", "You have reached the maximum number of bots", 409)
+	ErrTooManyTokens   = errors.New("TOO_MANY_BOT_TOKENS", "The bot has reached the maximum number of active tokens", 409)
+	ErrBotOwnsRooms    = errors.New("BOT_OWNS_ROOMS", "The bot owns rooms; transfer their ownership first", 409)
+	ErrOwnerSuspended  = errors.New("BOT_OWNER_SUSPENDED", "The bot's owner is suspended", 403)
+)
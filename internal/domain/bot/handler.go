@@ -0,0 +1,198 @@
+package bot
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/validator"
+	authMiddleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+type Handler struct {
+	service   *Service
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewHandler(service *Service, logger *slog.Logger, v *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		logger:    logger,
+		validator: v,
+	}
+}
+
+// CreateBot handles POST /api/bots
+func (h *Handler) CreateBot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	var req CreateBotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	bot, err := h.service.CreateBot(r.Context(), userID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, bot)
+}
+
+// ListBots handles GET /api/bots
+func (h *Handler) ListBots(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	bots, err := h.service.ListBots(r.Context(), userID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, bots)
+}
+
+// GetBot handles GET /api/bots/{bot_id}
+func (h *Handler) GetBot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	botID := chi.URLParam(r, "bot_id")
+
+	bot, err := h.service.GetBot(r.Context(), userID, botID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, bot)
+}
+
+// UpdateBot handles PATCH /api/bots/{bot_id}
+func (h *Handler) UpdateBot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	botID := chi.URLParam(r, "bot_id")
+
+	var req UpdateBotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	bot, err := h.service.UpdateBot(r.Context(), userID, botID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, bot)
+}
+
+// DeleteBot handles DELETE /api/bots/{bot_id}
+func (h *Handler) DeleteBot(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	botID := chi.URLParam(r, "bot_id")
+
+	if err := h.service.DeleteBot(r.Context(), userID, botID); err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateToken handles POST /api/bots/{bot_id}/tokens
+func (h *Handler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	botID := chi.URLParam(r, "bot_id")
+
+	var req CreateTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	token, err := h.service.CreateToken(r.Context(), userID, botID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, token)
+}
+
+// ListTokens handles GET /api/bots/{bot_id}/tokens
+func (h *Handler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	botID := chi.URLParam(r, "bot_id")
+
+	tokens, err := h.service.ListTokens(r.Context(), userID, botID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, tokens)
+}
+
+// RevokeToken handles DELETE /api/bots/{bot_id}/tokens/{token_id}
+func (h *Handler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	botID := chi.URLParam(r, "bot_id")
+	tokenID := chi.URLParam(r, "token_id")
+
+	if err := h.service.RevokeToken(r.Context(), userID, botID, tokenID); err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,18 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/workspace"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// UserProvider looks up bot owners, whose suspension also locks out their bots.
+type UserProvider interface {
+	GetByIDShared(ctx context.Context, id string) (*types.User, error)
+}
+
+// WorkspaceProvider checks that a bot's owner belongs to the workspace the bot is created in.
+type WorkspaceProvider interface {
+	FindMember(ctx context.Context, workspaceID, userID string) (*workspace.Member, error)
+}
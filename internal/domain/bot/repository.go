@@ -0,0 +1,28 @@
+package bot
+
+import "context"
+
+type Repository interface {
+	// CreateBot stores the bot's account and adds it to the workspace as a
+	// member. Unlike people, bots do not join the workspace's default rooms.
+	CreateBot(ctx context.Context, bot *Bot, workspaceID string) error
+	FindBot(ctx context.Context, botID string) (*Bot, error)
+	ListOwnedBots(ctx context.Context, ownerID string) ([]*Bot, error)
+	CountOwnedBots(ctx context.Context, ownerID string) (int, error)
+	UpdateBot(ctx context.Context, bot *Bot) error
+	// DeleteBot deletes the bot's account, revokes its tokens and takes it out
+	// of its rooms and workspaces. It returns ErrBotOwnsRooms if the bot still
+	// owns a room.
+	DeleteBot(ctx context.Context, botID string) error
+
+	// Token Methods
+	CreateToken(ctx context.Context, token *Token) error
+	ListTokens(ctx context.Context, botID string) ([]*Token, error)
+	CountActiveTokens(ctx context.Context, botID string) (int, error)
+	RevokeToken(ctx context.Context, botID, tokenID string) error
+	// FindTokenByHash returns ErrInvalidToken unless the token exists and its
+	// bot and the bot's owner have not been deleted. Expiry and revocation are
+	// left to the caller.
+	FindTokenByHash(ctx context.Context, tokenHash string) (*Token, error)
+	TouchToken(ctx context.Context, tokenID string) error
+}
@@ -0,0 +1,23 @@
+package bot
+
+import "github.com/purushothdl/gochat-backend/internal/shared/types"
+
+// CreateBotRequest creates a bot in one of the owner's workspaces.
+type CreateBotRequest struct {
+	WorkspaceID string `json:"workspace_id" validate:"required,uuid"`
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	ImageURL    string `json:"image_url" validate:"omitempty,url,max=500"`
+}
+
+type UpdateBotRequest struct {
+	Name     *string `json:"name" validate:"omitempty,min=1,max=100"`
+	ImageURL *string `json:"image_url" validate:"omitempty,max=500"` // An empty string clears the image
+}
+
+// CreateTokenRequest issues a token for a bot. Tokens without ExpiresInDays
+// last until they are revoked.
+type CreateTokenRequest struct {
+	Name          string           `json:"name" validate:"required,min=1,max=80"`
+	Scopes        []types.BotScope `json:"scopes" validate:"required,min=1,dive,required"`
+	ExpiresInDays *int             `json:"expires_in_days" validate:"omitempty,min=1,max=3650"`
+}
@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type BotResponse struct {
+	ID        string    `json:"id"`
+	OwnerID   string    `json:"owner_id"`
+	Name      string    `json:"name"`
+	ImageURL  string    `json:"image_url"`
+	IsBot     bool      `json:"is_bot"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TokenResponse struct {
+	ID         string           `json:"id"`
+	BotID      string           `json:"bot_id"`
+	Name       string           `json:"name"`
+	Token      string           `json:"token,omitempty"` // Only returned when the token is created
+	Scopes     []types.BotScope `json:"scopes"`
+	CreatedBy  *string          `json:"created_by"`
+	ExpiresAt  *time.Time       `json:"expires_at"`
+	LastUsedAt *time.Time       `json:"last_used_at"`
+	RevokedAt  *time.Time       `json:"revoked_at"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+func toBotResponse(b *Bot) *BotResponse {
+	return &BotResponse{
+		ID:        b.ID,
+		OwnerID:   b.OwnerID,
+		Name:      b.Name,
+		ImageURL:  b.ImageURL,
+		IsBot:     true,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
+
+func toTokenResponse(t *Token, token string) *TokenResponse {
+	return &TokenResponse{
+		ID:         t.ID,
+		BotID:      t.BotID,
+		Name:       t.Name,
+		Token:      token,
+		Scopes:     t.Scopes,
+		CreatedBy:  t.CreatedBy,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		RevokedAt:  t.RevokedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
@@ -0,0 +1,265 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/domain/workspace"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+	pointer "github.com/purushothdl/gochat-backend/pkg/utils/pointer"
+	"github.com/purushothdl/gochat-backend/pkg/utils/tokenutil"
+)
+
+type Service struct {
+	botRepo       Repository
+	userProv      UserProvider
+	workspaceProv WorkspaceProvider
+	config        *config.Config
+	logger        *slog.Logger
+}
+
+func NewService(botRepo Repository, userProv UserProvider, workspaceProv WorkspaceProvider, cfg *config.Config, logger *slog.Logger) *Service {
+	return &Service{
+		botRepo:       botRepo,
+		userProv:      userProv,
+		workspaceProv: workspaceProv,
+		config:        cfg,
+		logger:        logger,
+	}
+}
+
+// CreateBot creates a bot owned by the actor and adds it to one of the actor's
+// workspaces. From there it can be invited to rooms or join public ones like
+// any other member.
+func (s *Service) CreateBot(ctx context.Context, ownerID string, req CreateBotRequest) (*BotResponse, error) {
+	if err := validateImageURL(req.ImageURL); err != nil {
+		return nil, err
+	}
+	if _, err := s.workspaceProv.FindMember(ctx, req.WorkspaceID, ownerID); err != nil {
+		if err == workspace.ErrMemberNotFound {
+			return nil, workspace.ErrNotMember
+		}
+		return nil, err
+	}
+
+	count, err := s.botRepo.CountOwnedBots(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= s.config.Bot.MaxPerOwner {
+		return nil, ErrTooManyBots
+	}
+
+	b := NewBot(ownerID, req.Name, req.ImageURL)
+	if err := s.botRepo.CreateBot(ctx, b, req.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("bot created", "bot_id", b.ID, "owner_id", ownerID, "workspace_id", req.WorkspaceID)
+	return toBotResponse(b), nil
+}
+
+// ListBots returns the bots the user owns.
+func (s *Service) ListBots(ctx context.Context, ownerID string) ([]*BotResponse, error) {
+	bots, err := s.botRepo.ListOwnedBots(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*BotResponse, len(bots))
+	for i, b := range bots {
+		items[i] = toBotResponse(b)
+	}
+	return items, nil
+}
+
+func (s *Service) GetBot(ctx context.Context, ownerID, botID string) (*BotResponse, error) {
+	b, err := s.findOwnedBot(ctx, ownerID, botID)
+	if err != nil {
+		return nil, err
+	}
+	return toBotResponse(b), nil
+}
+
+// UpdateBot changes a bot's name or image.
+func (s *Service) UpdateBot(ctx context.Context, ownerID, botID string, req UpdateBotRequest) (*BotResponse, error) {
+	b, err := s.findOwnedBot(ctx, ownerID, botID)
+	if err != nil {
+		return nil, err
+	}
+
+	pointer.UpdatePointerField(&b.Name, req.Name)
+	if req.ImageURL != nil {
+		if err := validateImageURL(*req.ImageURL); err != nil {
+			return nil, err
+		}
+		b.ImageURL = *req.ImageURL
+	}
+	if err := s.botRepo.UpdateBot(ctx, b); err != nil {
+		return nil, err
+	}
+	return toBotResponse(b), nil
+}
+
+// DeleteBot deletes a bot along with its tokens and memberships. Its messages
+// stay in their rooms.
+func (s *Service) DeleteBot(ctx context.Context, ownerID, botID string) error {
+	if _, err := s.findOwnedBot(ctx, ownerID, botID); err != nil {
+		return err
+	}
+	if err := s.botRepo.DeleteBot(ctx, botID); err != nil {
+		return err
+	}
+
+	s.logger.Info("bot deleted", "bot_id", botID, "owner_id", ownerID)
+	return nil
+}
+
+// CreateToken issues a new token for a bot. The token is only returned here;
+// afterwards just its hash is kept.
+func (s *Service) CreateToken(ctx context.Context, ownerID, botID string, req CreateTokenRequest) (*TokenResponse, error) {
+	if _, err := s.findOwnedBot(ctx, ownerID, botID); err != nil {
+		return nil, err
+	}
+	scopes, err := validateScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.botRepo.CountActiveTokens(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= s.config.Bot.MaxTokensPerBot {
+		return nil, ErrTooManyTokens
+	}
+
+	secret, _, err := tokenutil.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate bot token: %w", err)
+	}
+	plaintext := types.BotTokenPrefix + secret
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays != nil {
+		expires := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		expiresAt = &expires
+	}
+	token := NewToken(botID, ownerID, req.Name, tokenutil.Hash(plaintext), scopes, expiresAt)
+	if err := s.botRepo.CreateToken(ctx, token); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("bot token created", "bot_id", botID, "token_id", token.ID, "scopes", scopes)
+	return toTokenResponse(token, plaintext), nil
+}
+
+// ListTokens returns a bot's tokens, newest first, including revoked ones.
+func (s *Service) ListTokens(ctx context.Context, ownerID, botID string) ([]*TokenResponse, error) {
+	if _, err := s.findOwnedBot(ctx, ownerID, botID); err != nil {
+		return nil, err
+	}
+	tokens, err := s.botRepo.ListTokens(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*TokenResponse, len(tokens))
+	for i, t := range tokens {
+		items[i] = toTokenResponse(t, "")
+	}
+	return items, nil
+}
+
+// RevokeToken stops a token from being used. It takes effect on the token's
+// next request.
+func (s *Service) RevokeToken(ctx context.Context, ownerID, botID, tokenID string) error {
+	if _, err := s.findOwnedBot(ctx, ownerID, botID); err != nil {
+		return err
+	}
+	if err := s.botRepo.RevokeToken(ctx, botID, tokenID); err != nil {
+		return err
+	}
+
+	s.logger.Info("bot token revoked", "bot_id", botID, "token_id", tokenID)
+	return nil
+}
+
+// AuthenticateBot resolves a bot token to the bot it belongs to and the
+// scopes it carries. Tokens stop working when they expire or are revoked, and
+// while the bot's owner is suspended.
+func (s *Service) AuthenticateBot(ctx context.Context, token string) (*types.BotIdentity, error) {
+	t, err := s.botRepo.FindTokenByHash(ctx, tokenutil.Hash(token))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !t.IsActive(now) {
+		return nil, ErrInvalidToken
+	}
+
+	owner, err := s.userProv.GetByIDShared(ctx, t.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot owner: %w", err)
+	}
+	if owner.IsSuspended(now) {
+		return nil, ErrOwnerSuspended
+	}
+
+	// last_used_at only needs to be roughly right, so busy tokens are not
+	// written on every request.
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) >= s.config.Bot.TokenTouchInterval {
+		if err := s.botRepo.TouchToken(ctx, t.ID); err != nil {
+			s.logger.Error("failed to record bot token use", "error", err, "token_id", t.ID)
+		}
+	}
+
+	return &types.BotIdentity{
+		BotID:   t.BotID,
+		OwnerID: t.OwnerID,
+		TokenID: t.ID,
+		Scopes:  t.Scopes,
+	}, nil
+}
+
+// findOwnedBot hides other users' bots behind ErrBotNotFound.
+func (s *Service) findOwnedBot(ctx context.Context, ownerID, botID string) (*Bot, error) {
+	b, err := s.botRepo.FindBot(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	if b.OwnerID != ownerID {
+		return nil, ErrBotNotFound
+	}
+	return b, nil
+}
+
+func validateImageURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.ParseRequestURI(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidImageURL
+	}
+	return nil
+}
+
+// validateScopes rejects unknown scopes and drops duplicates.
+func validateScopes(scopes []types.BotScope) ([]types.BotScope, error) {
+	valid := make([]types.BotScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(types.BotScopes, scope) {
+			return nil, ErrInvalidScope
+		}
+		if !slices.Contains(valid, scope) {
+			valid = append(valid, scope)
+		}
+	}
+	return valid, nil
+}
@@ -0,0 +1,199 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+const (
+	maxArgs           = 10
+	maxChoices        = 25
+	maxArgDescription = 100
+	maxChoiceLength   = 100
+)
+
+var userArgPattern = regexp.MustCompile(`^<@([0-9a-fA-F-]{36})>$`)
+
+// parseArgs reads the text after a command's name into its arguments, keyed
+// by name. Users are given by their id, integers as ints and everything else
+// as strings; optional arguments that were left out are absent.
+func parseArgs(name string, args []Arg, text string) (map[string]any, error) {
+	values := make(map[string]any, len(args))
+	scan := &scanner{rest: text}
+	for _, arg := range args {
+		var raw string
+		if arg.Type == ArgText {
+			raw = strings.TrimSpace(scan.rest)
+			scan.rest = ""
+		} else {
+			token, err := scan.next()
+			if err != nil {
+				return nil, NewUsageError(err.Error(), usage(name, args))
+			}
+			raw = token
+		}
+
+		if raw == "" {
+			if arg.Required {
+				return nil, NewUsageError(fmt.Sprintf("Missing %s.", arg.Name), usage(name, args))
+			}
+			continue
+		}
+		value, err := convertArg(arg, raw)
+		if err != nil {
+			return nil, NewUsageError(err.Error(), usage(name, args))
+		}
+		values[arg.Name] = value
+	}
+
+	if strings.TrimSpace(scan.rest) != "" {
+		return nil, NewUsageError("Too many arguments.", usage(name, args))
+	}
+	return values, nil
+}
+
+func convertArg(arg Arg, raw string) (any, error) {
+	switch arg.Type {
+	case ArgUser:
+		id := raw
+		if match := userArgPattern.FindStringSubmatch(raw); match != nil {
+			id = match[1]
+		}
+		if err := uuid.Validate(id); err != nil {
+			return nil, fmt.Errorf("%s must mention a user.", arg.Name)
+		}
+		return strings.ToLower(id), nil
+	case ArgInteger:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a whole number.", arg.Name)
+		}
+		return n, nil
+	case ArgChoice:
+		for _, choice := range arg.Choices {
+			if strings.EqualFold(choice, raw) {
+				return choice, nil
+			}
+		}
+		return nil, fmt.Errorf("%s must be one of: %s.", arg.Name, strings.Join(arg.Choices, ", "))
+	default:
+		return raw, nil
+	}
+}
+
+// scanner splits text into words, treating a phrase in double quotes as one.
+type scanner struct {
+	rest string
+}
+
+// next returns the next word, or "" once the text is used up.
+func (s *scanner) next() (string, error) {
+	s.rest = strings.TrimLeftFunc(s.rest, unicode.IsSpace)
+	if s.rest == "" {
+		return "", nil
+	}
+
+	if s.rest[0] == '"' {
+		end := strings.IndexByte(s.rest[1:], '"')
+		if end < 0 {
+			return "", fmt.Errorf("A quote is not closed.")
+		}
+		token := s.rest[1 : end+1]
+		s.rest = s.rest[end+2:]
+		return token, nil
+	}
+
+	end := strings.IndexFunc(s.rest, unicode.IsSpace)
+	if end < 0 {
+		end = len(s.rest)
+	}
+	token := s.rest[:end]
+	s.rest = s.rest[end:]
+	return token, nil
+}
+
+// usage describes how a command is written, such as "/mute <user> [minutes] [reason...]".
+func usage(name string, args []Arg) string {
+	var b strings.Builder
+	b.WriteString("/" + name)
+	for _, arg := range args {
+		label := arg.Name
+		if arg.Type == ArgText {
+			label += "..."
+		}
+		if arg.Required {
+			b.WriteString(" <" + label + ">")
+		} else {
+			b.WriteString(" [" + label + "]")
+		}
+	}
+	return b.String()
+}
+
+// normalizeArgs checks a command's argument definitions and fills in the
+// autocomplete source for user and choice arguments that do not set one.
+func normalizeArgs(args []Arg) ([]Arg, error) {
+	if len(args) > maxArgs {
+		return nil, NewInvalidArgError("", fmt.Sprintf("A command can have at most %d arguments", maxArgs))
+	}
+
+	normalized := make([]Arg, 0, len(args))
+	names := make([]string, 0, len(args))
+	for i, arg := range args {
+		if !types.ValidCommandName(arg.Name) {
+			return nil, NewInvalidArgError(arg.Name, "Argument names start with a letter and contain only lowercase letters, digits, - and _")
+		}
+		if slices.Contains(names, arg.Name) {
+			return nil, NewInvalidArgError(arg.Name, "Argument names must be unique")
+		}
+		names = append(names, arg.Name)
+
+		if !slices.Contains(ArgTypes, arg.Type) {
+			return nil, NewInvalidArgError(arg.Name, "The argument has an unknown type")
+		}
+		if utf8.RuneCountInString(arg.Description) > maxArgDescription {
+			return nil, NewInvalidArgError(arg.Name, fmt.Sprintf("Argument descriptions can be at most %d characters", maxArgDescription))
+		}
+		if arg.Type == ArgText && i != len(args)-1 {
+			return nil, NewInvalidArgError(arg.Name, "Only the last argument can be text")
+		}
+		if arg.Required && i > 0 && !args[i-1].Required {
+			return nil, NewInvalidArgError(arg.Name, "Required arguments cannot follow optional ones")
+		}
+
+		if arg.Type == ArgChoice {
+			if len(arg.Choices) == 0 || len(arg.Choices) > maxChoices {
+				return nil, NewInvalidArgError(arg.Name, fmt.Sprintf("A choice argument needs between 1 and %d choices", maxChoices))
+			}
+			for _, choice := range arg.Choices {
+				if choice == "" || utf8.RuneCountInString(choice) > maxChoiceLength || strings.ContainsFunc(choice, unicode.IsSpace) {
+					return nil, NewInvalidArgError(arg.Name, fmt.Sprintf("Choices must be single words of at most %d characters", maxChoiceLength))
+				}
+			}
+		} else {
+			arg.Choices = nil
+		}
+
+		switch {
+		case arg.Autocomplete == AutocompleteNone && arg.Type == ArgUser:
+			arg.Autocomplete = AutocompleteMembers
+		case arg.Autocomplete == AutocompleteNone && arg.Type == ArgChoice:
+			arg.Autocomplete = AutocompleteChoices
+		case arg.Autocomplete == AutocompleteMembers && arg.Type != ArgUser,
+			arg.Autocomplete == AutocompleteChoices && arg.Type != ArgChoice:
+			return nil, NewInvalidArgError(arg.Name, "The autocomplete source does not fit the argument's type")
+		case arg.Autocomplete != AutocompleteNone && arg.Autocomplete != AutocompleteMembers && arg.Autocomplete != AutocompleteChoices:
+			return nil, NewInvalidArgError(arg.Name, "The argument has an unknown autocomplete source")
+		}
+		normalized = append(normalized, arg)
+	}
+	return normalized, nil
+}
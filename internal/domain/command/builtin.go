@@ -0,0 +1,183 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/domain/room"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+const (
+	maxTopicLength     = 250
+	defaultMuteMinutes = 60
+	maxMuteMinutes     = 43200 // 30 days, as for the mute endpoint
+	minPollOptions     = 2
+	maxPollOptions     = 10
+)
+
+// builtin is a command the server answers itself. Built-ins are available in
+// every room and their names cannot be registered.
+type builtin struct {
+	name        string
+	description string
+	args        []Arg
+	run         func(ctx context.Context, b *builtin, inv *types.CommandInvocation, args map[string]any) (*reply, error)
+}
+
+// reply is what a built-in command produced: a text only the invoker sees, a
+// message it posted into the room, or both.
+type reply struct {
+	text    string
+	message *message.Message
+}
+
+func (b *builtin) usageError(msg string) error {
+	return NewUsageError(msg, usage(b.name, b.args))
+}
+
+func (s *Service) newBuiltins() []*builtin {
+	return []*builtin{
+		{
+			name:        "invite",
+			description: "Invite a user to the room",
+			args:        []Arg{{Name: "user", Type: ArgUser, Description: "Who to invite", Required: true}},
+			run:         s.runInvite,
+		},
+		{
+			name:        "kick",
+			description: "Remove a member from the room",
+			args:        []Arg{{Name: "user", Type: ArgUser, Description: "Who to remove", Required: true, Autocomplete: AutocompleteMembers}},
+			run:         s.runKick,
+		},
+		{
+			name:        "topic",
+			description: "Set the room's topic",
+			args:        []Arg{{Name: "topic", Type: ArgText, Description: "The new topic", Required: true}},
+			run:         s.runTopic,
+		},
+		{
+			name:        "mute",
+			description: "Stop a member from sending messages for a while",
+			args: []Arg{
+				{Name: "user", Type: ArgUser, Description: "Who to mute", Required: true, Autocomplete: AutocompleteMembers},
+				{Name: "minutes", Type: ArgInteger, Description: fmt.Sprintf("How long, %d minutes unless given", defaultMuteMinutes)},
+				{Name: "reason", Type: ArgText, Description: "Why, shown to the member"},
+			},
+			run: s.runMute,
+		},
+		{
+			name:        "poll",
+			description: "Ask the room a question",
+			args: []Arg{
+				{Name: "question", Type: ArgString, Description: "The question, in quotes if it has spaces", Required: true},
+				{Name: "options", Type: ArgText, Description: "The answers to choose from, each in quotes if it has spaces", Required: true},
+			},
+			run: s.runPoll,
+		},
+		{
+			name:        "me",
+			description: "Describe something you are doing",
+			args:        []Arg{{Name: "action", Type: ArgText, Description: "What you are doing", Required: true}},
+			run:         s.runMe,
+		},
+	}
+}
+
+// findBuiltin returns the built-in command with the given name, or nil.
+func (s *Service) findBuiltin(name string) *builtin {
+	for _, b := range s.builtins {
+		if b.name == name {
+			return b
+		}
+	}
+	return nil
+}
+
+func (s *Service) runInvite(ctx context.Context, b *builtin, inv *types.CommandInvocation, args map[string]any) (*reply, error) {
+	userID := args["user"].(string)
+	if _, err := s.rooms.InviteUser(ctx, inv.UserID, inv.RoomID, userID); err != nil {
+		return nil, err
+	}
+	return &reply{text: fmt.Sprintf("Invited <@%s> to the room.", userID)}, nil
+}
+
+func (s *Service) runKick(ctx context.Context, b *builtin, inv *types.CommandInvocation, args map[string]any) (*reply, error) {
+	userID := args["user"].(string)
+	if err := s.rooms.RemoveMember(ctx, inv.UserID, inv.RoomID, userID); err != nil {
+		return nil, err
+	}
+	return &reply{text: fmt.Sprintf("Removed <@%s> from the room.", userID)}, nil
+}
+
+func (s *Service) runTopic(ctx context.Context, b *builtin, inv *types.CommandInvocation, args map[string]any) (*reply, error) {
+	topic := args["topic"].(string)
+	if utf8.RuneCountInString(topic) > maxTopicLength {
+		return nil, b.usageError(fmt.Sprintf("The topic can be at most %d characters.", maxTopicLength))
+	}
+	if _, err := s.rooms.UpdateRoomSettings(ctx, inv.UserID, inv.RoomID, room.UpdateRoomSettingsRequest{Topic: &topic}); err != nil {
+		return nil, err
+	}
+	return &reply{text: "Topic updated."}, nil
+}
+
+func (s *Service) runMute(ctx context.Context, b *builtin, inv *types.CommandInvocation, args map[string]any) (*reply, error) {
+	userID := args["user"].(string)
+	minutes := defaultMuteMinutes
+	if n, ok := args["minutes"].(int); ok {
+		minutes = n
+	}
+	if minutes < 1 || minutes > maxMuteMinutes {
+		return nil, b.usageError(fmt.Sprintf("minutes must be between 1 and %d.", maxMuteMinutes))
+	}
+	reason, _ := args["reason"].(string)
+
+	req := room.MuteMemberRequest{DurationMinutes: minutes, Reason: reason}
+	if _, err := s.rooms.MuteMember(ctx, inv.UserID, inv.RoomID, userID, req); err != nil {
+		return nil, err
+	}
+	return &reply{text: fmt.Sprintf("Muted <@%s> for %d minutes.", userID, minutes)}, nil
+}
+
+// runPoll posts the question and its numbered options as a message from the
+// invoker, which members answer in the room.
+func (s *Service) runPoll(ctx context.Context, b *builtin, inv *types.CommandInvocation, args map[string]any) (*reply, error) {
+	var options []string
+	scan := &scanner{rest: args["options"].(string)}
+	for {
+		option, err := scan.next()
+		if err != nil {
+			return nil, b.usageError(err.Error())
+		}
+		if option == "" {
+			break
+		}
+		options = append(options, option)
+	}
+	if len(options) < minPollOptions || len(options) > maxPollOptions {
+		return nil, b.usageError(fmt.Sprintf("A poll needs between %d and %d options.", minPollOptions, maxPollOptions))
+	}
+
+	var content strings.Builder
+	content.WriteString("Poll: " + args["question"].(string))
+	for i, option := range options {
+		fmt.Fprintf(&content, "\n%d. %s", i+1, option)
+	}
+
+	msg, err := s.messages.SendTextMessage(ctx, inv.UserID, inv.RoomID, content.String(), inv.ClientMessageID)
+	if err != nil {
+		return nil, err
+	}
+	return &reply{message: msg}, nil
+}
+
+func (s *Service) runMe(ctx context.Context, b *builtin, inv *types.CommandInvocation, args map[string]any) (*reply, error) {
+	msg, err := s.messages.SendActionMessage(ctx, inv.UserID, inv.RoomID, args["action"].(string), inv.ClientMessageID)
+	if err != nil {
+		return nil, err
+	}
+	return &reply{message: msg}, nil
+}
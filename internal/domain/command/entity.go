@@ -0,0 +1,142 @@
+package command
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArgType is how an argument is read from the text after a command's name.
+type ArgType string
+
+const (
+	ArgString  ArgType = "string"  // One word, or a phrase in double quotes
+	ArgText    ArgType = "text"    // The rest of the line; only allowed as the last argument
+	ArgUser    ArgType = "user"    // A mention, <@user_id>
+	ArgInteger ArgType = "integer" // A whole number
+	ArgChoice  ArgType = "choice"  // One of the argument's choices
+)
+
+// ArgTypes lists every type an argument can have.
+var ArgTypes = []ArgType{ArgString, ArgText, ArgUser, ArgInteger, ArgChoice}
+
+// Autocomplete tells clients where to find suggestions for an argument.
+type Autocomplete string
+
+const (
+	AutocompleteNone    Autocomplete = ""
+	AutocompleteMembers Autocomplete = "members" // The room's members
+	AutocompleteChoices Autocomplete = "choices" // The argument's choices
+)
+
+// Arg describes one argument of a command. It is stored as JSON with the
+// command and returned as-is to clients.
+type Arg struct {
+	Name         string       `json:"name"`
+	Type         ArgType      `json:"type"`
+	Description  string       `json:"description,omitempty"`
+	Required     bool         `json:"required"`
+	Choices      []string     `json:"choices,omitempty"`
+	Autocomplete Autocomplete `json:"autocomplete,omitempty"`
+}
+
+// Source is what answers a command.
+type Source string
+
+const (
+	SourceBuiltin Source = "BUILTIN"
+	SourceBot     Source = "BOT"
+	SourceWebhook Source = "WEBHOOK"
+)
+
+// Command is a custom slash command, answered by the bot or outgoing webhook
+// that registered it. A bot's commands belong to a room the bot is in; an
+// outgoing webhook's commands share its room or workspace. Within a room, its
+// own commands take precedence over its workspace's.
+type Command struct {
+	ID          string
+	Name        string
+	Description string
+	Args        []Arg
+	RoomID      *string
+	WorkspaceID *string
+	BotID       *string
+	WebhookID   *string
+	CreatedBy   *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewBotCommand creates a command answered by a bot in one room.
+func NewBotCommand(roomID, botID, name, description string, args []Arg) *Command {
+	return &Command{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Description: description,
+		Args:        args,
+		RoomID:      &roomID,
+		BotID:       &botID,
+		CreatedBy:   &botID,
+	}
+}
+
+// NewWebhookCommand creates a command answered by an outgoing webhook, in the
+// webhook's room or, when roomID is nil, its workspace.
+func NewWebhookCommand(roomID, workspaceID *string, webhookID, createdBy, name, description string, args []Arg) *Command {
+	return &Command{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Description: description,
+		Args:        args,
+		RoomID:      roomID,
+		WorkspaceID: workspaceID,
+		WebhookID:   &webhookID,
+		CreatedBy:   &createdBy,
+	}
+}
+
+func (c *Command) Source() Source {
+	if c.BotID != nil {
+		return SourceBot
+	}
+	return SourceWebhook
+}
+
+// ResponseType is who sees a handler's reply.
+type ResponseType string
+
+const (
+	ResponseEphemeral ResponseType = "EPHEMERAL" // Only the invoker, on their user channel
+	ResponseInRoom    ResponseType = "IN_ROOM"   // Everyone, as a message in the room
+)
+
+// Invocation is one run of a custom command. Its handler may reply through
+// the invocation's response token until it expires; only the token's hash
+// is stored.
+type Invocation struct {
+	ID        string
+	CommandID string
+	Command   string  // The command's name, loaded with the invocation
+	BotID     *string // The command's bot, or nil for a webhook command
+	WebhookID *string // The command's outgoing webhook, or nil for a bot command
+	RoomID    string
+	UserID    string
+	TokenHash string
+	Responses int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// NewInvocation records a user running a command in a room.
+func NewInvocation(cmd *Command, roomID, userID, tokenHash string, expiresAt time.Time) *Invocation {
+	return &Invocation{
+		ID:        uuid.NewString(),
+		CommandID: cmd.ID,
+		Command:   cmd.Name,
+		BotID:     cmd.BotID,
+		RoomID:    roomID,
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+var (
+	ErrUnknownCommand       = errors.New("UNKNOWN_COMMAND", "There is no such command in this room", 404)
+	ErrCommandNotFound      = errors.New("COMMAND_NOT_FOUND", "The requested command was not found", 404)
+	ErrCommandExists        = errors.New("COMMAND_EXISTS", "A command with this name already exists here", 409)
+	ErrReservedName         = errors.New("RESERVED_COMMAND_NAME", "This name belongs to a built-in command", 409)
+	ErrInvalidName          = errors.New("INVALID_COMMAND_NAME", "Command names start with a letter and contain only lowercase letters, digits, - and _", 400)
+	ErrTooManyCommands      = errors.New("TOO_MANY_COMMANDS", "The maximum number of commands has been reached here", 409)
+	ErrNotBot               = errors.New("NOT_A_BOT", "Only bots can register commands in a room", 403)
+	ErrInvalidResponseToken = errors.New("INVALID_RESPONSE_TOKEN", "The response token is invalid, expired or used up", 404)
+)
+
+// NewInvalidArgError reports an argument definition that cannot be registered.
+func NewInvalidArgError(arg, reason string) *errors.AppError {
+	return errors.New("INVALID_COMMAND_ARG", reason, 400).WithDetails(map[string]any{"arg": arg})
+}
+
+// NewUsageError reports text that does not match a command's arguments,
+// along with how the command is used.
+func NewUsageError(message, usage string) *errors.AppError {
+	return errors.New("INVALID_COMMAND_USAGE", message, 422).WithDetails(map[string]any{"usage": usage})
+}
+
+// NewRateLimitedError reports how long a user must wait before running another command.
+func NewRateLimitedError(wait time.Duration) *errors.AppError {
+	secs := max(1, int(math.Ceil(wait.Seconds())))
+	return errors.New("COMMAND_RATE_LIMITED", fmt.Sprintf("You are running commands too quickly. Try again in %d seconds.", secs), 429).
+		WithDetails(map[string]any{"retry_after_seconds": secs})
+}
@@ -0,0 +1,185 @@
+package command
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/validator"
+	authMiddleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+type Handler struct {
+	service   *Service
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewHandler(service *Service, logger *slog.Logger, v *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		logger:    logger,
+		validator: v,
+	}
+}
+
+// ListRoomCommands handles GET /api/rooms/{room_id}/commands
+func (h *Handler) ListRoomCommands(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	commands, err := h.service.ListRoomCommands(r.Context(), userID, roomID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, commands)
+}
+
+// RegisterBotCommand handles POST /api/rooms/{room_id}/commands
+func (h *Handler) RegisterBotCommand(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req CreateCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	cmd, err := h.service.RegisterBotCommand(r.Context(), userID, roomID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, cmd)
+}
+
+// RegisterWebhookCommand handles POST /api/outgoing-webhooks/{webhook_id}/commands
+func (h *Handler) RegisterWebhookCommand(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	webhookID := chi.URLParam(r, "webhook_id")
+
+	var req CreateCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	cmd, err := h.service.RegisterWebhookCommand(r.Context(), userID, webhookID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, cmd)
+}
+
+// ListWebhookCommands handles GET /api/outgoing-webhooks/{webhook_id}/commands
+func (h *Handler) ListWebhookCommands(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	webhookID := chi.URLParam(r, "webhook_id")
+
+	commands, err := h.service.ListWebhookCommands(r.Context(), userID, webhookID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, commands)
+}
+
+// UpdateCommand handles PATCH /api/commands/{command_id}
+func (h *Handler) UpdateCommand(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	commandID := chi.URLParam(r, "command_id")
+
+	var req UpdateCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	cmd, err := h.service.UpdateCommand(r.Context(), userID, commandID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, cmd)
+}
+
+// DeleteCommand handles DELETE /api/commands/{command_id}
+func (h *Handler) DeleteCommand(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	commandID := chi.URLParam(r, "command_id")
+
+	if err := h.service.DeleteCommand(r.Context(), userID, commandID); err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Respond handles POST /api/command-responses/{token}
+func (h *Handler) Respond(w http.ResponseWriter, r *http.Request) {
+	var req RespondRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	resp, err := h.service.Respond(r.Context(), chi.URLParam(r, "token"), req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
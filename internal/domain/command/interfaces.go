@@ -0,0 +1,48 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/domain/room"
+	"github.com/purushothdl/gochat-backend/internal/domain/webhook"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// RoomProvider looks up the room a command is run in and the invoker's membership.
+type RoomProvider interface {
+	GetRoomInfo(ctx context.Context, roomID string) (*types.RoomInfo, error)
+	GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error)
+}
+
+// RoomActions carries out the built-in commands that manage a room. Each
+// action checks the invoker's permissions just as its API route does.
+type RoomActions interface {
+	InviteUser(ctx context.Context, inviterID, roomID, inviteeID string) (*room.Invitation, error)
+	RemoveMember(ctx context.Context, actorID, roomID, targetUserID string) error
+	MuteMember(ctx context.Context, actorID, roomID, targetUserID string, req room.MuteMemberRequest) (time.Time, error)
+	UpdateRoomSettings(ctx context.Context, actorID, roomID string, req room.UpdateRoomSettingsRequest) (*room.Room, error)
+}
+
+// MessagePoster posts what commands and their handlers put into a room.
+type MessagePoster interface {
+	SendTextMessage(ctx context.Context, senderID, roomID, content, clientMessageID string) (*message.Message, error)
+	SendActionMessage(ctx context.Context, senderID, roomID, content, clientMessageID string) (*message.Message, error)
+	PostWebhookMessage(ctx context.Context, post message.WebhookPost) (*message.Message, error)
+}
+
+// UserProvider tells bots, which register their own commands, apart from people.
+type UserProvider interface {
+	GetByIDShared(ctx context.Context, id string) (*types.User, error)
+}
+
+// WebhookProvider checks that the user may manage the outgoing webhook whose commands they change.
+type WebhookProvider interface {
+	FindManagedOutgoingWebhook(ctx context.Context, actorID, webhookID string) (*webhook.OutgoingWebhook, error)
+}
+
+// WebhookDispatcher delivers invocations to the outgoing webhooks that answer them.
+type WebhookDispatcher interface {
+	DispatchTo(ctx context.Context, webhookID string, event *types.WebhookEvent) error
+}
@@ -0,0 +1,39 @@
+package command
+
+import (
+	"context"
+
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+)
+
+type Repository interface {
+	// CreateCommand returns ErrCommandExists if its room or workspace already
+	// has a command with the same name.
+	CreateCommand(ctx context.Context, cmd *Command) error
+	FindCommand(ctx context.Context, commandID string) (*Command, error)
+	UpdateCommand(ctx context.Context, cmd *Command) error
+	DeleteCommand(ctx context.Context, commandID string) error
+	// ListRoomCommands returns the custom commands that can be run in the room,
+	// ordered by name: its own and its workspace's, skipping those of paused
+	// webhooks and of bots that are no longer in the room. A room's own
+	// command hides a workspace command of the same name.
+	ListRoomCommands(ctx context.Context, roomID string) ([]*Command, error)
+	// FindRoomCommand finds a command by name among those ListRoomCommands
+	// returns, or returns ErrCommandNotFound.
+	FindRoomCommand(ctx context.Context, roomID, name string) (*Command, error)
+	ListWebhookCommands(ctx context.Context, webhookID string) ([]*Command, error)
+	// CountScopeCommands counts the commands of a room or, when roomID is nil, a workspace.
+	CountScopeCommands(ctx context.Context, roomID, workspaceID *string) (int, error)
+
+	// Invocation Methods
+	// CreateInvocation stores the invocation with the events that notify its
+	// handler, and clears the command's expired invocations.
+	CreateInvocation(ctx context.Context, inv *Invocation, events ...*contracts.OutboxEvent) error
+	// ClaimResponse counts a reply against the invocation with the token hash.
+	// It returns ErrInvalidResponseToken if there is no such invocation, it
+	// has expired or it already has maxResponses replies.
+	ClaimResponse(ctx context.Context, tokenHash string, maxResponses int) (*Invocation, error)
+	// PublishEvents stores events for the outbox relay on their own, for
+	// replies that are not tied to any other write.
+	PublishEvents(ctx context.Context, events ...*contracts.OutboxEvent) error
+}
@@ -0,0 +1,22 @@
+package command
+
+// CreateCommandRequest registers a custom command. The arguments are read in
+// order from the text after the command's name.
+type CreateCommandRequest struct {
+	Name        string `json:"name" validate:"required,max=32"`
+	Description string `json:"description" validate:"max=200"`
+	Args        []Arg  `json:"args" validate:"max=10"`
+}
+
+// UpdateCommandRequest changes a command; Args, when set, replaces all of them.
+type UpdateCommandRequest struct {
+	Name        *string `json:"name" validate:"omitempty,max=32"`
+	Description *string `json:"description" validate:"omitempty,max=200"`
+	Args        *[]Arg  `json:"args"`
+}
+
+// RespondRequest is a handler's reply to an invocation.
+type RespondRequest struct {
+	Text         string       `json:"text" validate:"required,min=1,max=2000"`
+	ResponseType ResponseType `json:"response_type" validate:"omitempty,oneof=EPHEMERAL IN_ROOM"` // Defaults to EPHEMERAL
+}
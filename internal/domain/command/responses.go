@@ -0,0 +1,70 @@
+package command
+
+import "time"
+
+// CommandResponse describes a command for clients, which use its arguments to
+// offer autocomplete. Built-in commands have no id or timestamps.
+type CommandResponse struct {
+	ID          string     `json:"id,omitempty"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Usage       string     `json:"usage"`
+	Args        []Arg      `json:"args"`
+	Source      Source     `json:"source"`
+	RoomID      *string    `json:"room_id,omitempty"`
+	WorkspaceID *string    `json:"workspace_id,omitempty"`
+	BotID       *string    `json:"bot_id,omitempty"`
+	WebhookID   *string    `json:"webhook_id,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// RespondResponse acknowledges a handler's reply.
+type RespondResponse struct {
+	InvocationID string       `json:"invocation_id"`
+	ResponseType ResponseType `json:"response_type"`
+	MessageID    *string      `json:"message_id,omitempty"` // Set for IN_ROOM replies
+}
+
+func toCommandResponse(cmd *Command) *CommandResponse {
+	return &CommandResponse{
+		ID:          cmd.ID,
+		Name:        cmd.Name,
+		Description: cmd.Description,
+		Usage:       usage(cmd.Name, cmd.Args),
+		Args:        nonNilArgs(cmd.Args),
+		Source:      cmd.Source(),
+		RoomID:      cmd.RoomID,
+		WorkspaceID: cmd.WorkspaceID,
+		BotID:       cmd.BotID,
+		WebhookID:   cmd.WebhookID,
+		CreatedAt:   &cmd.CreatedAt,
+		UpdatedAt:   &cmd.UpdatedAt,
+	}
+}
+
+func toCommandResponses(cmds []*Command) []*CommandResponse {
+	items := make([]*CommandResponse, 0, len(cmds))
+	for _, cmd := range cmds {
+		items = append(items, toCommandResponse(cmd))
+	}
+	return items
+}
+
+func toBuiltinResponse(b *builtin) *CommandResponse {
+	return &CommandResponse{
+		Name:        b.name,
+		Description: b.description,
+		Usage:       usage(b.name, b.args),
+		Args:        nonNilArgs(b.args),
+		Source:      SourceBuiltin,
+	}
+}
+
+// nonNilArgs keeps commands without arguments from being returned with "args": null.
+func nonNilArgs(args []Arg) []Arg {
+	if args == nil {
+		return []Arg{}
+	}
+	return args
+}
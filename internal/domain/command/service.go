@@ -0,0 +1,475 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/domain/webhook"
+	"github.com/purushothdl/gochat-backend/internal/shared/authz"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+	"github.com/purushothdl/gochat-backend/internal/websocket"
+	pointer "github.com/purushothdl/gochat-backend/pkg/utils/pointer"
+	"github.com/purushothdl/gochat-backend/pkg/utils/tokenutil"
+)
+
+// Service runs slash commands: the built-in ones itself, and custom ones by
+// handing them to the bot or outgoing webhook that registered them, which
+// then replies through the invocation's response token.
+type Service struct {
+	commandRepo Repository
+	roomProv    RoomProvider
+	rooms       RoomActions
+	messages    MessagePoster
+	userProv    UserProvider
+	webhooks    WebhookProvider
+	dispatcher  WebhookDispatcher
+	limiter     contracts.RateLimiter
+	builtins    []*builtin
+	config      *config.Config
+	logger      *slog.Logger
+}
+
+func NewService(
+	commandRepo Repository,
+	roomProv RoomProvider,
+	rooms RoomActions,
+	messages MessagePoster,
+	userProv UserProvider,
+	webhooks WebhookProvider,
+	dispatcher WebhookDispatcher,
+	limiter contracts.RateLimiter,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *Service {
+	s := &Service{
+		commandRepo: commandRepo,
+		roomProv:    roomProv,
+		rooms:       rooms,
+		messages:    messages,
+		userProv:    userProv,
+		webhooks:    webhooks,
+		dispatcher:  dispatcher,
+		limiter:     limiter,
+		config:      cfg,
+		logger:      logger,
+	}
+	s.builtins = s.newBuiltins()
+	return s
+}
+
+// ============================================================================
+// Running Commands
+// ============================================================================
+
+// Execute runs a slash command sent as a message. Built-in commands act
+// right away; custom commands are handed to their bot or webhook and the
+// result is marked deferred, as the reply arrives later.
+func (s *Service) Execute(ctx context.Context, inv *types.CommandInvocation) (*types.CommandResult, error) {
+	name, text, ok := types.ParseCommand(inv.Text)
+	if !ok {
+		return nil, ErrUnknownCommand
+	}
+
+	membership, err := s.roomProv.GetMembershipInfo(ctx, inv.RoomID, inv.UserID)
+	if err != nil {
+		return nil, err
+	}
+	targetRoom, err := s.roomProv.GetRoomInfo(ctx, inv.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if targetRoom.IsArchived {
+		return nil, message.ErrRoomArchived
+	}
+	if err := s.enforceRateLimit(ctx, inv.UserID); err != nil {
+		return nil, err
+	}
+
+	if b := s.findBuiltin(name); b != nil {
+		return s.runBuiltin(ctx, b, inv, text)
+	}
+
+	cmd, err := s.commandRepo.FindRoomCommand(ctx, inv.RoomID, name)
+	if err != nil {
+		if err == ErrCommandNotFound {
+			return nil, ErrUnknownCommand
+		}
+		return nil, err
+	}
+	// Custom commands speak for the invoker to an outside handler, so they
+	// take the same permission as sending a message.
+	if err := authz.Require(membership.Role, membership.MemberPermissions, authz.SendMessages); err != nil {
+		return nil, err
+	}
+	args, err := parseArgs(cmd.Name, cmd.Args, text)
+	if err != nil {
+		return nil, err
+	}
+	return s.invoke(ctx, cmd, inv, text, args)
+}
+
+func (s *Service) runBuiltin(ctx context.Context, b *builtin, inv *types.CommandInvocation, text string) (*types.CommandResult, error) {
+	args, err := parseArgs(b.name, b.args, text)
+	if err != nil {
+		return nil, err
+	}
+	r, err := b.run(ctx, b, inv, args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.CommandResult{InvocationID: uuid.NewString(), Command: b.name, Text: r.text}
+	if r.message != nil {
+		result.MessageID = &r.message.ID
+	}
+	// The reply is in the response already; the event is for the invoker's
+	// other devices, so failing to send it does not fail the command.
+	if r.text != "" {
+		err := s.publishEphemeral(ctx, inv.UserID, websocket.CommandResponsePayload{
+			InvocationID: result.InvocationID,
+			Command:      b.name,
+			RoomID:       inv.RoomID,
+			Text:         r.text,
+		})
+		if err != nil {
+			s.logger.Error("failed to publish command reply", "error", err, "command", b.name, "user_id", inv.UserID)
+		}
+	}
+
+	s.logger.Info("command run", "command", b.name, "room_id", inv.RoomID, "user_id", inv.UserID)
+	return result, nil
+}
+
+// invoke stores an invocation of a custom command and notifies its handler:
+// a bot on its user channel, a webhook through a delivery. Either way the
+// handler gets a response token to reply with until the invocation expires.
+func (s *Service) invoke(ctx context.Context, cmd *Command, inv *types.CommandInvocation, text string, args map[string]any) (*types.CommandResult, error) {
+	token, _, err := tokenutil.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate response token: %w", err)
+	}
+	invocation := NewInvocation(cmd, inv.RoomID, inv.UserID, tokenutil.Hash(token), time.Now().Add(s.config.Command.ResponseTTL))
+
+	payload := websocket.CommandInvokedPayload{
+		InvocationID:  invocation.ID,
+		CommandID:     cmd.ID,
+		Command:       cmd.Name,
+		RoomID:        inv.RoomID,
+		UserID:        inv.UserID,
+		Text:          text,
+		Args:          args,
+		ResponseToken: token,
+		ExpiresAt:     invocation.ExpiresAt,
+	}
+
+	var events []*contracts.OutboxEvent
+	if cmd.BotID != nil {
+		event, err := commandInvokedEvent(*cmd.BotID, payload)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err := s.commandRepo.CreateInvocation(ctx, invocation, events...); err != nil {
+		return nil, err
+	}
+	if cmd.WebhookID != nil {
+		if err := s.dispatcher.DispatchTo(ctx, *cmd.WebhookID, commandInvokedWebhookEvent(payload)); err != nil {
+			return nil, fmt.Errorf("failed to deliver command: %w", err)
+		}
+	}
+
+	s.logger.Info("command invoked", "command", cmd.Name, "command_id", cmd.ID, "invocation_id", invocation.ID, "room_id", inv.RoomID, "user_id", inv.UserID)
+	return &types.CommandResult{InvocationID: invocation.ID, Command: cmd.Name, Deferred: true}, nil
+}
+
+// Respond delivers a handler's reply to an invocation: ephemeral replies go
+// to the invoker's user channel only, others are posted into the room. A
+// bot's replies are posted as the bot, so its membership and the room's
+// limits apply; a webhook has no account of its own and its replies are
+// posted as webhook messages under the command's name. Either way they pass
+// the room's moderation hooks, and archived rooms take no replies.
+func (s *Service) Respond(ctx context.Context, token string, req RespondRequest) (*RespondResponse, error) {
+	inv, err := s.commandRepo.ClaimResponse(ctx, tokenutil.Hash(token), s.config.Command.MaxResponses)
+	if err != nil {
+		return nil, err
+	}
+	targetRoom, err := s.roomProv.GetRoomInfo(ctx, inv.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if targetRoom.IsArchived {
+		return nil, message.ErrRoomArchived
+	}
+
+	resp := &RespondResponse{InvocationID: inv.ID, ResponseType: req.ResponseType}
+	if resp.ResponseType == "" {
+		resp.ResponseType = ResponseEphemeral
+	}
+
+	if resp.ResponseType == ResponseEphemeral {
+		err := s.publishEphemeral(ctx, inv.UserID, websocket.CommandResponsePayload{
+			InvocationID: inv.ID,
+			Command:      inv.Command,
+			RoomID:       inv.RoomID,
+			Text:         req.Text,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	var msg *message.Message
+	if inv.BotID != nil {
+		msg, err = s.messages.SendTextMessage(ctx, *inv.BotID, inv.RoomID, req.Text, "")
+	} else {
+		msg, err = s.messages.PostWebhookMessage(ctx, message.WebhookPost{
+			RoomID:     inv.RoomID,
+			WebhookID:  *inv.WebhookID,
+			SenderName: "/" + inv.Command,
+			Content:    req.Text,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp.MessageID = &msg.ID
+	return resp, nil
+}
+
+// enforceRateLimit caps how many commands a user runs per window. Like the
+// send limit it fails open when the limiter is unavailable.
+func (s *Service) enforceRateLimit(ctx context.Context, userID string) error {
+	limits := s.config.Command
+	if limits.InvocationsPerWindow <= 0 {
+		return nil
+	}
+	allowed, retryAfter, err := s.limiter.Allow(ctx, fmt.Sprintf("command:%s", userID), limits.InvocationsPerWindow, limits.InvocationWindow)
+	if err != nil {
+		s.logger.Error("failed to check command rate limit", "error", err, "user_id", userID)
+		return nil
+	}
+	if !allowed {
+		return NewRateLimitedError(retryAfter)
+	}
+	return nil
+}
+
+func (s *Service) publishEphemeral(ctx context.Context, userID string, payload websocket.CommandResponsePayload) error {
+	event, err := websocket.NewEvent(websocket.EventCommandResponse, payload)
+	if err != nil {
+		return fmt.Errorf("failed to build command response event: %w", err)
+	}
+	outboxEvent, err := event.ToOutbox(fmt.Sprintf("user:%s", userID))
+	if err != nil {
+		return fmt.Errorf("failed to build command response event: %w", err)
+	}
+	return s.commandRepo.PublishEvents(ctx, outboxEvent)
+}
+
+// commandInvokedEvent builds a COMMAND_INVOKED event for the bot's own channel.
+func commandInvokedEvent(botID string, payload websocket.CommandInvokedPayload) (*contracts.OutboxEvent, error) {
+	event, err := websocket.NewEvent(websocket.EventCommandInvoked, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build command invoked event: %w", err)
+	}
+	return event.ToOutbox(fmt.Sprintf("user:%s", botID))
+}
+
+// commandInvokedWebhookEvent describes an invocation for the webhook that answers it.
+func commandInvokedWebhookEvent(payload websocket.CommandInvokedPayload) *types.WebhookEvent {
+	return &types.WebhookEvent{
+		Type:   types.WebhookCommandInvoked,
+		RoomID: payload.RoomID,
+		Data: map[string]any{
+			"invocation_id":  payload.InvocationID,
+			"command_id":     payload.CommandID,
+			"command":        payload.Command,
+			"user_id":        payload.UserID,
+			"text":           payload.Text,
+			"args":           payload.Args,
+			"response_token": payload.ResponseToken,
+			"expires_at":     payload.ExpiresAt,
+		},
+	}
+}
+
+// ============================================================================
+// Managing Commands
+// ============================================================================
+
+// ListRoomCommands returns every command that can be run in the room: the
+// built-ins, then the room's and its workspace's custom commands by name.
+func (s *Service) ListRoomCommands(ctx context.Context, userID, roomID string) ([]*CommandResponse, error) {
+	if _, err := s.roomProv.GetMembershipInfo(ctx, roomID, userID); err != nil {
+		return nil, err
+	}
+	cmds, err := s.commandRepo.ListRoomCommands(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*CommandResponse, 0, len(s.builtins)+len(cmds))
+	for _, b := range s.builtins {
+		items = append(items, toBuiltinResponse(b))
+	}
+	return append(items, toCommandResponses(cmds)...), nil
+}
+
+// RegisterBotCommand registers a command for the calling bot in a room it is
+// a member of. The command stops being offered once the bot leaves.
+func (s *Service) RegisterBotCommand(ctx context.Context, botID, roomID string, req CreateCommandRequest) (*CommandResponse, error) {
+	actor, err := s.userProv.GetByIDShared(ctx, botID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !actor.IsBot {
+		return nil, ErrNotBot
+	}
+	if _, err := s.roomProv.GetMembershipInfo(ctx, roomID, botID); err != nil {
+		return nil, err
+	}
+
+	args, err := s.validateDefinition(req.Name, req.Args)
+	if err != nil {
+		return nil, err
+	}
+	cmd := NewBotCommand(roomID, botID, req.Name, req.Description, args)
+	if err := s.createCommand(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return toCommandResponse(cmd), nil
+}
+
+// RegisterWebhookCommand registers a command answered by an outgoing
+// webhook, in the webhook's room or in every room of its workspace.
+func (s *Service) RegisterWebhookCommand(ctx context.Context, actorID, webhookID string, req CreateCommandRequest) (*CommandResponse, error) {
+	hook, err := s.webhooks.FindManagedOutgoingWebhook(ctx, actorID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := s.validateDefinition(req.Name, req.Args)
+	if err != nil {
+		return nil, err
+	}
+	cmd := NewWebhookCommand(hook.RoomID, hook.WorkspaceID, hook.ID, actorID, req.Name, req.Description, args)
+	if err := s.createCommand(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return toCommandResponse(cmd), nil
+}
+
+// ListWebhookCommands returns the commands an outgoing webhook answers.
+func (s *Service) ListWebhookCommands(ctx context.Context, actorID, webhookID string) ([]*CommandResponse, error) {
+	if _, err := s.webhooks.FindManagedOutgoingWebhook(ctx, actorID, webhookID); err != nil {
+		return nil, err
+	}
+	cmds, err := s.commandRepo.ListWebhookCommands(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return toCommandResponses(cmds), nil
+}
+
+// UpdateCommand renames a custom command or changes its description or arguments.
+func (s *Service) UpdateCommand(ctx context.Context, actorID, commandID string, req UpdateCommandRequest) (*CommandResponse, error) {
+	cmd, err := s.findManagedCommand(ctx, actorID, commandID)
+	if err != nil {
+		return nil, err
+	}
+
+	pointer.UpdatePointerField(&cmd.Name, req.Name)
+	pointer.UpdatePointerField(&cmd.Description, req.Description)
+	if req.Args != nil {
+		cmd.Args = *req.Args
+	}
+	if cmd.Args, err = s.validateDefinition(cmd.Name, cmd.Args); err != nil {
+		return nil, err
+	}
+	if err := s.commandRepo.UpdateCommand(ctx, cmd); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("command updated", "command_id", cmd.ID, "actor_id", actorID)
+	return toCommandResponse(cmd), nil
+}
+
+func (s *Service) DeleteCommand(ctx context.Context, actorID, commandID string) error {
+	if _, err := s.findManagedCommand(ctx, actorID, commandID); err != nil {
+		return err
+	}
+	if err := s.commandRepo.DeleteCommand(ctx, commandID); err != nil {
+		return err
+	}
+
+	s.logger.Info("command deleted", "command_id", commandID, "actor_id", actorID)
+	return nil
+}
+
+func (s *Service) createCommand(ctx context.Context, cmd *Command) error {
+	count, err := s.commandRepo.CountScopeCommands(ctx, cmd.RoomID, cmd.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if count >= s.config.Command.MaxPerScope {
+		return ErrTooManyCommands
+	}
+	if err := s.commandRepo.CreateCommand(ctx, cmd); err != nil {
+		return err
+	}
+
+	s.logger.Info("command registered", "command_id", cmd.ID, "name", cmd.Name, "room_id", cmd.RoomID, "workspace_id", cmd.WorkspaceID)
+	return nil
+}
+
+// findManagedCommand loads a command the user may change. A webhook's
+// commands are managed by whoever manages the webhook; a bot's by the bot
+// itself and by the room's members who may manage webhooks.
+func (s *Service) findManagedCommand(ctx context.Context, actorID, commandID string) (*Command, error) {
+	cmd, err := s.commandRepo.FindCommand(ctx, commandID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.WebhookID != nil {
+		if _, err := s.webhooks.FindManagedOutgoingWebhook(ctx, actorID, *cmd.WebhookID); err != nil {
+			if err == webhook.ErrWebhookNotFound {
+				return nil, ErrCommandNotFound
+			}
+			return nil, err
+		}
+		return cmd, nil
+	}
+
+	if *cmd.BotID == actorID {
+		return cmd, nil
+	}
+	membership, err := s.roomProv.GetMembershipInfo(ctx, *cmd.RoomID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authz.Require(membership.Role, membership.MemberPermissions, authz.ManageWebhooks); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// validateDefinition checks a command's name, which must not shadow a
+// built-in, and its arguments.
+func (s *Service) validateDefinition(name string, args []Arg) ([]Arg, error) {
+	if !types.ValidCommandName(name) {
+		return nil, ErrInvalidName
+	}
+	if s.findBuiltin(name) != nil {
+		return nil, ErrReservedName
+	}
+	return normalizeArgs(args)
+}
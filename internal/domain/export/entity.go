@@ -0,0 +1,95 @@
+package export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Format string
+
+const (
+	FormatJSONL Format = "JSONL"
+	FormatHTML  Format = "HTML"
+)
+
+type Status string
+
+const (
+	StatusPending    Status = "PENDING"
+	StatusProcessing Status = "PROCESSING"
+	StatusCompleted  Status = "COMPLETED"
+	StatusFailed     Status = "FAILED"
+)
+
+// Export represents a single room history export job.
+type Export struct {
+	ID                string
+	RoomID            string
+	RequestedBy       *string
+	Format            Format
+	Status            Status
+	TotalMessages     int
+	ProcessedMessages int
+	FileKey           *string
+	Error             *string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	CompletedAt       *time.Time
+	ExpiresAt         *time.Time
+}
+
+// ExportJob is the payload placed on the queue for the export worker.
+type ExportJob struct {
+	ExportID string `json:"export_id"`
+}
+
+// ExportedMessage is a flattened message row as it appears in an export file.
+type ExportedMessage struct {
+	ID         string     `json:"id"`
+	SenderID   *string    `json:"sender_id,omitempty"`
+	SenderName *string    `json:"sender_name,omitempty"`
+	Content    string     `json:"content"`
+	Type       string     `json:"type"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ExportCursor is a keyset position used to page through a room's messages in order.
+type ExportCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// NewExport creates a pending export entity for the given room.
+func NewExport(roomID, requestedBy string, format Format) *Export {
+	return &Export{
+		ID:          uuid.NewString(),
+		RoomID:      roomID,
+		RequestedBy: &requestedBy,
+		Format:      format,
+		Status:      StatusPending,
+	}
+}
+
+// IsDownloadable reports whether the export file exists and its link has not expired.
+func (e *Export) IsDownloadable() bool {
+	return e.Status == StatusCompleted && e.FileKey != nil && e.ExpiresAt != nil && time.Now().Before(*e.ExpiresAt)
+}
+
+// FileExtension returns the extension used for the export's output file.
+func (f Format) FileExtension() string {
+	if f == FormatHTML {
+		return "html"
+	}
+	return "jsonl"
+}
+
+// ContentType returns the MIME type used when storing the export's output file.
+func (f Format) ContentType() string {
+	if f == FormatHTML {
+		return "text/html; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
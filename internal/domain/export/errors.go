@@ -0,0 +1,8 @@
+package export
+
+import "github.com/purushothdl/gochat-backend/pkg/errors"
+
+var (
+	ErrExportNotFound = errors.New("EXPORT_NOT_FOUND", "The requested export was not found", 404)
+	ErrNotAdmin       = errors.New("NOT_ADMIN", "You must be an admin to export this room", 403)
+)
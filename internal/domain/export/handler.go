@@ -0,0 +1,92 @@
+package export
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/validator"
+	authMiddleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+type Handler struct {
+	service   *Service
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewHandler(service *Service, logger *slog.Logger, v *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		logger:    logger,
+		validator: v,
+	}
+}
+
+// CreateExport handles POST /api/rooms/{room_id}/exports
+func (h *Handler) CreateExport(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req CreateExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	newExport, err := h.service.RequestExport(r.Context(), actorID, roomID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusAccepted, newExport.ToResponse())
+}
+
+// ListExports handles GET /api/rooms/{room_id}/exports
+func (h *Handler) ListExports(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	exports, err := h.service.ListExports(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, exports)
+}
+
+// GetExport handles GET /api/rooms/{room_id}/exports/{export_id}
+func (h *Handler) GetExport(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	exportID := chi.URLParam(r, "export_id")
+
+	exportStatus, err := h.service.GetExport(r.Context(), actorID, roomID, exportID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, exportStatus)
+}
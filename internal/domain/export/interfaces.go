@@ -0,0 +1,24 @@
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// RoomProvider defines the methods the export service needs about rooms.
+type RoomProvider interface {
+	GetRoomInfo(ctx context.Context, roomID string) (*types.RoomInfo, error)
+	GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error)
+}
+
+// UserProvider defines the methods the export worker needs about users.
+type UserProvider interface {
+	GetByIDShared(ctx context.Context, id string) (*types.User, error)
+}
+
+// EmailService defines the notification sent when an export is ready.
+type EmailService interface {
+	SendRoomExportReadyEmail(ctx context.Context, recipientEmail, recipientName, roomName, downloadLink string, expiresAt time.Time) error
+}
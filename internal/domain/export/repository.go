@@ -0,0 +1,21 @@
+package export
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the persistence interface for export jobs and the messages they read.
+type Repository interface {
+	CreateExport(ctx context.Context, export *Export) error
+	GetExport(ctx context.Context, exportID string) (*Export, error)
+	ListRoomExports(ctx context.Context, roomID string, limit int) ([]*Export, error)
+
+	MarkProcessing(ctx context.Context, exportID string, totalMessages int) error
+	UpdateProgress(ctx context.Context, exportID string, processedMessages int) error
+	MarkCompleted(ctx context.Context, exportID, fileKey string, expiresAt time.Time) error
+	MarkFailed(ctx context.Context, exportID, reason string) error
+
+	CountRoomMessages(ctx context.Context, roomID string) (int, error)
+	ListMessagesForExport(ctx context.Context, roomID string, after *ExportCursor, limit int) ([]*ExportedMessage, error)
+}
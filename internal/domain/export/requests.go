@@ -0,0 +1,5 @@
+package export
+
+type CreateExportRequest struct {
+	Format Format `json:"format" validate:"required,oneof=JSONL HTML"`
+}
@@ -0,0 +1,48 @@
+package export
+
+import "time"
+
+type ExportResponse struct {
+	ID          string           `json:"id"`
+	RoomID      string           `json:"room_id"`
+	Format      Format           `json:"format"`
+	Status      Status           `json:"status"`
+	Progress    ProgressResponse `json:"progress"`
+	DownloadURL string           `json:"download_url,omitempty"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty"`
+	Error       *string          `json:"error,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+}
+
+type ProgressResponse struct {
+	ProcessedMessages int `json:"processed_messages"`
+	TotalMessages     int `json:"total_messages"`
+	Percent           int `json:"percent"`
+}
+
+func (e *Export) ToResponse() *ExportResponse {
+	percent := 0
+	switch {
+	case e.Status == StatusCompleted:
+		percent = 100
+	case e.TotalMessages > 0:
+		percent = e.ProcessedMessages * 100 / e.TotalMessages
+	}
+
+	return &ExportResponse{
+		ID:     e.ID,
+		RoomID: e.RoomID,
+		Format: e.Format,
+		Status: e.Status,
+		Progress: ProgressResponse{
+			ProcessedMessages: e.ProcessedMessages,
+			TotalMessages:     e.TotalMessages,
+			Percent:           percent,
+		},
+		ExpiresAt:   e.ExpiresAt,
+		Error:       e.Error,
+		CreatedAt:   e.CreatedAt,
+		CompletedAt: e.CompletedAt,
+	}
+}
@@ -0,0 +1,131 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/shared/authz"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type Service struct {
+	exportRepo Repository
+	roomProv   RoomProvider
+	storage    contracts.FileStorage
+	queue      contracts.Queue
+	config     *config.Config
+	logger     *slog.Logger
+}
+
+func NewService(
+	exportRepo Repository,
+	roomProv RoomProvider,
+	storage contracts.FileStorage,
+	queue contracts.Queue,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		exportRepo: exportRepo,
+		roomProv:   roomProv,
+		storage:    storage,
+		queue:      queue,
+		config:     cfg,
+		logger:     logger,
+	}
+}
+
+// RequestExport records a new export job for a room and schedules it for processing.
+func (s *Service) RequestExport(ctx context.Context, actorID, roomID string, req CreateExportRequest) (*Export, error) {
+	if err := s.authorizeAdmin(ctx, actorID, roomID); err != nil {
+		return nil, err
+	}
+
+	newExport := NewExport(roomID, actorID, req.Format)
+	if err := s.exportRepo.CreateExport(ctx, newExport); err != nil {
+		return nil, fmt.Errorf("service failed to create export: %w", err)
+	}
+
+	if err := s.queue.Enqueue(ctx, s.config.Export.QueueName, ExportJob{ExportID: newExport.ID}); err != nil {
+		s.logger.Error("failed to enqueue export job", "error", err, "export_id", newExport.ID)
+		if markErr := s.exportRepo.MarkFailed(context.Background(), newExport.ID, "failed to schedule export"); markErr != nil {
+			s.logger.Error("failed to mark export as failed", "error", markErr, "export_id", newExport.ID)
+		}
+		return nil, fmt.Errorf("failed to schedule export for processing: %w", err)
+	}
+
+	s.logger.Info("room export requested", "export_id", newExport.ID, "room_id", roomID, "user_id", actorID)
+	return newExport, nil
+}
+
+// GetExport returns an export's status and progress, with a fresh download link once it is ready.
+func (s *Service) GetExport(ctx context.Context, actorID, roomID, exportID string) (*ExportResponse, error) {
+	if err := s.authorizeAdmin(ctx, actorID, roomID); err != nil {
+		return nil, err
+	}
+
+	found, err := s.exportRepo.GetExport(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if found.RoomID != roomID {
+		return nil, ErrExportNotFound
+	}
+
+	resp := found.ToResponse()
+	if found.IsDownloadable() {
+		link, err := s.downloadLink(ctx, found)
+		if err != nil {
+			return nil, err
+		}
+		resp.DownloadURL = link
+	}
+	return resp, nil
+}
+
+// ListExports returns the most recent exports for a room.
+func (s *Service) ListExports(ctx context.Context, actorID, roomID string) ([]*ExportResponse, error) {
+	if err := s.authorizeAdmin(ctx, actorID, roomID); err != nil {
+		return nil, err
+	}
+
+	exports, err := s.exportRepo.ListRoomExports(ctx, roomID, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*ExportResponse, len(exports))
+	for i, e := range exports {
+		responses[i] = e.ToResponse()
+	}
+	return responses, nil
+}
+
+// downloadLink presigns the export file for no longer than the export's remaining lifetime.
+func (s *Service) downloadLink(ctx context.Context, e *Export) (string, error) {
+	expiry := time.Until(*e.ExpiresAt)
+	if expiry > s.config.Export.LinkExpiry {
+		expiry = s.config.Export.LinkExpiry
+	}
+
+	link, err := s.storage.GetPresignedURL(ctx, *e.FileKey, expiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to create export download link: %w", err)
+	}
+	return link, nil
+}
+
+func (s *Service) authorizeAdmin(ctx context.Context, actorID, roomID string) error {
+	membership, err := s.roomProv.GetMembershipInfo(ctx, roomID, actorID)
+	if err != nil {
+		return err
+	}
+	if !authz.AtLeast(membership.Role, types.AdminRole) {
+		return ErrNotAdmin
+	}
+	return nil
+}
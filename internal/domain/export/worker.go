@@ -0,0 +1,188 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+)
+
+type Worker struct {
+	queue      contracts.Queue
+	storage    contracts.FileStorage
+	exportRepo Repository
+	roomProv   RoomProvider
+	userProv   UserProvider
+	email      EmailService
+	config     *config.Config
+	logger     *slog.Logger
+}
+
+func NewWorker(
+	queue contracts.Queue,
+	storage contracts.FileStorage,
+	exportRepo Repository,
+	roomProv RoomProvider,
+	userProv UserProvider,
+	email EmailService,
+	config *config.Config,
+	logger *slog.Logger,
+) *Worker {
+	return &Worker{
+		queue:      queue,
+		storage:    storage,
+		exportRepo: exportRepo,
+		roomProv:   roomProv,
+		userProv:   userProv,
+		email:      email,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	w.logger.Info("starting room export worker...")
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("room export worker shutting down")
+			return
+		default:
+			w.processNextJob(ctx)
+		}
+	}
+}
+
+func (w *Worker) processNextJob(ctx context.Context) {
+	var job ExportJob
+	if err := w.queue.Dequeue(ctx, w.config.Export.QueueName, &job); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		w.logger.Error("failed to dequeue export job", "error", err)
+		time.Sleep(5 * time.Second)
+		return
+	}
+
+	logger := w.logger.With("export_id", job.ExportID)
+	logger.Info("processing new export job")
+
+	if err := w.processExport(ctx, job.ExportID, logger); err != nil {
+		logger.Error("failed to process export", "error", err)
+		if markErr := w.exportRepo.MarkFailed(context.Background(), job.ExportID, err.Error()); markErr != nil {
+			logger.Error("failed to mark export as failed", "error", markErr)
+		}
+		return
+	}
+
+	logger.Info("successfully processed export job")
+}
+
+func (w *Worker) processExport(ctx context.Context, exportID string, logger *slog.Logger) error {
+	job, err := w.exportRepo.GetExport(ctx, exportID)
+	if err != nil {
+		return fmt.Errorf("failed to load export: %w", err)
+	}
+	if job.Status != StatusPending {
+		logger.Warn("skipping export that is no longer pending", "status", job.Status)
+		return nil
+	}
+
+	roomInfo, err := w.roomProv.GetRoomInfo(ctx, job.RoomID)
+	if err != nil {
+		return fmt.Errorf("failed to load room: %w", err)
+	}
+
+	total, err := w.exportRepo.CountRoomMessages(ctx, job.RoomID)
+	if err != nil {
+		return fmt.Errorf("failed to count room messages: %w", err)
+	}
+	if err := w.exportRepo.MarkProcessing(ctx, job.ID, total); err != nil {
+		return fmt.Errorf("failed to mark export as processing: %w", err)
+	}
+
+	var buf bytes.Buffer
+	writer := newFormatWriter(job.Format, &buf)
+	if err := writer.WriteHeader(roomInfo.Name, time.Now()); err != nil {
+		return fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	processed := 0
+	var cursor *ExportCursor
+	for {
+		batch, err := w.exportRepo.ListMessagesForExport(ctx, job.RoomID, cursor, w.config.Export.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to read messages: %w", err)
+		}
+
+		for _, msg := range batch {
+			// Soft-deleted messages keep their place in the transcript but never their content.
+			if msg.DeletedAt != nil {
+				msg.Content = ""
+			}
+			if err := writer.WriteMessage(msg); err != nil {
+				return fmt.Errorf("failed to write message: %w", err)
+			}
+		}
+
+		processed += len(batch)
+		if len(batch) > 0 {
+			if err := w.exportRepo.UpdateProgress(ctx, job.ID, processed); err != nil {
+				logger.Warn("failed to update export progress", "error", err)
+			}
+		}
+
+		if len(batch) < w.config.Export.BatchSize {
+			break
+		}
+		last := batch[len(batch)-1]
+		cursor = &ExportCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	if err := writer.WriteFooter(); err != nil {
+		return fmt.Errorf("failed to write export footer: %w", err)
+	}
+
+	fileKey := fmt.Sprintf("%s/%s/%s.%s", w.config.Export.StoragePath, job.RoomID, job.ID, job.Format.FileExtension())
+	if err := w.storage.Upload(ctx, fileKey, job.Format.ContentType(), bytes.NewReader(buf.Bytes()), false); err != nil {
+		return fmt.Errorf("failed to upload export file: %w", err)
+	}
+
+	expiresAt := time.Now().Add(w.config.Export.LinkExpiry)
+	if err := w.exportRepo.MarkCompleted(ctx, job.ID, fileKey, expiresAt); err != nil {
+		return fmt.Errorf("failed to mark export as completed: %w", err)
+	}
+
+	w.notifyRequester(ctx, job, roomInfo.Name, fileKey, expiresAt, logger)
+
+	logger.Info("room export completed", "room_id", job.RoomID, "messages", processed, "key", fileKey)
+	return nil
+}
+
+// notifyRequester emails the download link. Failures are logged because the export itself succeeded.
+func (w *Worker) notifyRequester(ctx context.Context, job *Export, roomName, fileKey string, expiresAt time.Time, logger *slog.Logger) {
+	if job.RequestedBy == nil {
+		return
+	}
+
+	requester, err := w.userProv.GetByIDShared(ctx, *job.RequestedBy)
+	if err != nil {
+		logger.Error("failed to load export requester", "error", err, "user_id", *job.RequestedBy)
+		return
+	}
+
+	link, err := w.storage.GetPresignedURL(ctx, fileKey, w.config.Export.LinkExpiry)
+	if err != nil {
+		logger.Error("failed to create export download link", "error", err)
+		return
+	}
+
+	if err := w.email.SendRoomExportReadyEmail(ctx, requester.Email, requester.Name, roomName, link, expiresAt); err != nil {
+		logger.Error("failed to send export ready email", "error", err, "user_id", requester.ID)
+	}
+}
@@ -0,0 +1,108 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"time"
+)
+
+const deletedMessagePlaceholder = "This message was deleted"
+
+// linkPattern matches plain URLs so attachments shared as links stay clickable in HTML exports.
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"']+`)
+
+// formatWriter serializes exported messages into a specific file format.
+type formatWriter interface {
+	WriteHeader(roomName string, generatedAt time.Time) error
+	WriteMessage(msg *ExportedMessage) error
+	WriteFooter() error
+}
+
+func newFormatWriter(format Format, w io.Writer) formatWriter {
+	if format == FormatHTML {
+		return &htmlWriter{w: w}
+	}
+	return &jsonlWriter{enc: json.NewEncoder(w)}
+}
+
+// jsonlWriter emits one JSON object per line.
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonlWriter) WriteHeader(string, time.Time) error { return nil }
+
+func (j *jsonlWriter) WriteMessage(msg *ExportedMessage) error {
+	return j.enc.Encode(msg)
+}
+
+func (j *jsonlWriter) WriteFooter() error { return nil }
+
+// htmlWriter emits a standalone, human-readable transcript.
+type htmlWriter struct {
+	w io.Writer
+}
+
+func (h *htmlWriter) WriteHeader(roomName string, generatedAt time.Time) error {
+	title := html.EscapeString(roomName)
+	_, err := fmt.Fprintf(h.w, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s - GoChat export</title>
+<style>
+body { font-family: sans-serif; margin: 2rem; }
+.msg { margin: 0.5rem 0; }
+.meta { color: #666; font-size: 0.85em; }
+.system, .deleted { color: #888; font-style: italic; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+<p class="meta">Exported %s</p>
+`, title, title, generatedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+func (h *htmlWriter) WriteMessage(msg *ExportedMessage) error {
+	sender := "System"
+	if msg.SenderName != nil {
+		sender = *msg.SenderName
+	}
+
+	class := "msg"
+	body := linkify(msg.Content)
+	switch {
+	case msg.DeletedAt != nil:
+		class = "msg deleted"
+		body = deletedMessagePlaceholder
+	case msg.Type == "SYSTEM":
+		class = "msg system"
+	}
+
+	_, err := fmt.Fprintf(h.w, `<div class="%s"><span class="meta">%s</span> <strong>%s</strong>: %s</div>
+`, class, msg.CreatedAt.UTC().Format(time.RFC3339), html.EscapeString(sender), body)
+	return err
+}
+
+func (h *htmlWriter) WriteFooter() error {
+	_, err := io.WriteString(h.w, "</body>\n</html>\n")
+	return err
+}
+
+// linkify escapes message content and wraps any URLs in anchor tags.
+func linkify(content string) string {
+	var out []byte
+	last := 0
+	for _, loc := range linkPattern.FindAllStringIndex(content, -1) {
+		out = append(out, html.EscapeString(content[last:loc[0]])...)
+		url := html.EscapeString(content[loc[0]:loc[1]])
+		out = append(out, fmt.Sprintf(`<a href="%s" rel="noopener noreferrer">%s</a>`, url, url)...)
+		last = loc[1]
+	}
+	out = append(out, html.EscapeString(content[last:])...)
+	return string(out)
+}
@@ -9,19 +9,25 @@ import (
 type MessageType string
 
 const (
-	TypeText   MessageType = "TEXT"
-	TypeSystem MessageType = "SYSTEM"
+	TypeText    MessageType = "TEXT"
+	TypeSystem  MessageType = "SYSTEM"
+	TypeWebhook MessageType = "WEBHOOK"
+	TypeAction  MessageType = "ACTION" // Posted with /me; shown as something the sender did
 )
 
 type Message struct {
-	ID        string
-	RoomID    string
-	UserID    *string // Pointer to allow for NULL user (system messages)
-	Content   string
-	Type      MessageType
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time
+	ID              string
+	RoomID          string
+	UserID          *string // Pointer to allow for NULL user (system messages)
+	Content         string
+	Type            MessageType
+	ClientMessageID *string // Sender-supplied id that makes retried sends idempotent
+	WebhookID       *string // Set, instead of UserID, for messages posted by an incoming or outgoing webhook
+	SenderName      *string // Name shown for a webhook message
+	SenderAvatarURL *string // Avatar shown for a webhook message
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       *time.Time
 }
 
 // NewTextMessage creates a standard user-sent message entity.
@@ -33,4 +39,28 @@ func NewTextMessage(roomID, userID, content string) *Message {
 		Content: content,
 		Type:    TypeText,
 	}
-}
\ No newline at end of file
+}
+
+// NewSystemMessage creates a message generated by the server rather than a user.
+func NewSystemMessage(roomID, content string) *Message {
+	return &Message{
+		ID:      uuid.NewString(),
+		RoomID:  roomID,
+		Content: content,
+		Type:    TypeSystem,
+	}
+}
+
+// NewWebhookMessage creates a message posted by a webhook, shown
+// under the given name and avatar rather than a user's.
+func NewWebhookMessage(roomID, webhookID, senderName, senderAvatarURL, content string) *Message {
+	return &Message{
+		ID:              uuid.NewString(),
+		RoomID:          roomID,
+		Content:         content,
+		Type:            TypeWebhook,
+		WebhookID:       &webhookID,
+		SenderName:      &senderName,
+		SenderAvatarURL: &senderAvatarURL,
+	}
+}
@@ -1,9 +1,50 @@
 package message
 
-import "github.com/purushothdl/gochat-backend/pkg/errors"
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
 
 var (
-	ErrMessageNotFound = errors.New("MESSAGE_NOT_FOUND", "The requested message was not found", 404)
-	ErrEditTimeExpired = errors.New("EDIT_TIME_EXPIRED", "The time limit for editing this message has expired", 403)
-	ErrDeleteNotAllowed = errors.New("DELETE_NOT_ALLOWED", "You do not have permission to delete this message", 403)
-)
\ No newline at end of file
+	ErrMessageNotFound        = errors.New("MESSAGE_NOT_FOUND", "The requested message was not found", 404)
+	ErrEditTimeExpired        = errors.New("EDIT_TIME_EXPIRED", "The time limit for editing this message has expired", 403)
+	ErrDeleteNotAllowed       = errors.New("DELETE_NOT_ALLOWED", "You do not have permission to delete this message", 403)
+	ErrDuplicateClientMessage = errors.New("DUPLICATE_CLIENT_MESSAGE", "A message with this client_message_id already exists", 409)
+	ErrRoomArchived           = errors.New("ROOM_ARCHIVED", "This room is archived and no longer accepts messages", 403)
+)
+
+// NewSlowModeError reports how long a member must wait before posting in a slow-mode room again.
+func NewSlowModeError(wait time.Duration) *errors.AppError {
+	secs := waitSeconds(wait)
+	return errors.New("SLOW_MODE", fmt.Sprintf("Slow mode is enabled. You can send another message in %d seconds.", secs), 429).
+		WithDetails(map[string]any{"retry_after_seconds": secs})
+}
+
+// NewMutedError reports that the member is muted in the room, and until when.
+func NewMutedError(until time.Time) *errors.AppError {
+	secs := waitSeconds(time.Until(until))
+	return errors.New("MEMBER_MUTED", fmt.Sprintf("You are muted in this room. You can send messages again in %d seconds.", secs), 403).
+		WithDetails(map[string]any{"muted_until": until, "retry_after_seconds": secs})
+}
+
+// NewRateLimitedError reports how long a user must wait after exceeding the global send rate.
+func NewRateLimitedError(wait time.Duration) *errors.AppError {
+	secs := waitSeconds(wait)
+	return errors.New("RATE_LIMITED", fmt.Sprintf("You are sending messages too quickly. Try again in %d seconds.", secs), 429).
+		WithDetails(map[string]any{"retry_after_seconds": secs})
+}
+
+// NewRejectedError reports that a moderation hook refused the content.
+func NewRejectedError(reason string) *errors.AppError {
+	if reason == "" {
+		reason = "This message was blocked by the room's content policy."
+	}
+	return errors.New("MESSAGE_REJECTED", reason, 422)
+}
+
+func waitSeconds(wait time.Duration) int {
+	return max(1, int(math.Ceil(wait.Seconds())))
+}
@@ -66,18 +66,30 @@ func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	msg, err := h.service.SendMessage(r.Context(), senderID, roomID, req.Content)
+	result, err := h.service.SendMessage(r.Context(), senderID, roomID, req.Content, req.ClientMessageID)
 	if err != nil {
 		response.Error(w, 0, err)
 		return
 	}
 
+	// Slash commands are answered with what the command did instead of a message.
+	if result.Command != nil {
+		response.JSON(w, http.StatusOK, result.Command)
+		return
+	}
+
+	// A replayed client_message_id returns the original message rather than creating a new one.
+	status := http.StatusCreated
+	if !result.Created {
+		status = http.StatusOK
+	}
+
 	msgWithFlag := &MessageWithSeenFlag{
-		Message:      *msg,
+		Message:      *result.Message,
 		IsSeenByUser: true,
 		User:         senderBasicUser, 
 	}
-	response.JSON(w, http.StatusCreated, msgWithFlag.ToResponse())
+	response.JSON(w, status, msgWithFlag.ToResponse())
 }
 
 func (h *Handler) GetMessages(w http.ResponseWriter, r *http.Request) {
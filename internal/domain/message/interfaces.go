@@ -3,6 +3,9 @@ package message
 
 import (
 	"context"
+
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
 	"github.com/purushothdl/gochat-backend/internal/shared/types"
 )
 
@@ -11,6 +14,10 @@ type RoomProvider interface {
 	GetRoomInfo(ctx context.Context, roomID string) (*types.RoomInfo, error)
 	GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error)
 	ListMembers(ctx context.Context, roomID string) ([]*types.MemberDetail, error)
+	// ListNotificationTargets returns the notification settings of those userIDs who are members of the room.
+	ListNotificationTargets(ctx context.Context, roomID string, userIDs []string) ([]*types.NotificationTarget, error)
+	// UnhideRoom brings the room back into the room lists of those userIDs who had hidden it.
+	UnhideRoom(ctx context.Context, roomID string, userIDs []string, buildEvent func(userID string, prefs types.RoomPrefs) (*contracts.OutboxEvent, error)) error
 }
 
 // UserProvider defines the methods the message service needs about users.
@@ -21,4 +28,19 @@ type UserProvider interface {
 // PresenceProvider defines the method needed to check online status.
 type PresenceProvider interface {
 	GetOnlineUserIDs(ctx context.Context, roomID string) ([]string, error)
-}
\ No newline at end of file
+}
+
+// AuditRecorder records moderators deleting other members' messages.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *audit.Entry)
+}
+
+// WebhookDispatcher hands message events to the room's outgoing webhooks.
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, event *types.WebhookEvent)
+}
+
+// CommandRouter runs the slash commands sent through SendMessage.
+type CommandRouter interface {
+	Execute(ctx context.Context, invocation *types.CommandInvocation) (*types.CommandResult, error)
+}
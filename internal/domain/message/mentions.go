@@ -0,0 +1,124 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+	"github.com/purushothdl/gochat-backend/internal/websocket"
+)
+
+// mentionPattern matches a mention of a user by ID, written <@user_id>.
+var mentionPattern = regexp.MustCompile(`<@([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})>`)
+
+const (
+	// maxMentions caps how many members a single message can notify.
+	maxMentions = 50
+	// mentionExcerptRunes is how much of the message a MENTIONED event carries.
+	mentionExcerptRunes = 140
+)
+
+// parseMentions returns the distinct user IDs mentioned in content, in order of first appearance.
+func parseMentions(content string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		id := strings.ToLower(match[1])
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+		if len(ids) == maxMentions {
+			break
+		}
+	}
+	return ids
+}
+
+// mentionEvents returns a MENTIONED event builder for each member mentioned in
+// msg whose global and per-room notification settings let it through, along
+// with those notified members who have hidden the room. The sender is never
+// notified of their own mention.
+func (s *Service) mentionEvents(ctx context.Context, targetRoom *types.RoomInfo, msg *Message) ([]EventBuilder, []string, error) {
+	var mentioned []string
+	for _, id := range parseMentions(msg.Content) {
+		if msg.UserID == nil || id != *msg.UserID {
+			mentioned = append(mentioned, id)
+		}
+	}
+	if len(mentioned) == 0 {
+		return nil, nil, nil
+	}
+
+	targets, err := s.roomProv.ListNotificationTargets(ctx, targetRoom.ID, mentioned)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load mention targets: %w", err)
+	}
+
+	now := time.Now()
+	var builders []EventBuilder
+	var hidden []string
+	for _, target := range targets {
+		if !target.NotificationsEnabled || !target.Prefs.Allows(now, true) {
+			continue
+		}
+		builders = append(builders, mentionedEvent(targetRoom.Name, target.UserID))
+		if target.Hidden {
+			hidden = append(hidden, target.UserID)
+		}
+	}
+	return builders, hidden, nil
+}
+
+// unhideForMentions returns a hidden room to the lists of members it just
+// notified. Like the mention lookup, a failure here only costs the unhide.
+func (s *Service) unhideForMentions(ctx context.Context, roomID string, userIDs []string) {
+	if len(userIDs) == 0 {
+		return
+	}
+	err := s.roomProv.UnhideRoom(ctx, roomID, userIDs, func(userID string, prefs types.RoomPrefs) (*contracts.OutboxEvent, error) {
+		event, err := websocket.NewEvent(websocket.EventRoomPrefsChanged, websocket.NewRoomPrefsPayload(roomID, prefs))
+		if err != nil {
+			return nil, err
+		}
+		return event.ToOutbox(fmt.Sprintf("user:%s", userID))
+	})
+	if err != nil {
+		s.logger.Error("failed to unhide room for mentioned members", "error", err, "room_id", roomID)
+	}
+}
+
+// mentionedEvent builds the MENTIONED event for a mentioned member's own channel.
+func mentionedEvent(roomName, userID string) EventBuilder {
+	return func(msg *Message) (*contracts.OutboxEvent, error) {
+		payload := websocket.MentionedPayload{
+			MessageID: msg.ID,
+			RoomID:    msg.RoomID,
+			RoomName:  roomName,
+			Excerpt:   excerpt(msg.Content, mentionExcerptRunes),
+			CreatedAt: msg.CreatedAt,
+		}
+		if msg.UserID != nil {
+			payload.SenderID = *msg.UserID
+		}
+		event, err := websocket.NewEvent(websocket.EventMentioned, payload)
+		if err != nil {
+			return nil, err
+		}
+		return event.ToOutbox(fmt.Sprintf("user:%s", userID))
+	}
+}
+
+// excerpt shortens content to at most limit runes, marking the cut with an ellipsis.
+func excerpt(content string, limit int) string {
+	runes := []rune(content)
+	if len(runes) <= limit {
+		return content
+	}
+	return string(runes[:limit-1]) + "…"
+}
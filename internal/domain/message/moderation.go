@@ -0,0 +1,115 @@
+package message
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// ModerationAction is a hook's verdict on a piece of content.
+type ModerationAction string
+
+const (
+	ModerationAllow  ModerationAction = "ALLOW"
+	ModerationReject ModerationAction = "REJECT"
+	ModerationMask   ModerationAction = "MASK"
+	ModerationFlag   ModerationAction = "FLAG"
+)
+
+// ModerationRequest is the content a hook is asked to screen.
+type ModerationRequest struct {
+	RoomID    string
+	UserID    string
+	MessageID string // Empty for new messages
+	Content   string
+}
+
+// ModerationResult is a hook's verdict. Content carries the replacement text
+// when Action is ModerationMask; Reason is shown to the sender on rejection
+// and stored with flags.
+type ModerationResult struct {
+	Action  ModerationAction
+	Content string
+	Reason  string
+}
+
+// ModerationHook screens message content before it is stored. Hooks run in
+// order; each sees the content as masked by the hooks before it.
+type ModerationHook interface {
+	// Name identifies the hook in flags and is the key of its per-room toggle.
+	Name() string
+	Moderate(ctx context.Context, req *ModerationRequest) (*ModerationResult, error)
+}
+
+// MessageFlag records content a hook let through but marked for review.
+type MessageFlag struct {
+	ID        string
+	MessageID string
+	RoomID    string
+	Hook      string
+	Reason    string
+}
+
+// moderationOutcome is the combined verdict of every enabled hook.
+type moderationOutcome struct {
+	Content string
+	Flags   []*MessageFlag
+}
+
+// moderate runs the enabled hooks over content. A rejection stops the pipeline
+// and is returned as an error; masks rewrite the content for later hooks; flags
+// are collected so they can be stored once the message has an ID.
+func (s *Service) moderate(ctx context.Context, room *types.RoomInfo, req *ModerationRequest) (*moderationOutcome, error) {
+	outcome := &moderationOutcome{Content: req.Content}
+
+	for _, hook := range s.hooks {
+		if !room.ModerationSettings.Enabled(hook.Name()) {
+			continue
+		}
+
+		req.Content = outcome.Content
+		result, err := hook.Moderate(ctx, req)
+		if err != nil {
+			if s.config.Moderation.FailOpen {
+				s.logger.Error("moderation hook failed, allowing content", "hook", hook.Name(), "error", err, "room_id", room.ID)
+				continue
+			}
+			return nil, fmt.Errorf("moderation hook %s failed: %w", hook.Name(), err)
+		}
+
+		switch result.Action {
+		case ModerationReject:
+			return nil, NewRejectedError(result.Reason)
+		case ModerationMask:
+			outcome.Content = result.Content
+		case ModerationFlag:
+			outcome.Flags = append(outcome.Flags, &MessageFlag{
+				ID:     uuid.NewString(),
+				RoomID: room.ID,
+				Hook:   hook.Name(),
+				Reason: result.Reason,
+			})
+		}
+	}
+
+	if outcome.Content == "" {
+		return nil, NewRejectedError("Message is empty after moderation")
+	}
+	return outcome, nil
+}
+
+// recordFlags stores the flags raised for a message. Failures are logged so
+// that a flagged message is still delivered.
+func (s *Service) recordFlags(ctx context.Context, messageID string, flags []*MessageFlag) {
+	if len(flags) == 0 {
+		return
+	}
+	for _, f := range flags {
+		f.MessageID = messageID
+	}
+	if err := s.msgRepo.CreateMessageFlags(ctx, flags); err != nil {
+		s.logger.Error("failed to record message flags", "error", err, "message_id", messageID)
+	}
+}
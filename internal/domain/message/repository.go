@@ -4,14 +4,21 @@ import (
 	"context"
 	"time"
 
+	"github.com/purushothdl/gochat-backend/internal/contracts"
 	"github.com/purushothdl/gochat-backend/internal/shared/types"
 )
 
+// EventBuilder produces a real-time event for a message once the insert has
+// filled in its timestamps. The event is stored in the same transaction.
+type EventBuilder func(msg *Message) (*contracts.OutboxEvent, error)
+
 type Repository interface {
-	CreateMessage(ctx context.Context, msg *Message) error
+	CreateMessage(ctx context.Context, msg *Message, buildEvents ...EventBuilder) error
 	GetMessageByID(ctx context.Context, messageID string) (*Message, error)
+	GetMessageByClientID(ctx context.Context, roomID, userID, clientMessageID string) (*Message, error)
 	ListMessagesByRoom(ctx context.Context, roomID, userID string, cursor PaginationCursor) ([]*MessageWithSeenFlag, error)
 	UpdateMessage(ctx context.Context, messageID, content string) error
+	CreateMessageFlags(ctx context.Context, flags []*MessageFlag) error
 
 	SoftDeleteMessage(ctx context.Context, messageID string) error
 	DeleteMessageForUser(ctx context.Context, messageID, userID string) error
@@ -3,7 +3,8 @@ package message
 import "time"
 
 type CreateMessageRequest struct {
-	Content string `json:"content" validate:"required,min=1,max=2000"`
+	Content         string `json:"content" validate:"required,min=1,max=2000"`
+	ClientMessageID string `json:"client_message_id" validate:"omitempty,max=64"` // Optional; a retry with the same id returns the original message
 }
 
 type UpdateMessageRequest struct {
@@ -19,6 +20,15 @@ type ReadMarkerRequest struct {
 	LastReadTimestamp time.Time `json:"last_read_timestamp" validate:"required"`
 }
 
+// WebhookPost is a message an incoming webhook posts into its room.
+type WebhookPost struct {
+	RoomID          string
+	WebhookID       string
+	SenderName      string
+	SenderAvatarURL string
+	Content         string
+}
+
 type PaginationCursor struct {
 	Timestamp time.Time
 	Limit     int
@@ -7,14 +7,15 @@ import (
 )
 
 type MessageResponse struct {
-	ID              string             `json:"id"`
-	RoomID          string             `json:"room_id"`
-	Content         string             `json:"content"`
-	Type            MessageType        `json:"type"`
-	CreatedAt       time.Time          `json:"created_at"`
-	UpdatedAt       time.Time          `json:"updated_at"`
-	IsEdited        bool               `json:"is_edited"`
-	Sender          *types.BasicUser   `json:"sender,omitempty"`
+	ID              string           `json:"id"`
+	RoomID          string           `json:"room_id"`
+	Content         string           `json:"content"`
+	Type            MessageType      `json:"type"`
+	ClientMessageID *string          `json:"client_message_id,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	IsEdited        bool             `json:"is_edited"`
+	Sender          *types.BasicUser `json:"sender,omitempty"`
 }
 
 // PaginatedMessagesResponse is the structured response for message history.
@@ -46,18 +47,35 @@ func (m *MessageWithSeenFlag) ToResponse() *MessageResponse {
 			CreatedAt: m.CreatedAt,
 			UpdatedAt: m.UpdatedAt,
 			IsEdited:  m.UpdatedAt.After(m.CreatedAt.Add(5 * time.Second)), // Add buffer
-			Sender:    nil, 
+			Sender:    nil,
 		}
 	}
-	
+
 	return &MessageResponse{
-		ID:        m.ID,
-		RoomID:    m.RoomID,
-		Content:   m.Content,
-		Type:      m.Type,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
-		IsEdited:  m.UpdatedAt.After(m.CreatedAt.Add(5 * time.Second)),
-		Sender:    m.User,
+		ID:              m.ID,
+		RoomID:          m.RoomID,
+		Content:         m.Content,
+		Type:            m.Type,
+		ClientMessageID: m.ClientMessageID,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+		IsEdited:        m.UpdatedAt.After(m.CreatedAt.Add(5 * time.Second)),
+		Sender:          m.sender(),
+	}
+}
+
+// sender is the message's author, or for a webhook message the webhook shown
+// under the name and avatar it posted with.
+func (m *MessageWithSeenFlag) sender() *types.BasicUser {
+	if m.User != nil || m.WebhookID == nil {
+		return m.User
 	}
-}
\ No newline at end of file
+	webhook := &types.BasicUser{ID: *m.WebhookID}
+	if m.SenderName != nil {
+		webhook.Name = *m.SenderName
+	}
+	if m.SenderAvatarURL != nil {
+		webhook.ImageURL = *m.SenderAvatarURL
+	}
+	return webhook
+}
@@ -3,14 +3,17 @@ package message
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/purushothdl/gochat-backend/internal/config"
 	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/shared/authz"
 	"github.com/purushothdl/gochat-backend/internal/shared/types"
+	"github.com/purushothdl/gochat-backend/internal/websocket"
 	"github.com/purushothdl/gochat-backend/pkg/errors"
 )
 
@@ -19,7 +22,11 @@ type Service struct {
 	roomProv     RoomProvider
 	userProv     UserProvider
 	presenceProv PresenceProvider
-	pubSub       contracts.PubSub
+	limiter      contracts.RateLimiter
+	hooks        []ModerationHook
+	auditor      AuditRecorder
+	webhooks     WebhookDispatcher
+	commands     CommandRouter
 	config       *config.Config
 	logger       *slog.Logger
 }
@@ -29,7 +36,10 @@ func NewService(
 	roomProv RoomProvider,
 	userProv UserProvider,
 	presenceProv PresenceProvider,
-	pubSub contracts.PubSub,
+	limiter contracts.RateLimiter,
+	hooks []ModerationHook,
+	auditor AuditRecorder,
+	webhooks WebhookDispatcher,
 	cfg *config.Config,
 	logger *slog.Logger,
 ) *Service {
@@ -38,49 +48,292 @@ func NewService(
 		roomProv:     roomProv,
 		userProv:     userProv,
 		presenceProv: presenceProv,
-		pubSub:       pubSub,
+		limiter:      limiter,
+		hooks:        hooks,
+		auditor:      auditor,
+		webhooks:     webhooks,
 		config:       cfg,
 		logger:       logger,
 	}
 }
 
-func (s *Service) SendMessage(ctx context.Context, senderID, roomID, content string) (*Message, error) {
-	membership, err := s.roomProv.GetMembershipInfo(ctx, roomID, senderID)
+// SetCommandRouter routes slash commands sent through SendMessage. The router
+// posts through this service itself, so it is wired in once both exist; until
+// then commands are sent as plain text.
+func (s *Service) SetCommandRouter(commands CommandRouter) {
+	s.commands = commands
+}
+
+// SendResult is what SendMessage did with the content: either stored it as a
+// message or ran it as a slash command.
+type SendResult struct {
+	Message *Message
+	Created bool                 // False when a replayed client message ID returned the original
+	Command *types.CommandResult // Set instead of Message when the content was a command
+}
+
+// SendMessage stores and broadcasts a new message. Content that starts with a
+// slash command is run by the command router instead; a leading "//" escapes
+// the slash and sends the rest as a message. When clientMessageID is set and
+// the sender already stored a message under it, the original is returned with
+// Created false instead of inserting a duplicate. Commands that post for the
+// sender, such as /poll and /me, store their message under the same id.
+func (s *Service) SendMessage(ctx context.Context, senderID, roomID, content, clientMessageID string) (*SendResult, error) {
+	if strings.HasPrefix(content, "//") {
+		content = content[1:]
+	} else if _, _, isCommand := types.ParseCommand(content); isCommand && s.commands != nil {
+		result, err := s.commands.Execute(ctx, &types.CommandInvocation{
+			RoomID:          roomID,
+			UserID:          senderID,
+			Text:            content,
+			ClientMessageID: clientMessageID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &SendResult{Command: result}, nil
+	}
+
+	msg, created, err := s.send(ctx, TypeText, senderID, roomID, content, clientMessageID)
 	if err != nil {
 		return nil, err
 	}
+	return &SendResult{Message: msg, Created: created}, nil
+}
+
+// SendTextMessage posts content exactly as given, without running it as a
+// slash command. Commands and their handlers post through it so that their
+// output is never taken for another command. clientMessageID makes retries
+// idempotent as it does for SendMessage, and may be empty.
+func (s *Service) SendTextMessage(ctx context.Context, senderID, roomID, content, clientMessageID string) (*Message, error) {
+	msg, _, err := s.send(ctx, TypeText, senderID, roomID, content, clientMessageID)
+	return msg, err
+}
+
+// SendActionMessage posts an ACTION message, as written with /me. It is sent
+// like any other message from the sender.
+func (s *Service) SendActionMessage(ctx context.Context, senderID, roomID, content, clientMessageID string) (*Message, error) {
+	msg, _, err := s.send(ctx, TypeAction, senderID, roomID, content, clientMessageID)
+	return msg, err
+}
+
+func (s *Service) send(ctx context.Context, msgType MessageType, senderID, roomID, content, clientMessageID string) (*Message, bool, error) {
+	membership, err := s.roomProv.GetMembershipInfo(ctx, roomID, senderID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Replays are answered before any limits so a retried send is never rejected as a new one.
+	if clientMessageID != "" {
+		existing, err := s.msgRepo.GetMessageByClientID(ctx, roomID, senderID, clientMessageID)
+		if err == nil {
+			return existing, false, nil
+		}
+		if err != ErrMessageNotFound {
+			return nil, false, err
+		}
+	}
 
 	targetRoom, err := s.roomProv.GetRoomInfo(ctx, roomID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if targetRoom.IsArchived {
+		return nil, false, ErrRoomArchived
 	}
 
-	if targetRoom.IsBroadcastOnly && membership.Role != types.AdminRole {
-		return nil, errors.New("BROADCAST_ONLY", "Only admins can send messages in this room.", 403)
+	if err := authz.Require(membership.Role, membership.MemberPermissions, authz.SendMessages); err != nil {
+		return nil, false, err
+	}
+	if membership.MutedUntil != nil && membership.MutedUntil.After(time.Now()) {
+		return nil, false, NewMutedError(*membership.MutedUntil)
+	}
+	if targetRoom.IsBroadcastOnly && !authz.AtLeast(membership.Role, types.ModeratorRole) {
+		return nil, false, errors.New("BROADCAST_ONLY", "Only moderators and admins can send messages in this room.", 403)
+	}
+
+	refund, err := s.enforceSendLimits(ctx, senderID, targetRoom, membership)
+	if err != nil {
+		return nil, false, err
+	}
+
+	outcome, err := s.moderate(ctx, targetRoom, &ModerationRequest{RoomID: roomID, UserID: senderID, Content: content})
+	if err != nil {
+		refund()
+		return nil, false, err
 	}
 
-	msg := NewTextMessage(roomID, senderID, content)
-	if err := s.msgRepo.CreateMessage(ctx, msg); err != nil {
-		return nil, fmt.Errorf("failed to send message: %w", err)
+	msg := NewTextMessage(roomID, senderID, outcome.Content)
+	msg.Type = msgType
+	if clientMessageID != "" {
+		msg.ClientMessageID = &clientMessageID
+	}
+
+	// A failed mention lookup only costs the notifications, not the message.
+	events := []EventBuilder{messageCreatedEvent}
+	mentions, hiddenBy, err := s.mentionEvents(ctx, targetRoom, msg)
+	if err != nil {
+		s.logger.Error("failed to resolve mentions", "error", err, "room_id", roomID)
+	}
+	events = append(events, mentions...)
+
+	if err := s.msgRepo.CreateMessage(ctx, msg, events...); err != nil {
+		refund()
+		if err == ErrDuplicateClientMessage {
+			// A concurrent retry won the insert; hand back the stored message.
+			existing, err := s.msgRepo.GetMessageByClientID(ctx, roomID, senderID, clientMessageID)
+			if err != nil {
+				return nil, false, err
+			}
+			return existing, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to send message: %w", err)
 	}
 
 	s.logger.Info("message sent", "message_id", msg.ID, "room_id", roomID)
+	s.recordFlags(ctx, msg.ID, outcome.Flags)
+	s.unhideForMentions(ctx, roomID, hiddenBy)
+	s.webhooks.Dispatch(ctx, messageCreatedWebhookEvent(msg))
+
+	return msg, true, nil
+}
+
+// enforceSendLimits applies, for regular members, the room's slow mode and then
+// the global per-user send ceiling, so a send slow mode turns away costs none of
+// the user's quota. It returns a refund that gives back whatever was charged, for
+// sends that are rejected or fail later on. Limiter outages are logged and fail
+// open so that a Redis problem degrades to unthrottled chat rather than no chat at all.
+func (s *Service) enforceSendLimits(ctx context.Context, senderID string, targetRoom *types.RoomInfo, membership *types.MembershipInfo) (func(), error) {
+	var slotKey, quotaKey string
+
+	if targetRoom.SlowModeSeconds > 0 && !authz.AtLeast(membership.Role, types.ModeratorRole) {
+		key := fmt.Sprintf("slowmode:%s:%s", targetRoom.ID, senderID)
+		window := time.Duration(targetRoom.SlowModeSeconds) * time.Second
+		allowed, retryAfter, err := s.limiter.AcquireSlot(ctx, key, window)
+		switch {
+		case err != nil:
+			s.logger.Error("failed to check slow mode", "error", err, "room_id", targetRoom.ID, "user_id", senderID)
+		case !allowed:
+			return nil, NewSlowModeError(retryAfter)
+		default:
+			slotKey = key
+		}
+	}
+
+	limits := s.config.RateLimit
+	if limits.MessagesPerWindow > 0 {
+		key := fmt.Sprintf("send:%s", senderID)
+		allowed, retryAfter, err := s.limiter.Allow(ctx, key, limits.MessagesPerWindow, limits.MessageWindow)
+		switch {
+		case err != nil:
+			s.logger.Error("failed to check send rate limit", "error", err, "user_id", senderID)
+		case !allowed:
+			s.refundSendLimits(ctx, senderID, slotKey, "")
+			return nil, NewRateLimitedError(retryAfter)
+		default:
+			quotaKey = key
+		}
+	}
+
+	return func() { s.refundSendLimits(ctx, senderID, slotKey, quotaKey) }, nil
+}
 
-	// Publish the message to the Redis channel
-	channel := fmt.Sprintf("room:%s:messages", roomID)
-	messageJSON, err := json.Marshal(msg)
+// refundSendLimits frees the slow mode slot and gives back the quota hit a send
+// was charged. It runs even if the request was cancelled, as that is often why
+// the send failed.
+func (s *Service) refundSendLimits(ctx context.Context, senderID, slotKey, quotaKey string) {
+	ctx = context.WithoutCancel(ctx)
+	if slotKey != "" {
+		if err := s.limiter.ReleaseSlot(ctx, slotKey); err != nil {
+			s.logger.Error("failed to release slow mode slot", "error", err, "user_id", senderID)
+		}
+	}
+	if quotaKey != "" {
+		if err := s.limiter.Refund(ctx, quotaKey); err != nil {
+			s.logger.Error("failed to refund send rate limit", "error", err, "user_id", senderID)
+		}
+	}
+}
+
+// PostSystemMessage writes a server-generated notice into a room and broadcasts it to connected members.
+func (s *Service) PostSystemMessage(ctx context.Context, roomID, content string) (*Message, error) {
+	msg := NewSystemMessage(roomID, content)
+	if err := s.msgRepo.CreateMessage(ctx, msg, messageCreatedEvent); err != nil {
+		return nil, fmt.Errorf("failed to post system message: %w", err)
+	}
+
+	s.logger.Info("system message posted", "message_id", msg.ID, "room_id", roomID)
+	return msg, nil
+}
+
+// PostWebhookMessage stores and broadcasts a message posted by an incoming
+// webhook or by an outgoing webhook answering a slash command. It goes through
+// the same archive check, moderation hooks and mention notifications as a
+// member's message; membership, permission and per-user limits do not apply,
+// as the webhook is rate-limited by its caller.
+func (s *Service) PostWebhookMessage(ctx context.Context, post WebhookPost) (*Message, error) {
+	targetRoom, err := s.roomProv.GetRoomInfo(ctx, post.RoomID)
 	if err != nil {
-		s.logger.Error("failed to marshal message for Redis", "error", err)
-		return msg, nil
+		return nil, err
+	}
+	if targetRoom.IsArchived {
+		return nil, ErrRoomArchived
 	}
 
-	if err := s.pubSub.Publish(ctx, channel, string(messageJSON)); err != nil {
-		s.logger.Error("failed to publish message to Redis", "error", err)
+	outcome, err := s.moderate(ctx, targetRoom, &ModerationRequest{RoomID: post.RoomID, Content: post.Content})
+	if err != nil {
+		return nil, err
 	}
 
+	msg := NewWebhookMessage(post.RoomID, post.WebhookID, post.SenderName, post.SenderAvatarURL, outcome.Content)
+
+	events := []EventBuilder{messageCreatedEvent}
+	mentions, hiddenBy, err := s.mentionEvents(ctx, targetRoom, msg)
+	if err != nil {
+		s.logger.Error("failed to resolve mentions", "error", err, "room_id", post.RoomID)
+	}
+	events = append(events, mentions...)
+
+	if err := s.msgRepo.CreateMessage(ctx, msg, events...); err != nil {
+		return nil, fmt.Errorf("failed to post webhook message: %w", err)
+	}
+
+	s.logger.Info("webhook message posted", "message_id", msg.ID, "room_id", post.RoomID, "webhook_id", post.WebhookID)
+	s.recordFlags(ctx, msg.ID, outcome.Flags)
+	s.unhideForMentions(ctx, post.RoomID, hiddenBy)
+	s.webhooks.Dispatch(ctx, messageCreatedWebhookEvent(msg))
+
 	return msg, nil
 }
 
+// messageCreatedEvent wraps a stored message in a MESSAGE_CREATED event for
+// the room's channel. The outbox relay delivers it once the insert commits.
+func messageCreatedEvent(msg *Message) (*contracts.OutboxEvent, error) {
+	event, err := websocket.NewEvent(websocket.EventMessageCreated, msg)
+	if err != nil {
+		return nil, err
+	}
+	return event.ToOutbox(fmt.Sprintf("room:%s:messages", msg.RoomID))
+}
+
+// messageCreatedWebhookEvent describes a new message for outgoing webhooks.
+func messageCreatedWebhookEvent(msg *Message) *types.WebhookEvent {
+	return &types.WebhookEvent{
+		Type:   types.WebhookMessageCreated,
+		RoomID: msg.RoomID,
+		Data: map[string]any{
+			"message_id":        msg.ID,
+			"type":              msg.Type,
+			"user_id":           msg.UserID,
+			"webhook_id":        msg.WebhookID,
+			"sender_name":       msg.SenderName,
+			"sender_avatar_url": msg.SenderAvatarURL,
+			"content":           msg.Content,
+			"created_at":        msg.CreatedAt,
+		},
+	}
+}
+
 func (s *Service) GetMessageHistory(ctx context.Context, userID, roomID string, limit int, before time.Time) ([]*MessageWithSeenFlag, error) {
 	if _, err := s.roomProv.GetMembershipInfo(ctx, roomID, userID); err != nil {
 		return nil, err
@@ -105,8 +358,24 @@ func (s *Service) EditMessage(ctx context.Context, actorID, messageID, newConten
 		return ErrEditTimeExpired
 	}
 
+	targetRoom, err := s.roomProv.GetRoomInfo(ctx, msg.RoomID)
+	if err != nil {
+		return err
+	}
+	if targetRoom.IsArchived {
+		return ErrRoomArchived
+	}
+	outcome, err := s.moderate(ctx, targetRoom, &ModerationRequest{RoomID: msg.RoomID, UserID: actorID, MessageID: messageID, Content: newContent})
+	if err != nil {
+		return err
+	}
+
 	// TODO: Publish real-time event via Redis
-	return s.msgRepo.UpdateMessage(ctx, messageID, newContent)
+	if err := s.msgRepo.UpdateMessage(ctx, messageID, outcome.Content); err != nil {
+		return err
+	}
+	s.recordFlags(ctx, messageID, outcome.Flags)
+	return nil
 }
 
 func (s *Service) DeleteMessage(ctx context.Context, actorID, messageID, scope string) error {
@@ -124,14 +393,25 @@ func (s *Service) DeleteMessage(ctx context.Context, actorID, messageID, scope s
 	}
 
 	isSender := msg.UserID != nil && *msg.UserID == actorID
-	isAdmin := membership.Role == types.AdminRole
-
-	if !isSender && !isAdmin {
+	if !isSender && !authz.Can(membership.Role, membership.MemberPermissions, authz.DeleteMessages) {
 		return ErrDeleteNotAllowed
 	}
 
 	// TODO: Publish real-time event via Redis
-	return s.msgRepo.SoftDeleteMessage(ctx, messageID)
+	if err := s.msgRepo.SoftDeleteMessage(ctx, messageID); err != nil {
+		return err
+	}
+	if !isSender {
+		s.auditor.Record(ctx, audit.NewEntry(msg.RoomID, actorID, audit.ActionMessageDeleted, "message", messageID, map[string]any{
+			"sender_id": msg.UserID,
+		}))
+	}
+	s.webhooks.Dispatch(ctx, &types.WebhookEvent{
+		Type:   types.WebhookMessageDeleted,
+		RoomID: msg.RoomID,
+		Data:   map[string]any{"message_id": messageID, "deleted_by": actorID},
+	})
+	return nil
 }
 
 func (s *Service) MarkMessagesAsSeen(ctx context.Context, userID, roomID string, messageIDs []string) error {
@@ -218,6 +498,7 @@ func (s *Service) GetMessageReceipts(ctx context.Context, actorID, messageID str
 					ID:       member.UserID,
 					Name:     member.Name,
 					ImageURL: member.ImageURL,
+					IsBot:    member.IsBot,
 				},
 			})
 		}
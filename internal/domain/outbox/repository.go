@@ -0,0 +1,20 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+)
+
+type Repository interface {
+	// ClaimPending leases up to limit due events to this relay for the given duration.
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*contracts.OutboxEvent, error)
+	MarkPublished(ctx context.Context, eventID string) error
+	MarkFailed(ctx context.Context, eventID, lastError string, nextAttemptAt time.Time) error
+	DeletePublishedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+
+	// WaitForEvents blocks until new events are committed or timeout elapses.
+	WaitForEvents(ctx context.Context, timeout time.Duration) error
+	Close()
+}
@@ -0,0 +1,156 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/websocket"
+)
+
+// cleanupInterval is how often delivered events older than the retention window are removed.
+const cleanupInterval = 10 * time.Minute
+
+// Worker relays committed outbox events to the channel's event log and then to
+// Pub/Sub. Delivery is at-least-once: an event is only marked published after
+// both succeed, so a crash in between sends it again and clients deduplicate
+// on the event ID.
+type Worker struct {
+	outboxRepo Repository
+	eventLog   contracts.EventLog
+	pubSub     contracts.PubSub
+	config     *config.Config
+	logger     *slog.Logger
+}
+
+func NewWorker(
+	outboxRepo Repository,
+	eventLog contracts.EventLog,
+	pubSub contracts.PubSub,
+	config *config.Config,
+	logger *slog.Logger,
+) *Worker {
+	return &Worker{
+		outboxRepo: outboxRepo,
+		eventLog:   eventLog,
+		pubSub:     pubSub,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	cfg := w.config.Outbox
+	w.logger.Info("starting outbox relay worker...", "poll_interval", cfg.PollInterval, "batch_size", cfg.BatchSize)
+	defer w.outboxRepo.Close()
+
+	lastCleanup := time.Time{}
+	for {
+		if ctx.Err() != nil {
+			w.logger.Info("outbox relay worker shutting down")
+			return
+		}
+
+		relayed := w.relayBatch(ctx)
+
+		if time.Since(lastCleanup) >= cleanupInterval {
+			w.cleanup(ctx)
+			lastCleanup = time.Now()
+		}
+
+		// A full batch means more events are probably waiting, so go straight back for them.
+		if relayed == cfg.BatchSize {
+			continue
+		}
+		if err := w.outboxRepo.WaitForEvents(ctx, cfg.PollInterval); err != nil {
+			w.logger.Error("failed to wait for outbox events", "error", err)
+			w.sleep(ctx, cfg.PollInterval)
+		}
+	}
+}
+
+// relayBatch claims due events and publishes them in creation order, returning how many were claimed.
+// Once an event fails, later events on its channel are held back until its retry so that the
+// channel's history stays in order.
+func (w *Worker) relayBatch(ctx context.Context) int {
+	cfg := w.config.Outbox
+	events, err := w.outboxRepo.ClaimPending(ctx, cfg.BatchSize, cfg.Lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("failed to claim outbox events", "error", err)
+		}
+		return 0
+	}
+
+	blocked := make(map[string]time.Time) // Channel -> retry time of its failed event
+	for _, event := range events {
+		if retryAt, ok := blocked[event.Channel]; ok {
+			// Sharing the failed event's retry time keeps this one behind it on the next claim.
+			if err := w.outboxRepo.MarkFailed(ctx, event.ID, "held behind an earlier event on the channel", retryAt); err != nil {
+				w.logger.Error("failed to hold back outbox event", "error", err, "event_id", event.ID)
+			}
+			continue
+		}
+
+		if err := w.deliver(ctx, event); err != nil {
+			retryAt := time.Now().Add(w.backoff(event.Attempts))
+			w.logger.Warn("failed to publish outbox event, will retry",
+				"error", err, "event_id", event.ID, "channel", event.Channel, "attempts", event.Attempts, "retry_at", retryAt)
+			if err := w.outboxRepo.MarkFailed(ctx, event.ID, err.Error(), retryAt); err != nil {
+				// The lease still expires on its own, so the event is retried either way.
+				w.logger.Error("failed to record outbox publish failure", "error", err, "event_id", event.ID)
+			}
+			blocked[event.Channel] = retryAt
+			continue
+		}
+
+		if err := w.outboxRepo.MarkPublished(ctx, event.ID); err != nil {
+			// The event will be published again once its lease expires; clients drop the duplicate.
+			w.logger.Error("failed to mark outbox event published", "error", err, "event_id", event.ID)
+		}
+	}
+	return len(events)
+}
+
+// deliver appends the event to its channel's history, so reconnecting clients
+// can replay it, and then publishes it live stamped with its history position.
+func (w *Worker) deliver(ctx context.Context, event *contracts.OutboxEvent) error {
+	seq, err := w.eventLog.Append(ctx, event.Channel, event.Payload)
+	if err != nil {
+		return err
+	}
+	return w.pubSub.Publish(ctx, event.Channel, websocket.WithSeq(event.Payload, event.Channel, seq))
+}
+
+// backoff doubles the retry delay with every failed attempt, capped at MaxBackoff.
+func (w *Worker) backoff(attempts int) time.Duration {
+	cfg := w.config.Outbox
+	delay := cfg.MinBackoff
+	for i := 1; i < attempts && delay < cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, cfg.MaxBackoff)
+}
+
+func (w *Worker) cleanup(ctx context.Context) {
+	cutoff := time.Now().Add(-w.config.Outbox.Retention)
+	deleted, err := w.outboxRepo.DeletePublishedBefore(ctx, cutoff)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("failed to clean up published outbox events", "error", err)
+		}
+		return
+	}
+	if deleted > 0 {
+		w.logger.Info("cleaned up published outbox events", "count", deleted)
+	}
+}
+
+func (w *Worker) sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,125 @@
+package report
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+)
+
+type TargetType string
+
+const (
+	TargetMessage TargetType = "MESSAGE"
+	TargetUser    TargetType = "USER"
+)
+
+type Reason string
+
+const (
+	ReasonSpam          Reason = "SPAM"
+	ReasonHarassment    Reason = "HARASSMENT"
+	ReasonHateSpeech    Reason = "HATE_SPEECH"
+	ReasonSexualContent Reason = "SEXUAL_CONTENT"
+	ReasonViolence      Reason = "VIOLENCE"
+	ReasonImpersonation Reason = "IMPERSONATION"
+	ReasonOther         Reason = "OTHER"
+)
+
+type Status string
+
+const (
+	StatusOpen      Status = "OPEN"
+	StatusInReview  Status = "IN_REVIEW"
+	StatusResolved  Status = "RESOLVED"
+	StatusDismissed Status = "DISMISSED"
+)
+
+// IsValid reports whether s is a known report status.
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusOpen, StatusInReview, StatusResolved, StatusDismissed:
+		return true
+	}
+	return false
+}
+
+// Action is the step a moderator takes when closing a report.
+type Action string
+
+const (
+	ActionDismiss       Action = "DISMISS"
+	ActionNone          Action = "NO_ACTION"
+	ActionDeleteMessage Action = "DELETE_MESSAGE"
+	ActionRemoveMember  Action = "REMOVE_MEMBER"
+	ActionSuspendUser   Action = "SUSPEND_USER"
+)
+
+// Report is a user's complaint about a message or another account.
+type Report struct {
+	ID               string
+	ReporterID       *string
+	TargetType       TargetType
+	MessageID        *string
+	ReportedUserID   *string
+	RoomID           *string // Nil for account reports made outside a room; only system admins see those
+	Reason           Reason
+	Details          string
+	Status           Status
+	AssigneeID       *string
+	ResolutionAction *Action
+	ResolutionNote   *string
+	ResolvedBy       *string
+	ResolvedAt       *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// IsClosed reports whether the report has already been resolved or dismissed.
+func (r *Report) IsClosed() bool {
+	return r.Status == StatusResolved || r.Status == StatusDismissed
+}
+
+// NewMessageReport creates an open report against a message.
+func NewMessageReport(reporterID, messageID, authorID, roomID string, reason Reason, details string) *Report {
+	r := &Report{
+		ID:         uuid.NewString(),
+		ReporterID: &reporterID,
+		TargetType: TargetMessage,
+		MessageID:  &messageID,
+		RoomID:     &roomID,
+		Reason:     reason,
+		Details:    details,
+		Status:     StatusOpen,
+	}
+	if authorID != "" {
+		r.ReportedUserID = &authorID
+	}
+	return r
+}
+
+// NewUserReport creates an open report against an account, optionally scoped to the room it happened in.
+func NewUserReport(reporterID, userID, roomID string, reason Reason, details string) *Report {
+	r := &Report{
+		ID:             uuid.NewString(),
+		ReporterID:     &reporterID,
+		TargetType:     TargetUser,
+		ReportedUserID: &userID,
+		Reason:         reason,
+		Details:        details,
+		Status:         StatusOpen,
+	}
+	if roomID != "" {
+		r.RoomID = &roomID
+	}
+	return r
+}
+
+// ListFilter narrows the moderation queue. A nil RoomID lists every room and
+// is only honoured for system admins.
+type ListFilter struct {
+	RoomID *string
+	Status *Status
+	Cursor *response.Cursor
+	Limit  int
+}
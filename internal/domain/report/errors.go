@@ -0,0 +1,16 @@
+package report
+
+import "github.com/purushothdl/gochat-backend/pkg/errors"
+
+var (
+	ErrReportNotFound    = errors.New("REPORT_NOT_FOUND", "The requested report was not found", 404)
+	ErrAlreadyReported   = errors.New("ALREADY_REPORTED", "You already have an open report for this", 409)
+	ErrCannotReportSelf  = errors.New("CANNOT_REPORT_SELF", "You cannot report yourself or your own messages", 400)
+	ErrNotModerator      = errors.New("NOT_MODERATOR", "You must be a room moderator or system admin to moderate reports", 403)
+	ErrReportClosed      = errors.New("REPORT_CLOSED", "This report has already been closed", 409)
+	ErrActionNotAllowed  = errors.New("ACTION_NOT_ALLOWED", "This action does not apply to this report", 400)
+	ErrSuspendNotAllowed = errors.New("SUSPEND_NOT_ALLOWED", "Only system admins can suspend accounts", 403)
+	ErrUserNotFound      = errors.New("USER_NOT_FOUND", "The reported user was not found", 404)
+	ErrInvalidStatus     = errors.New("INVALID_STATUS", "Status must be one of OPEN, IN_REVIEW, RESOLVED or DISMISSED", 400)
+	ErrRoomRequired      = errors.New("ROOM_REQUIRED", "Room moderators must filter the queue by a room they moderate", 400)
+)
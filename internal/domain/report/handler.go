@@ -0,0 +1,198 @@
+package report
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/validator"
+	authMiddleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+type Handler struct {
+	service   *Service
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewHandler(service *Service, logger *slog.Logger, v *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		logger:    logger,
+		validator: v,
+	}
+}
+
+// ReportMessage handles POST /api/messages/{message_id}/report
+func (h *Handler) ReportMessage(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	messageID := chi.URLParam(r, "message_id")
+
+	var req ReportMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	report, err := h.service.ReportMessage(r.Context(), actorID, messageID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, report)
+}
+
+// ReportUser handles POST /api/users/{user_id}/report
+func (h *Handler) ReportUser(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	userID := chi.URLParam(r, "user_id")
+
+	var req ReportUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	report, err := h.service.ReportUser(r.Context(), actorID, userID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, report)
+}
+
+// ListReports handles GET /api/moderation/reports?status=&room_id=&cursor=&limit=
+func (h *Handler) ListReports(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	cursor, err := response.DecodeCursor(query.Get("cursor"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	filter := ListFilter{
+		Cursor: cursor,
+		Limit:  response.ParseLimit(r, 25, 100),
+	}
+	if roomID := query.Get("room_id"); roomID != "" {
+		filter.RoomID = &roomID
+	}
+	if status := query.Get("status"); status != "" {
+		s := Status(status)
+		if !s.IsValid() {
+			response.Error(w, 0, ErrInvalidStatus)
+			return
+		}
+		filter.Status = &s
+	}
+
+	page, err := h.service.ListReports(r.Context(), actorID, filter)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, page)
+}
+
+// GetReport handles GET /api/moderation/reports/{report_id}
+func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	reportID := chi.URLParam(r, "report_id")
+
+	report, err := h.service.GetReport(r.Context(), actorID, reportID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, report)
+}
+
+// AssignReport handles POST /api/moderation/reports/{report_id}/assign
+func (h *Handler) AssignReport(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	reportID := chi.URLParam(r, "report_id")
+
+	var req AssignReportRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	report, err := h.service.AssignReport(r.Context(), actorID, reportID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, report)
+}
+
+// ResolveReport handles POST /api/moderation/reports/{report_id}/resolve
+func (h *Handler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	reportID := chi.URLParam(r, "report_id")
+
+	var req ResolveReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	report, err := h.service.ResolveReport(r.Context(), actorID, reportID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, report)
+}
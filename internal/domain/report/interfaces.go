@@ -0,0 +1,49 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// MessageProvider looks up reported messages and removes them when a report is upheld.
+type MessageProvider interface {
+	GetMessageInfo(ctx context.Context, messageID string) (*types.MessageInfo, error)
+	SoftDeleteMessage(ctx context.Context, messageID string) error
+}
+
+// RoomProvider defines the methods the report service needs about rooms.
+type RoomProvider interface {
+	GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error)
+}
+
+// MemberRemover kicks a member on behalf of a room admin or a system admin,
+// applying the room's own rules.
+type MemberRemover interface {
+	RemoveMember(ctx context.Context, actorID, roomID, targetUserID string) error
+	RemoveMemberAsAdmin(ctx context.Context, actorID, roomID, targetUserID, reason string) error
+}
+
+// UserProvider defines the methods the report service needs about accounts.
+type UserProvider interface {
+	GetByIDShared(ctx context.Context, id string) (*types.User, error)
+	ExistsByID(ctx context.Context, id string) (bool, error)
+	SuspendUser(ctx context.Context, userID string, until time.Time) error
+}
+
+// SessionRevoker signs a suspended user out of every device.
+type SessionRevoker interface {
+	DeleteUserRefreshTokens(ctx context.Context, userID string) error
+}
+
+// AuditRecorder records reports and the moderation actions taken on them.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *audit.Entry)
+}
+
+// WebhookDispatcher tells outgoing webhooks about messages and members removed by moderators.
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, event *types.WebhookEvent)
+}
@@ -0,0 +1,18 @@
+package report
+
+import "context"
+
+type Repository interface {
+	// CreateReport stores a new report, returning ErrAlreadyReported when the
+	// reporter already has an unresolved report against the same target.
+	CreateReport(ctx context.Context, report *Report) error
+	GetReportByID(ctx context.Context, reportID string) (*Report, error)
+	ListReports(ctx context.Context, filter ListFilter) ([]*Report, error)
+
+	// AssignReport sets the assignee and moves an open report into review.
+	AssignReport(ctx context.Context, reportID, assigneeID string) error
+
+	// CloseReport persists the report's resolution fields. It returns
+	// ErrReportClosed if the report was closed concurrently.
+	CloseReport(ctx context.Context, report *Report) error
+}
@@ -0,0 +1,25 @@
+package report
+
+type ReportMessageRequest struct {
+	Reason  Reason `json:"reason" validate:"required,oneof=SPAM HARASSMENT HATE_SPEECH SEXUAL_CONTENT VIOLENCE IMPERSONATION OTHER"`
+	Details string `json:"details" validate:"max=1000"`
+}
+
+// ReportUserRequest reports an account. RoomID optionally names the room the
+// behaviour happened in so that its admins can act on the report.
+type ReportUserRequest struct {
+	Reason  Reason `json:"reason" validate:"required,oneof=SPAM HARASSMENT HATE_SPEECH SEXUAL_CONTENT VIOLENCE IMPERSONATION OTHER"`
+	Details string `json:"details" validate:"max=1000"`
+	RoomID  string `json:"room_id" validate:"omitempty,uuid"`
+}
+
+// AssignReportRequest assigns a report. An empty AssigneeID assigns it to the caller.
+type AssignReportRequest struct {
+	AssigneeID string `json:"assignee_id" validate:"omitempty,uuid"`
+}
+
+type ResolveReportRequest struct {
+	Action       Action `json:"action" validate:"required,oneof=DISMISS NO_ACTION DELETE_MESSAGE REMOVE_MEMBER SUSPEND_USER"`
+	Note         string `json:"note" validate:"max=1000"`
+	SuspendHours int    `json:"suspend_hours" validate:"required_if=Action SUSPEND_USER,omitempty,min=1,max=8760"`
+}
@@ -0,0 +1,43 @@
+package report
+
+import "time"
+
+type ReportResponse struct {
+	ID               string     `json:"id"`
+	ReporterID       *string    `json:"reporter_id"`
+	TargetType       TargetType `json:"target_type"`
+	MessageID        *string    `json:"message_id,omitempty"`
+	ReportedUserID   *string    `json:"reported_user_id,omitempty"`
+	RoomID           *string    `json:"room_id,omitempty"`
+	Reason           Reason     `json:"reason"`
+	Details          string     `json:"details"`
+	Status           Status     `json:"status"`
+	AssigneeID       *string    `json:"assignee_id,omitempty"`
+	ResolutionAction *Action    `json:"resolution_action,omitempty"`
+	ResolutionNote   *string    `json:"resolution_note,omitempty"`
+	ResolvedBy       *string    `json:"resolved_by,omitempty"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func toReportResponse(r *Report) *ReportResponse {
+	return &ReportResponse{
+		ID:               r.ID,
+		ReporterID:       r.ReporterID,
+		TargetType:       r.TargetType,
+		MessageID:        r.MessageID,
+		ReportedUserID:   r.ReportedUserID,
+		RoomID:           r.RoomID,
+		Reason:           r.Reason,
+		Details:          r.Details,
+		Status:           r.Status,
+		AssigneeID:       r.AssigneeID,
+		ResolutionAction: r.ResolutionAction,
+		ResolutionNote:   r.ResolutionNote,
+		ResolvedBy:       r.ResolvedBy,
+		ResolvedAt:       r.ResolvedAt,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
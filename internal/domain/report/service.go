@@ -0,0 +1,343 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/shared/authz"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type Service struct {
+	reportRepo    Repository
+	msgProv       MessageProvider
+	roomProv      RoomProvider
+	memberRemover MemberRemover
+	userProv      UserProvider
+	sessions      SessionRevoker
+	auditor       AuditRecorder
+	webhooks      WebhookDispatcher
+	config        *config.Config
+	logger        *slog.Logger
+}
+
+func NewService(
+	reportRepo Repository,
+	msgProv MessageProvider,
+	roomProv RoomProvider,
+	memberRemover MemberRemover,
+	userProv UserProvider,
+	sessions SessionRevoker,
+	auditor AuditRecorder,
+	webhooks WebhookDispatcher,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		reportRepo:    reportRepo,
+		msgProv:       msgProv,
+		roomProv:      roomProv,
+		memberRemover: memberRemover,
+		userProv:      userProv,
+		sessions:      sessions,
+		auditor:       auditor,
+		webhooks:      webhooks,
+		config:        cfg,
+		logger:        logger,
+	}
+}
+
+// ReportMessage files a report against a message in a room the reporter belongs to.
+func (s *Service) ReportMessage(ctx context.Context, reporterID, messageID string, req ReportMessageRequest) (*ReportResponse, error) {
+	msg, err := s.msgProv.GetMessageInfo(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.roomProv.GetMembershipInfo(ctx, msg.RoomID, reporterID); err != nil {
+		return nil, err
+	}
+
+	authorID := ""
+	if msg.UserID != nil {
+		authorID = *msg.UserID
+	}
+	if authorID == reporterID {
+		return nil, ErrCannotReportSelf
+	}
+
+	report := NewMessageReport(reporterID, messageID, authorID, msg.RoomID, req.Reason, req.Details)
+	if err := s.reportRepo.CreateReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	s.recordCreated(ctx, report)
+	return toReportResponse(report), nil
+}
+
+// ReportUser files a report against an account. When a room is given, both
+// users must belong to it so that the room's admins can act on the report.
+func (s *Service) ReportUser(ctx context.Context, reporterID, userID string, req ReportUserRequest) (*ReportResponse, error) {
+	if userID == reporterID {
+		return nil, ErrCannotReportSelf
+	}
+	exists, err := s.userProv.ExistsByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+
+	if req.RoomID != "" {
+		if _, err := s.roomProv.GetMembershipInfo(ctx, req.RoomID, reporterID); err != nil {
+			return nil, err
+		}
+		if _, err := s.roomProv.GetMembershipInfo(ctx, req.RoomID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	report := NewUserReport(reporterID, userID, req.RoomID, req.Reason, req.Details)
+	if err := s.reportRepo.CreateReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	s.recordCreated(ctx, report)
+	return toReportResponse(report), nil
+}
+
+// ListReports returns a page of the moderation queue. System admins may list
+// every report; room moderators must scope the queue to a room they moderate.
+func (s *Service) ListReports(ctx context.Context, actorID string, filter ListFilter) (*response.CursorPage[*ReportResponse], error) {
+	actor, err := s.userProv.GetByIDShared(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.IsAdmin {
+		if filter.RoomID == nil {
+			return nil, ErrRoomRequired
+		}
+		if !s.isRoomModerator(ctx, *filter.RoomID, actorID) {
+			return nil, ErrNotModerator
+		}
+	}
+
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	reports, err := s.reportRepo.ListReports(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*ReportResponse, 0, len(reports))
+	for _, r := range reports {
+		items = append(items, toReportResponse(r))
+	}
+	return response.NewCursorPage(items, limit, func(r *ReportResponse) response.Cursor {
+		return response.Cursor{CreatedAt: r.CreatedAt, ID: r.ID}
+	}), nil
+}
+
+// GetReport returns a single report to someone allowed to moderate it.
+func (s *Service) GetReport(ctx context.Context, actorID, reportID string) (*ReportResponse, error) {
+	report, _, err := s.loadForModeration(ctx, actorID, reportID)
+	if err != nil {
+		return nil, err
+	}
+	return toReportResponse(report), nil
+}
+
+// AssignReport hands a report to a moderator and moves it into review.
+func (s *Service) AssignReport(ctx context.Context, actorID, reportID string, req AssignReportRequest) (*ReportResponse, error) {
+	report, _, err := s.loadForModeration(ctx, actorID, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.IsClosed() {
+		return nil, ErrReportClosed
+	}
+
+	assigneeID := req.AssigneeID
+	if assigneeID == "" {
+		assigneeID = actorID
+	}
+	if assigneeID != actorID {
+		// The assignee must be able to work the report themselves.
+		if _, err := s.authorize(ctx, assigneeID, report); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.reportRepo.AssignReport(ctx, reportID, assigneeID); err != nil {
+		return nil, err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomIDOf(report), actorID, audit.ActionReportAssigned, "report", reportID, map[string]any{
+		"assignee_id": assigneeID,
+	}))
+
+	report.AssigneeID = &assigneeID
+	report.Status = StatusInReview
+	return toReportResponse(report), nil
+}
+
+// ResolveReport closes a report, first carrying out the chosen moderation action.
+func (s *Service) ResolveReport(ctx context.Context, actorID, reportID string, req ResolveReportRequest) (*ReportResponse, error) {
+	report, actor, err := s.loadForModeration(ctx, actorID, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.IsClosed() {
+		return nil, ErrReportClosed
+	}
+
+	if err := s.applyAction(ctx, actor, report, req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	report.Status = StatusResolved
+	if req.Action == ActionDismiss {
+		report.Status = StatusDismissed
+	}
+	report.ResolutionAction = &req.Action
+	if req.Note != "" {
+		report.ResolutionNote = &req.Note
+	}
+	report.ResolvedBy = &actorID
+	report.ResolvedAt = &now
+
+	if err := s.reportRepo.CloseReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomIDOf(report), actorID, audit.ActionReportResolved, "report", reportID, map[string]any{
+		"action": req.Action,
+		"status": report.Status,
+		"note":   req.Note,
+	}))
+
+	s.logger.Info("report resolved", "report_id", reportID, "action", req.Action, "user_id", actorID)
+	return toReportResponse(report), nil
+}
+
+// applyAction carries out the side effect of a resolution and records it.
+func (s *Service) applyAction(ctx context.Context, actor *types.User, report *Report, req ResolveReportRequest) error {
+	roomID := roomIDOf(report)
+	metadata := map[string]any{"report_id": report.ID}
+
+	switch req.Action {
+	case ActionDismiss, ActionNone:
+		return nil
+
+	case ActionDeleteMessage:
+		if report.TargetType != TargetMessage || report.MessageID == nil {
+			return ErrActionNotAllowed
+		}
+		if err := s.msgProv.SoftDeleteMessage(ctx, *report.MessageID); err != nil {
+			return fmt.Errorf("service failed to delete reported message: %w", err)
+		}
+		s.auditor.Record(ctx, audit.NewEntry(roomID, actor.ID, audit.ActionMessageDeleted, "message", *report.MessageID, metadata))
+		if roomID != "" {
+			s.webhooks.Dispatch(ctx, &types.WebhookEvent{
+				Type:   types.WebhookMessageDeleted,
+				RoomID: roomID,
+				Data:   map[string]any{"message_id": *report.MessageID, "deleted_by": actor.ID},
+			})
+		}
+
+	case ActionRemoveMember:
+		if report.RoomID == nil || report.ReportedUserID == nil {
+			return ErrActionNotAllowed
+		}
+		// The room service records the kick in the room's audit log itself;
+		// the resolution entry links it back to the report. System admins
+		// outside the room still cannot remove its owner.
+		var err error
+		if s.isRoomModerator(ctx, *report.RoomID, actor.ID) {
+			err = s.memberRemover.RemoveMember(ctx, actor.ID, *report.RoomID, *report.ReportedUserID)
+		} else {
+			err = s.memberRemover.RemoveMemberAsAdmin(ctx, actor.ID, *report.RoomID, *report.ReportedUserID, "reported")
+		}
+		if err != nil {
+			return err
+		}
+
+	case ActionSuspendUser:
+		if !actor.IsAdmin {
+			return ErrSuspendNotAllowed
+		}
+		if report.ReportedUserID == nil {
+			return ErrActionNotAllowed
+		}
+		until := time.Now().Add(time.Duration(req.SuspendHours) * time.Hour)
+		if err := s.userProv.SuspendUser(ctx, *report.ReportedUserID, until); err != nil {
+			return err
+		}
+		// Existing access tokens are rejected by the auth middleware; dropping
+		// refresh tokens stops them from being renewed.
+		if err := s.sessions.DeleteUserRefreshTokens(ctx, *report.ReportedUserID); err != nil {
+			s.logger.Error("failed to revoke sessions for suspended user", "error", err, "user_id", *report.ReportedUserID)
+		}
+		metadata["suspended_until"] = until
+		s.auditor.Record(ctx, audit.NewEntry(roomID, actor.ID, audit.ActionUserSuspended, "user", *report.ReportedUserID, metadata))
+
+	default:
+		return ErrActionNotAllowed
+	}
+	return nil
+}
+
+// loadForModeration fetches a report and checks that the actor may moderate it.
+func (s *Service) loadForModeration(ctx context.Context, actorID, reportID string) (*Report, *types.User, error) {
+	report, err := s.reportRepo.GetReportByID(ctx, reportID)
+	if err != nil {
+		return nil, nil, err
+	}
+	actor, err := s.authorize(ctx, actorID, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	return report, actor, nil
+}
+
+// authorize allows system admins on every report and room moderators on reports
+// raised in their rooms.
+func (s *Service) authorize(ctx context.Context, userID string, report *Report) (*types.User, error) {
+	user, err := s.userProv.GetByIDShared(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsAdmin {
+		return user, nil
+	}
+	if report.RoomID != nil && s.isRoomModerator(ctx, *report.RoomID, userID) {
+		return user, nil
+	}
+	return nil, ErrNotModerator
+}
+
+func (s *Service) isRoomModerator(ctx context.Context, roomID, userID string) bool {
+	membership, err := s.roomProv.GetMembershipInfo(ctx, roomID, userID)
+	return err == nil && authz.AtLeast(membership.Role, types.ModeratorRole)
+}
+
+func (s *Service) recordCreated(ctx context.Context, report *Report) {
+	s.auditor.Record(ctx, audit.NewEntry(roomIDOf(report), *report.ReporterID, audit.ActionReportCreated, "report", report.ID, map[string]any{
+		"target_type": report.TargetType,
+		"reason":      report.Reason,
+	}))
+	s.logger.Info("report created", "report_id", report.ID, "target_type", report.TargetType)
+}
+
+func roomIDOf(report *Report) string {
+	if report.RoomID == nil {
+		return ""
+	}
+	return *report.RoomID
+}
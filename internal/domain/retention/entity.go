@@ -0,0 +1,21 @@
+package retention
+
+import "time"
+
+// RoomPolicy is the retention window that applies to a single room.
+type RoomPolicy struct {
+	RoomID        string
+	RetentionDays int
+}
+
+// Cutoff returns the instant before which messages fall outside the policy window.
+func (p *RoomPolicy) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -p.RetentionDays)
+}
+
+// PurgePreview summarises the messages a purge would remove from a room.
+type PurgePreview struct {
+	MessageCount    int64
+	OldestMessageAt *time.Time
+	NewestMessageAt *time.Time
+}
@@ -0,0 +1,7 @@
+package retention
+
+import "github.com/purushothdl/gochat-backend/pkg/errors"
+
+var (
+	ErrNotAdmin = errors.New("NOT_ADMIN", "You must be an admin to manage this room's retention policy", 403)
+)
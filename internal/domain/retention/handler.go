@@ -0,0 +1,91 @@
+package retention
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/validator"
+	authMiddleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+type Handler struct {
+	service   *Service
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewHandler(service *Service, logger *slog.Logger, v *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		logger:    logger,
+		validator: v,
+	}
+}
+
+// GetPolicy handles GET /api/rooms/{room_id}/retention
+func (h *Handler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	policy, err := h.service.GetPolicy(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, policy)
+}
+
+// UpdatePolicy handles PUT /api/rooms/{room_id}/retention
+func (h *Handler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req UpdateRetentionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	policy, err := h.service.UpdatePolicy(r.Context(), actorID, roomID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, policy)
+}
+
+// PreviewPurge handles GET /api/rooms/{room_id}/retention/preview
+func (h *Handler) PreviewPurge(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	preview, err := h.service.PreviewPurge(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, preview)
+}
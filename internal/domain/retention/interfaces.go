@@ -0,0 +1,24 @@
+package retention
+
+import (
+	"context"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// RoomProvider defines the methods the retention service needs about rooms.
+type RoomProvider interface {
+	GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error)
+}
+
+// SystemMessenger posts the notice left in a room after a purge.
+type SystemMessenger interface {
+	PostSystemMessage(ctx context.Context, roomID, content string) (*message.Message, error)
+}
+
+// AuditRecorder records policy changes and purges.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *audit.Entry)
+}
@@ -0,0 +1,20 @@
+package retention
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	GetRoomRetention(ctx context.Context, roomID string) (*int, error)
+	SetRoomRetention(ctx context.Context, roomID string, days *int) error
+
+	// ListRoomPolicies returns every room with an effective retention window,
+	// applying defaultDays to rooms without their own policy.
+	ListRoomPolicies(ctx context.Context, defaultDays int) ([]*RoomPolicy, error)
+	PreviewPurge(ctx context.Context, roomID string, cutoff time.Time) (*PurgePreview, error)
+
+	// PurgeBatch deletes up to limit messages older than cutoff, together with
+	// their read receipts and per-user deletions, and returns how many were removed.
+	PurgeBatch(ctx context.Context, roomID string, cutoff time.Time, limit int) (int64, error)
+}
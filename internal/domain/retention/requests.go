@@ -0,0 +1,7 @@
+package retention
+
+// UpdateRetentionRequest sets a room's retention window. A null value reverts
+// the room to the global default.
+type UpdateRetentionRequest struct {
+	RetentionDays *int `json:"retention_days" validate:"omitempty,min=1,max=3650"`
+}
@@ -0,0 +1,19 @@
+package retention
+
+import "time"
+
+type RetentionPolicyResponse struct {
+	RoomID        string `json:"room_id"`
+	RetentionDays *int   `json:"retention_days"`
+	EffectiveDays int    `json:"effective_days"` // 0 means messages are kept forever
+	UsesDefault   bool   `json:"uses_default"`
+}
+
+type PurgePreviewResponse struct {
+	RoomID          string     `json:"room_id"`
+	EffectiveDays   int        `json:"effective_days"`
+	Cutoff          *time.Time `json:"cutoff,omitempty"`
+	MessageCount    int64      `json:"message_count"`
+	OldestMessageAt *time.Time `json:"oldest_message_at,omitempty"`
+	NewestMessageAt *time.Time `json:"newest_message_at,omitempty"`
+}
@@ -0,0 +1,129 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/shared/authz"
+)
+
+type Service struct {
+	retentionRepo Repository
+	roomProv      RoomProvider
+	auditor       AuditRecorder
+	config        *config.Config
+	logger        *slog.Logger
+}
+
+func NewService(
+	retentionRepo Repository,
+	roomProv RoomProvider,
+	auditor AuditRecorder,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		retentionRepo: retentionRepo,
+		roomProv:      roomProv,
+		auditor:       auditor,
+		config:        cfg,
+		logger:        logger,
+	}
+}
+
+// GetPolicy returns a room's retention policy along with the window actually in effect.
+func (s *Service) GetPolicy(ctx context.Context, actorID, roomID string) (*RetentionPolicyResponse, error) {
+	if err := s.authorizeAdmin(ctx, actorID, roomID); err != nil {
+		return nil, err
+	}
+
+	days, err := s.retentionRepo.GetRoomRetention(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return s.policyResponse(roomID, days), nil
+}
+
+// UpdatePolicy sets or clears a room's retention window.
+func (s *Service) UpdatePolicy(ctx context.Context, actorID, roomID string, req UpdateRetentionRequest) (*RetentionPolicyResponse, error) {
+	if err := s.authorizeAdmin(ctx, actorID, roomID); err != nil {
+		return nil, err
+	}
+
+	previous, err := s.retentionRepo.GetRoomRetention(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.retentionRepo.SetRoomRetention(ctx, roomID, req.RetentionDays); err != nil {
+		return nil, fmt.Errorf("service failed to update retention policy: %w", err)
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionRetentionPolicyUpdated, "room", roomID, map[string]any{
+		"previous_days": previous,
+		"new_days":      req.RetentionDays,
+	}))
+
+	s.logger.Info("room retention policy updated", "room_id", roomID, "user_id", actorID)
+	return s.policyResponse(roomID, req.RetentionDays), nil
+}
+
+// PreviewPurge reports what the next purge would remove from a room without deleting anything.
+func (s *Service) PreviewPurge(ctx context.Context, actorID, roomID string) (*PurgePreviewResponse, error) {
+	if err := s.authorizeAdmin(ctx, actorID, roomID); err != nil {
+		return nil, err
+	}
+
+	days, err := s.retentionRepo.GetRoomRetention(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &RoomPolicy{RoomID: roomID, RetentionDays: s.effectiveDays(days)}
+	resp := &PurgePreviewResponse{RoomID: roomID, EffectiveDays: policy.RetentionDays}
+	if policy.RetentionDays == 0 {
+		return resp, nil
+	}
+
+	cutoff := policy.Cutoff(time.Now())
+	preview, err := s.retentionRepo.PreviewPurge(ctx, roomID, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Cutoff = &cutoff
+	resp.MessageCount = preview.MessageCount
+	resp.OldestMessageAt = preview.OldestMessageAt
+	resp.NewestMessageAt = preview.NewestMessageAt
+	return resp, nil
+}
+
+func (s *Service) policyResponse(roomID string, days *int) *RetentionPolicyResponse {
+	return &RetentionPolicyResponse{
+		RoomID:        roomID,
+		RetentionDays: days,
+		EffectiveDays: s.effectiveDays(days),
+		UsesDefault:   days == nil,
+	}
+}
+
+func (s *Service) effectiveDays(days *int) int {
+	if days != nil {
+		return *days
+	}
+	return s.config.Retention.DefaultDays
+}
+
+func (s *Service) authorizeAdmin(ctx context.Context, actorID, roomID string) error {
+	membership, err := s.roomProv.GetMembershipInfo(ctx, roomID, actorID)
+	if err != nil {
+		return err
+	}
+	if !authz.Can(membership.Role, membership.MemberPermissions, authz.EditSettings) {
+		return ErrNotAdmin
+	}
+	return nil
+}
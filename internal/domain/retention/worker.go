@@ -0,0 +1,125 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+)
+
+// Worker periodically purges messages that have aged out of their room's retention window.
+type Worker struct {
+	retentionRepo Repository
+	messenger     SystemMessenger
+	auditor       AuditRecorder
+	config        *config.Config
+	logger        *slog.Logger
+}
+
+func NewWorker(
+	retentionRepo Repository,
+	messenger SystemMessenger,
+	auditor AuditRecorder,
+	config *config.Config,
+	logger *slog.Logger,
+) *Worker {
+	return &Worker{
+		retentionRepo: retentionRepo,
+		messenger:     messenger,
+		auditor:       auditor,
+		config:        config,
+		logger:        logger,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	w.logger.Info("starting retention worker...", "interval", w.config.Retention.Interval, "dry_run", w.config.Retention.DryRun)
+
+	ticker := time.NewTicker(w.config.Retention.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("retention worker shutting down")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) runOnce(ctx context.Context) {
+	policies, err := w.retentionRepo.ListRoomPolicies(ctx, w.config.Retention.DefaultDays)
+	if err != nil {
+		w.logger.Error("failed to list retention policies", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for _, policy := range policies {
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger := w.logger.With("room_id", policy.RoomID, "retention_days", policy.RetentionDays)
+		if w.config.Retention.DryRun {
+			w.reportDryRun(ctx, policy, now, logger)
+			continue
+		}
+		if err := w.purgeRoom(ctx, policy, now, logger); err != nil {
+			logger.Error("failed to purge room", "error", err)
+		}
+	}
+}
+
+// purgeRoom deletes expired messages in small batches so that no single
+// transaction holds row locks on messages for long.
+func (w *Worker) purgeRoom(ctx context.Context, policy *RoomPolicy, now time.Time, logger *slog.Logger) error {
+	cutoff := policy.Cutoff(now)
+
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := w.retentionRepo.PurgeBatch(ctx, policy.RoomID, cutoff, w.config.Retention.BatchSize)
+		if err != nil {
+			return fmt.Errorf("purge stopped after %d messages: %w", total, err)
+		}
+		total += deleted
+		if deleted < int64(w.config.Retention.BatchSize) {
+			break
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+	logger.Info("purged expired messages", "deleted", total, "cutoff", cutoff)
+
+	notice := fmt.Sprintf("%d message(s) older than %d days were removed under this room's retention policy.", total, policy.RetentionDays)
+	if _, err := w.messenger.PostSystemMessage(ctx, policy.RoomID, notice); err != nil {
+		logger.Error("failed to post retention notice", "error", err)
+	}
+
+	w.auditor.Record(ctx, audit.NewEntry(policy.RoomID, "", audit.ActionRetentionPurge, "room", policy.RoomID, map[string]any{
+		"deleted_messages": total,
+		"retention_days":   policy.RetentionDays,
+		"cutoff":           cutoff,
+	}))
+	return nil
+}
+
+func (w *Worker) reportDryRun(ctx context.Context, policy *RoomPolicy, now time.Time, logger *slog.Logger) {
+	cutoff := policy.Cutoff(now)
+	preview, err := w.retentionRepo.PreviewPurge(ctx, policy.RoomID, cutoff)
+	if err != nil {
+		logger.Error("failed to preview purge", "error", err)
+		return
+	}
+	if preview.MessageCount > 0 {
+		logger.Info("dry run: messages would be purged", "count", preview.MessageCount, "cutoff", cutoff, "oldest", preview.OldestMessageAt)
+	}
+}
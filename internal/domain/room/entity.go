@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
 )
 
 type RoomType string
@@ -17,35 +20,275 @@ const (
 type MemberRole string
 
 const (
-	AdminRole  MemberRole = "ADMIN"
-	RegularRole MemberRole = "MEMBER"
+	OwnerRole     MemberRole = "OWNER"
+	AdminRole     MemberRole = "ADMIN"
+	ModeratorRole MemberRole = "MODERATOR"
+	RegularRole   MemberRole = "MEMBER"
 )
 
 // Room represents the core room entity.
 type Room struct {
-	ID              string
-	Name            string
-	Type            RoomType
-	IsBroadcastOnly bool
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	DeletedAt       *time.Time
+	ID                 string
+	WorkspaceID        string // Only members of the workspace can join, be invited to or find the room
+	Name               string
+	Description        string
+	Topic              string
+	AvatarURL          string // Public URL of the processed avatar; empty until one is uploaded
+	Type               RoomType
+	IsBroadcastOnly    bool
+	IsDiscoverable     bool                      // A private room listed in the directory that users can ask to join
+	SlowModeSeconds    int                       // Minimum delay between messages from a non-admin member; 0 disables slow mode
+	ModerationSettings types.ModerationSettings  // Per-hook moderation toggles
+	MemberPermissions  types.PermissionOverrides // Per-room changes to the MEMBER role's default permissions
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	ArchivedAt         *time.Time // Set while the room is read-only
+	DeletedAt          *time.Time
+}
+
+// IsArchived reports whether the room is read-only.
+func (r *Room) IsArchived() bool {
+	return r.ArchivedAt != nil
 }
 
 // RoomMembership links a user to a room with a specific role.
 type RoomMembership struct {
+	RoomID     string
+	UserID     string
+	Role       MemberRole
+	MutedUntil *time.Time // The member cannot send messages before this time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IsMuted reports whether the member is still muted at now.
+func (m *RoomMembership) IsMuted(now time.Time) bool {
+	return m.MutedUntil != nil && now.Before(*m.MutedUntil)
+}
+
+// Ban keeps a user out of a room. A ban without ExpiresAt lasts until it is lifted.
+type Ban struct {
+	ID        string
 	RoomID    string
 	UserID    string
-	Role      MemberRole
+	BannedBy  *string
+	Reason    string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	User      *types.BasicUser // Populated when listing a room's bans
+}
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "PENDING"
+	InvitationAccepted InvitationStatus = "ACCEPTED"
+	InvitationDeclined InvitationStatus = "DECLINED"
+	InvitationRevoked  InvitationStatus = "REVOKED"
+	InvitationExpired  InvitationStatus = "EXPIRED" // Stored when a new invitation replaces a lapsed one
+)
+
+// Invitation is a pending offer for a user to join a room.
+type Invitation struct {
+	ID          string
+	RoomID      string
+	InviterID   *string
+	InviteeID   string
+	Status      InvitationStatus
+	ExpiresAt   time.Time
+	RespondedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// InvitationDetail is an invitation joined with the data needed to display it.
+type InvitationDetail struct {
+	Invitation
+	RoomName string
+	Inviter  *types.BasicUser
+	Invitee  *types.BasicUser
+}
+
+// NewInvitation creates a pending invitation that expires after ttl.
+func NewInvitation(roomID, inviterID, inviteeID string, ttl time.Duration) *Invitation {
+	return &Invitation{
+		ID:        uuid.NewString(),
+		RoomID:    roomID,
+		InviterID: &inviterID,
+		InviteeID: inviteeID,
+		Status:    InvitationPending,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+// EffectiveStatus reports the invitation's status, treating a pending
+// invitation past its expiry as expired.
+func (i *Invitation) EffectiveStatus(now time.Time) InvitationStatus {
+	if i.Status == InvitationPending && !now.Before(i.ExpiresAt) {
+		return InvitationExpired
+	}
+	return i.Status
+}
+
+type JoinRequestStatus string
+
+const (
+	JoinRequestPending  JoinRequestStatus = "PENDING"
+	JoinRequestApproved JoinRequestStatus = "APPROVED"
+	JoinRequestDenied   JoinRequestStatus = "DENIED"
+	JoinRequestCanceled JoinRequestStatus = "CANCELED"
+)
+
+// JoinRequest is a user's request to be let into a discoverable private room.
+type JoinRequest struct {
+	ID          string
+	RoomID      string
+	UserID      string
+	Message     string
+	Status      JoinRequestStatus
+	RespondedBy *string
+	RespondedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	RoomName    string           // Populated when listing a user's own requests
+	User        *types.BasicUser // Populated when listing a room's requests
+}
+
+// NewJoinRequest creates a pending request from userID to join roomID.
+func NewJoinRequest(roomID, userID, message string) *JoinRequest {
+	return &JoinRequest{
+		ID:      uuid.NewString(),
+		RoomID:  roomID,
+		UserID:  userID,
+		Message: message,
+		Status:  JoinRequestPending,
+	}
+}
+
+// InviteLink is a shareable token that lets anyone holding it join a room.
+// Only the token's hash is stored.
+type InviteLink struct {
+	ID        string
+	RoomID    string
+	CreatedBy *string
+	TokenHash string
+	Role      MemberRole // Role granted to users who join through the link
+	MaxUses   *int       // nil means unlimited
+	UseCount  int
+	ExpiresAt *time.Time // nil means the link never expires
+	RevokedAt *time.Time
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
-// NewPrivateRoom creates a new Room entity for a private chat.
-func NewPrivateRoom(name string) *Room {
+// InviteLinkPreview is what an invite link reveals about its room before joining.
+type InviteLinkPreview struct {
+	RoomID      string
+	RoomName    string
+	MemberCount int
+	ExpiresAt   *time.Time
+}
+
+// IsUsable reports whether the link can still be used to join its room.
+func (l *InviteLink) IsUsable(now time.Time) bool {
+	if l.RevokedAt != nil {
+		return false
+	}
+	if l.ExpiresAt != nil && !now.Before(*l.ExpiresAt) {
+		return false
+	}
+	return l.MaxUses == nil || l.UseCount < *l.MaxUses
+}
+
+// MemberEventBuilder produces the real-time event for a change to a member of
+// a room, for changes the repository discovers itself such as lapsed bans.
+type MemberEventBuilder func(roomID, userID string) (*contracts.OutboxEvent, error)
+
+// DirectorySort orders the public room directory.
+type DirectorySort string
+
+const (
+	SortByMembers  DirectorySort = "members"
+	SortByActivity DirectorySort = "activity"
+	SortByCreated  DirectorySort = "created"
+)
+
+func (s DirectorySort) IsValid() bool {
+	switch s {
+	case SortByMembers, SortByActivity, SortByCreated:
+		return true
+	}
+	return false
+}
+
+// UserRoom is a room as listed for one of its members, with that member's own settings for it.
+type UserRoom struct {
+	Room          *Room
+	Notifications types.NotificationPrefs
+	Prefs         types.RoomPrefs
+}
+
+// UserRoomFilter narrows a user's room list. Nil fields do not filter; hidden
+// rooms are always left out unless Hidden asks for them alone.
+type UserRoomFilter struct {
+	IncludeArchived bool
+	Pinned          *bool
+	Favorite        *bool
+	Hidden          bool    // List only hidden rooms instead of only visible ones
+	FolderID        *string // Rooms in this folder; an empty ID selects rooms in no folder
+}
+
+// Folder is a user-defined group in their own room list.
+type Folder struct {
+	ID        string
+	UserID    string
+	Name      string
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// FolderEventBuilder produces the real-time event for a new folder once the
+// insert has assigned its position. The event is stored in the same transaction.
+type FolderEventBuilder func(folder *Folder) (*contracts.OutboxEvent, error)
+
+// NewFolder creates a folder for userID. The repository places it after the user's existing folders.
+func NewFolder(userID, name string) *Folder {
+	return &Folder{
+		ID:     uuid.NewString(),
+		UserID: userID,
+		Name:   name,
+	}
+}
+
+// DirectoryFilter narrows and pages the public room directory. Query matches
+// room names and descriptions; ViewerID is the user whose memberships are
+// flagged. Only rooms in the viewer's workspaces are listed, and WorkspaceID
+// narrows that to one of them.
+type DirectoryFilter struct {
+	Query       string
+	Sort        DirectorySort
+	ViewerID    string
+	WorkspaceID string
+	Cursor      *response.Cursor
+	Limit       int
+}
+
+// DirectoryEntry is a public or discoverable private room as listed in the directory.
+type DirectoryEntry struct {
+	Room              *Room
+	MemberCount       int
+	LastActivityAt    time.Time
+	IsMember          bool
+	HasPendingRequest bool // The viewer is waiting on a join request to this room
+}
+
+// NewPrivateRoom creates a new Room entity for a private chat in a workspace.
+func NewPrivateRoom(workspaceID, name string) *Room {
 	return &Room{
-		ID:   uuid.NewString(),
-		Name: name,
-		Type: PrivateRoom,
+		ID:          uuid.NewString(),
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Type:        PrivateRoom,
 	}
-}
\ No newline at end of file
+}
@@ -1,6 +1,12 @@
 package room
 
-import "github.com/purushothdl/gochat-backend/pkg/errors"
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
 
 var (
 	ErrNotAdmin       = errors.New("NOT_ADMIN", "You must be an admin to perform this action", 403)
@@ -8,4 +14,51 @@ var (
 	ErrRoomNotFound   = errors.New("ROOM_NOT_FOUND", "The requested room was not found", 404)
 	ErrUserNotFound   = errors.New("USER_TO_INVITE_NOT_FOUND", "The user you are trying to invite does not exist", 404)
 	ErrNotMember      = errors.New("NOT_A_MEMBER", "You are not a member of this room", 403)
-)
\ No newline at end of file
+
+	ErrOwnerMustTransfer = errors.New("OWNER_MUST_TRANSFER", "The room owner must transfer ownership to another member first", 403)
+	ErrNotOwner          = errors.New("NOT_OWNER", "Only the room owner can perform this action", 403)
+	ErrCannotRemoveOwner = errors.New("CANNOT_REMOVE_OWNER", "The room owner cannot be removed from the room", 403)
+
+	ErrRoomArchived    = errors.New("ROOM_ARCHIVED", "This room is archived and is read-only", 403)
+	ErrAlreadyArchived = errors.New("ROOM_ALREADY_ARCHIVED", "This room is already archived", 409)
+	ErrRoomNotArchived = errors.New("ROOM_NOT_ARCHIVED", "This room is not archived", 409)
+
+	ErrInvitationNotFound   = errors.New("INVITATION_NOT_FOUND", "The requested invitation was not found", 404)
+	ErrAlreadyInvited       = errors.New("ALREADY_INVITED", "This user already has a pending invitation to this room", 409)
+	ErrInvitationNotPending = errors.New("INVITATION_NOT_PENDING", "This invitation is no longer pending", 409)
+	ErrInvitationExpired    = errors.New("INVITATION_EXPIRED", "This invitation has expired", 410)
+	ErrInviteBlocked        = errors.New("INVITE_BLOCKED", "This user is not accepting invitations from you", 403)
+
+	ErrNotWorkspaceMember    = errors.New("NOT_WORKSPACE_MEMBER", "You are not a member of this room's workspace", 403)
+	ErrInviteeNotInWorkspace = errors.New("INVITEE_NOT_IN_WORKSPACE", "This user is not a member of this room's workspace", 403)
+
+	ErrInviteLinkNotFound   = errors.New("INVITE_LINK_NOT_FOUND", "The requested invite link was not found", 404)
+	ErrInviteLinkInvalid    = errors.New("INVITE_LINK_INVALID", "This invite link is invalid, expired or has reached its usage limit", 404)
+	ErrInviteLinkNotAllowed = errors.New("INVITE_LINK_NOT_ALLOWED", "Invite links can only be created for private rooms", 400)
+
+	ErrJoinRequestNotFound   = errors.New("JOIN_REQUEST_NOT_FOUND", "The requested join request was not found", 404)
+	ErrJoinRequestExists     = errors.New("JOIN_REQUEST_EXISTS", "You already have a pending request to join this room", 409)
+	ErrJoinRequestNotPending = errors.New("JOIN_REQUEST_NOT_PENDING", "This join request is no longer pending", 409)
+	ErrJoinRequestNotAllowed = errors.New("JOIN_REQUEST_NOT_ALLOWED", "This room does not accept join requests", 403)
+	ErrNotDiscoverable       = errors.New("DISCOVERABLE_NOT_ALLOWED", "Only private rooms can be made discoverable", 400)
+
+	ErrInvalidMuteUntil = errors.New("INVALID_MUTED_UNTIL", "muted_until must be in the future", 400)
+
+	ErrFolderNotFound = errors.New("FOLDER_NOT_FOUND", "The requested folder was not found", 404)
+	ErrFolderExists   = errors.New("FOLDER_EXISTS", "You already have a folder with this name", 409)
+
+	ErrBanned        = errors.New("BANNED_FROM_ROOM", "You are banned from this room", 403)
+	ErrInviteeBanned = errors.New("INVITEE_BANNED", "This user is banned from this room", 403)
+	ErrBanNotFound   = errors.New("BAN_NOT_FOUND", "This user is not banned from this room", 404)
+	ErrNotMuted      = errors.New("NOT_MUTED", "This member is not muted", 409)
+
+	ErrInvalidDirectorySort = errors.New("INVALID_DIRECTORY_SORT", "Sort must be one of members, activity or created", 400)
+	ErrInvalidCursor        = errors.New("INVALID_CURSOR", "The cursor is invalid for this listing", 400)
+)
+
+// NewJoinRequestLimitError reports how long a user must wait before filing another join request.
+func NewJoinRequestLimitError(wait time.Duration) *errors.AppError {
+	secs := max(1, int(math.Ceil(wait.Seconds())))
+	return errors.New("JOIN_REQUEST_RATE_LIMITED", fmt.Sprintf("You are sending join requests too quickly. Try again in %d seconds.", secs), 429).
+		WithDetails(map[string]any{"retry_after_seconds": secs})
+}
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/gochat-backend/internal/config"
 	"github.com/purushothdl/gochat-backend/internal/shared/response"
 	"github.com/purushothdl/gochat-backend/internal/shared/validator"
 	authMiddleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
@@ -17,13 +20,15 @@ type Handler struct {
 	service   *Service
 	logger    *slog.Logger
 	validator *validator.Validator
+	config    *config.Config
 }
 
-func NewHandler(service *Service, logger *slog.Logger, v *validator.Validator) *Handler {
+func NewHandler(service *Service, logger *slog.Logger, v *validator.Validator, cfg *config.Config) *Handler {
 	return &Handler{
 		service:   service,
 		logger:    logger,
 		validator: v,
+		config:    cfg,
 	}
 }
 
@@ -73,16 +78,398 @@ func (h *Handler) InviteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.service.InviteUser(r.Context(), inviterID, roomID, req.UserID)
+	invitation, err := h.service.InviteUser(r.Context(), inviterID, roomID, req.UserID)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, response.MessageResponse{Message: "User invited successfully"})
+	response.JSON(w, http.StatusCreated, invitation.ToResponse())
 }
 
-// ListUserRooms handles GET /api/v1/rooms
+// ListRoomInvitations handles GET /api/v1/rooms/{room_id}/invitations
+func (h *Handler) ListRoomInvitations(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	invitations, err := h.service.ListRoomInvitations(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invitationDetailsToResponse(invitations))
+}
+
+// ResendInvitation handles POST /api/v1/rooms/{room_id}/invitations/{invitation_id}/resend
+func (h *Handler) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	invitationID := chi.URLParam(r, "invitation_id")
+
+	invitation, err := h.service.ResendInvitation(r.Context(), actorID, roomID, invitationID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invitation.ToResponse())
+}
+
+// RevokeInvitation handles DELETE /api/v1/rooms/{room_id}/invitations/{invitation_id}
+func (h *Handler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	invitationID := chi.URLParam(r, "invitation_id")
+
+	if err := h.service.RevokeInvitation(r.Context(), actorID, roomID, invitationID); err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListMyInvitations handles GET /api/v1/invitations
+func (h *Handler) ListMyInvitations(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	invitations, err := h.service.ListMyInvitations(r.Context(), userID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invitationDetailsToResponse(invitations))
+}
+
+// AcceptInvitation handles POST /api/v1/invitations/{invitation_id}/accept
+func (h *Handler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	invitationID := chi.URLParam(r, "invitation_id")
+
+	invitation, err := h.service.AcceptInvitation(r.Context(), userID, invitationID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invitation.ToResponse())
+}
+
+// DeclineInvitation handles POST /api/v1/invitations/{invitation_id}/decline
+func (h *Handler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	invitationID := chi.URLParam(r, "invitation_id")
+
+	invitation, err := h.service.DeclineInvitation(r.Context(), userID, invitationID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invitation.ToResponse())
+}
+
+// UploadAvatar handles PUT /api/v1/rooms/{room_id}/avatar
+func (h *Handler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	if err := r.ParseMultipartForm(h.config.Upload.MaxFileSize); err != nil {
+		response.Error(w, http.StatusBadRequest, errors.New("INVALID_FORM", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, errors.New("MISSING_FILE", "Image file is required.", http.StatusBadRequest))
+		return
+	}
+	defer file.Close()
+
+	job, err := h.service.UploadAvatar(r.Context(), actorID, roomID, file, header)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(w, 0, appErr)
+			return
+		}
+		h.logger.Error("failed to initiate room avatar upload", "error", err, "room_id", roomID)
+		response.Error(w, http.StatusBadRequest, errors.New("UPLOAD_FAILED", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	response.JSON(w, http.StatusAccepted, job)
+}
+
+// CreateInviteLink handles POST /api/v1/rooms/{room_id}/invite-links
+func (h *Handler) CreateInviteLink(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req CreateInviteLinkRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	link, token, err := h.service.CreateInviteLink(r.Context(), actorID, roomID, req)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := link.ToResponse()
+	resp.Token = token
+	response.JSON(w, http.StatusCreated, resp)
+}
+
+// ListInviteLinks handles GET /api/v1/rooms/{room_id}/invite-links
+func (h *Handler) ListInviteLinks(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	links, err := h.service.ListInviteLinks(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	linkResponses := make([]*InviteLinkResponse, len(links))
+	for i, link := range links {
+		linkResponses[i] = link.ToResponse()
+	}
+
+	response.JSON(w, http.StatusOK, linkResponses)
+}
+
+// RevokeInviteLink handles DELETE /api/v1/rooms/{room_id}/invite-links/{link_id}
+func (h *Handler) RevokeInviteLink(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	linkID := chi.URLParam(r, "link_id")
+
+	if err := h.service.RevokeInviteLink(r.Context(), actorID, roomID, linkID); err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PreviewInviteLink handles GET /api/v1/invites/{token}
+func (h *Handler) PreviewInviteLink(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	preview, err := h.service.PreviewInviteLink(r.Context(), token)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, preview.ToResponse())
+}
+
+// AcceptInviteLink handles POST /api/v1/invites/{token}/accept
+func (h *Handler) AcceptInviteLink(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	token := chi.URLParam(r, "token")
+
+	joinedRoom, err := h.service.AcceptInviteLink(r.Context(), userID, token)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, joinedRoom.ToResponse())
+}
+
+func invitationDetailsToResponse(invitations []*InvitationDetail) []*InvitationResponse {
+	resp := make([]*InvitationResponse, len(invitations))
+	for i, inv := range invitations {
+		resp[i] = InvitationDetailToResponse(inv)
+	}
+	return resp
+}
+
+// RequestToJoin handles POST /api/v1/rooms/{room_id}/join-requests
+func (h *Handler) RequestToJoin(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req CreateJoinRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.JSON(w, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	joinRequest, err := h.service.RequestToJoin(r.Context(), userID, roomID, req)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, joinRequest.ToResponse())
+}
+
+// ListJoinRequests handles GET /api/v1/rooms/{room_id}/join-requests
+func (h *Handler) ListJoinRequests(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	requests, err := h.service.ListJoinRequests(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, joinRequestsToResponse(requests))
+}
+
+// ApproveJoinRequest handles POST /api/v1/rooms/{room_id}/join-requests/{request_id}/approve
+func (h *Handler) ApproveJoinRequest(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	requestID := chi.URLParam(r, "request_id")
+
+	joinRequest, err := h.service.ApproveJoinRequest(r.Context(), actorID, roomID, requestID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, joinRequest.ToResponse())
+}
+
+// DenyJoinRequest handles POST /api/v1/rooms/{room_id}/join-requests/{request_id}/deny
+func (h *Handler) DenyJoinRequest(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	requestID := chi.URLParam(r, "request_id")
+
+	joinRequest, err := h.service.DenyJoinRequest(r.Context(), actorID, roomID, requestID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, joinRequest.ToResponse())
+}
+
+// ListMyJoinRequests handles GET /api/v1/join-requests
+func (h *Handler) ListMyJoinRequests(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	requests, err := h.service.ListMyJoinRequests(r.Context(), userID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, joinRequestsToResponse(requests))
+}
+
+// CancelJoinRequest handles DELETE /api/v1/join-requests/{request_id}
+func (h *Handler) CancelJoinRequest(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	requestID := chi.URLParam(r, "request_id")
+
+	if _, err := h.service.CancelJoinRequest(r.Context(), userID, requestID); err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func joinRequestsToResponse(requests []*JoinRequest) []*JoinRequestResponse {
+	resp := make([]*JoinRequestResponse, len(requests))
+	for i, joinRequest := range requests {
+		resp[i] = joinRequest.ToResponse()
+	}
+	return resp
+}
+
+// ListUserRooms handles GET /api/v1/rooms?include_archived=&pinned=&favorite=&hidden=&folder_id=
+// folder_id=none lists rooms outside any folder; hidden=true lists only the rooms the user has hidden.
 func (h *Handler) ListUserRooms(w http.ResponseWriter, r *http.Request) {
 	userID, ok := authMiddleware.GetUserID(r.Context())
 	if !ok {
@@ -90,34 +477,232 @@ func (h *Handler) ListUserRooms(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rooms, err := h.service.ListUserRooms(r.Context(), userID)
+	query := r.URL.Query()
+	filter := UserRoomFilter{}
+	filter.IncludeArchived, _ = strconv.ParseBool(query.Get("include_archived"))
+	filter.Hidden, _ = strconv.ParseBool(query.Get("hidden"))
+	var err error
+	if filter.Pinned, err = parseOptionalBool(query.Get("pinned")); err != nil {
+		response.Error(w, http.StatusBadRequest, errors.New("INVALID_FILTER", "pinned must be true or false", http.StatusBadRequest))
+		return
+	}
+	if filter.Favorite, err = parseOptionalBool(query.Get("favorite")); err != nil {
+		response.Error(w, http.StatusBadRequest, errors.New("INVALID_FILTER", "favorite must be true or false", http.StatusBadRequest))
+		return
+	}
+	if folderID := query.Get("folder_id"); folderID != "" {
+		if folderID == "none" {
+			folderID = ""
+		}
+		filter.FolderID = &folderID
+	}
+
+	rooms, err := h.service.ListUserRooms(r.Context(), userID, filter)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	roomResponses := make([]*UserRoomResponse, len(rooms))
+	for i, room := range rooms {
+		roomResponses[i] = room.ToResponse()
+	}
+
+	response.JSON(w, http.StatusOK, roomResponses)
+}
+
+// parseOptionalBool parses a boolean query value, returning nil when it is absent.
+func parseOptionalBool(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// UpdateRoomPrefs handles PUT /api/v1/rooms/{room_id}/prefs
+func (h *Handler) UpdateRoomPrefs(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req UpdateRoomPrefsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.JSON(w, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	prefs, err := h.service.UpdateRoomPrefs(r.Context(), userID, roomID, req)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, RoomPrefsToResponse(*prefs))
+}
+
+// ListFolders handles GET /api/v1/rooms/folders
+func (h *Handler) ListFolders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	folders, err := h.service.ListFolders(r.Context(), userID)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	roomResponses := make([]*RoomResponse, len(rooms))
-	for i, room := range rooms {
-		roomResponses[i] = room.ToResponse()
+	folderResponses := make([]*FolderResponse, len(folders))
+	for i, folder := range folders {
+		folderResponses[i] = folder.ToResponse()
 	}
 
-	response.JSON(w, http.StatusOK, roomResponses)
+	response.JSON(w, http.StatusOK, folderResponses)
 }
 
-// ListPublicRooms handles GET /api/v1/rooms/public
-func (h *Handler) ListPublicRooms(w http.ResponseWriter, r *http.Request) {
-	rooms, err := h.service.ListPublicRooms(r.Context())
+// CreateFolder handles POST /api/v1/rooms/folders
+func (h *Handler) CreateFolder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	var req CreateFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.JSON(w, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	folder, err := h.service.CreateFolder(r.Context(), userID, req)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	roomResponses := make([]*RoomResponse, len(rooms))
-	for i, room := range rooms {
-		roomResponses[i] = room.ToResponse()
+	response.JSON(w, http.StatusCreated, folder.ToResponse())
+}
+
+// UpdateFolder handles PUT /api/v1/rooms/folders/{folder_id}
+func (h *Handler) UpdateFolder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
 	}
+	folderID := chi.URLParam(r, "folder_id")
 
-	response.JSON(w, http.StatusOK, roomResponses)
+	var req UpdateFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.JSON(w, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	folder, err := h.service.UpdateFolder(r.Context(), userID, folderID, req)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, folder.ToResponse())
+}
+
+// DeleteFolder handles DELETE /api/v1/rooms/folders/{folder_id}
+func (h *Handler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	folderID := chi.URLParam(r, "folder_id")
+
+	if err := h.service.DeleteFolder(r.Context(), userID, folderID); err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateNotifications handles PUT /api/v1/rooms/{room_id}/notifications
+func (h *Handler) UpdateNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req UpdateNotificationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.JSON(w, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	prefs, err := h.service.UpdateNotifications(r.Context(), userID, roomID, req)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, NotificationPrefsToResponse(prefs))
+}
+
+// ListPublicRooms handles GET /api/v1/rooms/public?q=&sort=members|activity|created&workspace_id=&cursor=&limit=
+func (h *Handler) ListPublicRooms(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	cursor, err := response.DecodeCursor(query.Get("cursor"))
+	if err != nil {
+		response.Error(w, 0, ErrInvalidCursor)
+		return
+	}
+	filter := DirectoryFilter{
+		Query:       strings.TrimSpace(query.Get("q")),
+		Sort:        DirectorySort(query.Get("sort")),
+		ViewerID:    userID,
+		WorkspaceID: query.Get("workspace_id"),
+		Cursor:      cursor,
+		Limit:       response.ParseLimit(r, 25, 100),
+	}
+
+	page, err := h.service.ListPublicRooms(r.Context(), filter)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, page)
 }
 
 // ListMembers handles GET /api/v1/rooms/{room_id}/members
@@ -207,6 +792,122 @@ func (h *Handler) RemoveMember(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// BanMember handles POST /api/v1/rooms/{room_id}/bans
+func (h *Handler) BanMember(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req BanMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.JSON(w, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	ban, err := h.service.BanMember(r.Context(), actorID, roomID, req)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, ban.ToResponse())
+}
+
+// ListBans handles GET /api/v1/rooms/{room_id}/bans
+func (h *Handler) ListBans(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	bans, err := h.service.ListBans(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	banResponses := make([]*BanResponse, len(bans))
+	for i, ban := range bans {
+		banResponses[i] = ban.ToResponse()
+	}
+
+	response.JSON(w, http.StatusOK, banResponses)
+}
+
+// UnbanMember handles DELETE /api/v1/rooms/{room_id}/bans/{user_id}
+func (h *Handler) UnbanMember(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	targetUserID := chi.URLParam(r, "user_id")
+
+	if err := h.service.UnbanMember(r.Context(), actorID, roomID, targetUserID); err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MuteMember handles PUT /api/v1/rooms/{room_id}/members/{user_id}/mute
+func (h *Handler) MuteMember(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	targetUserID := chi.URLParam(r, "user_id")
+
+	var req MuteMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.JSON(w, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	mutedUntil, err := h.service.MuteMember(r.Context(), actorID, roomID, targetUserID, req)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, MuteResponse{RoomID: roomID, UserID: targetUserID, MutedUntil: mutedUntil})
+}
+
+// UnmuteMember handles DELETE /api/v1/rooms/{room_id}/members/{user_id}/mute
+func (h *Handler) UnmuteMember(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	targetUserID := chi.URLParam(r, "user_id")
+
+	if err := h.service.UnmuteMember(r.Context(), actorID, roomID, targetUserID); err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // LeaveRoom handles DELETE /api/v1/rooms/{room_id}/members/me
 func (h *Handler) LeaveRoom(w http.ResponseWriter, r *http.Request) {
 	userID, ok := authMiddleware.GetUserID(r.Context())
@@ -239,7 +940,10 @@ func (h *Handler) UpdateRoomSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// No validation needed for this specific request, but the hook is here for future fields.
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
 
 	updatedRoom, err := h.service.UpdateRoomSettings(r.Context(), actorID, roomID, req)
 	if err != nil {
@@ -248,4 +952,76 @@ func (h *Handler) UpdateRoomSettings(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response.JSON(w, http.StatusOK, updatedRoom.ToResponse())
-}
\ No newline at end of file
+}
+
+// ArchiveRoom handles POST /api/v1/rooms/{room_id}/archive
+func (h *Handler) ArchiveRoom(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	archivedRoom, err := h.service.ArchiveRoom(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, archivedRoom.ToResponse())
+}
+
+// UnarchiveRoom handles DELETE /api/v1/rooms/{room_id}/archive
+func (h *Handler) UnarchiveRoom(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	unarchivedRoom, err := h.service.UnarchiveRoom(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, unarchivedRoom.ToResponse())
+}
+
+// DeleteRoom handles DELETE /api/v1/rooms/{room_id}
+func (h *Handler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	purgeAfter, err := h.service.DeleteRoom(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, &DeletedRoomResponse{RoomID: roomID, PurgeAfter: purgeAfter})
+}
+
+// RestoreRoom handles POST /api/v1/rooms/{room_id}/restore
+func (h *Handler) RestoreRoom(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	restoredRoom, err := h.service.RestoreRoom(r.Context(), actorID, roomID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, restoredRoom.ToResponse())
+}
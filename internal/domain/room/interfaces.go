@@ -1,8 +1,45 @@
 package room
 
-import "context"
+import (
+	"context"
+	"mime/multipart"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/domain/upload"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
 
 // UserProvider defines the contract for user-related checks needed by the room service.
 type UserProvider interface {
 	ExistsByID(ctx context.Context, id string) (bool, error)
-}
\ No newline at end of file
+	GetByIDShared(ctx context.Context, id string) (*types.User, error)
+	// HasBlocked reports whether blockerID has blocked blockedID; unlike IsBlocked it is one-directional.
+	HasBlocked(ctx context.Context, blockerID, blockedID string) (bool, error)
+}
+
+// WorkspaceProvider answers the workspace questions the room service needs.
+type WorkspaceProvider interface {
+	DefaultWorkspaceID(ctx context.Context) (string, error)
+	IsWorkspaceMember(ctx context.Context, workspaceID, userID string) (bool, error)
+}
+
+// SystemMessenger posts server-generated notices into a room.
+type SystemMessenger interface {
+	PostSystemMessage(ctx context.Context, roomID, content string) (*message.Message, error)
+}
+
+// AvatarUploader queues room avatars for asynchronous processing.
+type AvatarUploader interface {
+	InitiateRoomAvatarUpload(ctx context.Context, userID, roomID string, file multipart.File, header *multipart.FileHeader) (*upload.JobResponse, error)
+}
+
+// AuditRecorder records the administrative actions taken in a room.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *audit.Entry)
+}
+
+// WebhookDispatcher hands membership events to the room's outgoing webhooks.
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, event *types.WebhookEvent)
+}
@@ -2,7 +2,9 @@ package room
 
 import (
 	"context"
+	"time"
 
+	"github.com/purushothdl/gochat-backend/internal/contracts"
 	"github.com/purushothdl/gochat-backend/internal/shared/types"
 )
 
@@ -10,14 +12,91 @@ import (
 type Repository interface {
 	CreateRoom(ctx context.Context, room *Room) error
 	FindRoomByID(ctx context.Context, roomID string) (*Room, error)
-	ListPublicRooms(ctx context.Context) ([]*Room, error)
-	UpdateRoom(ctx context.Context, room *Room) error
+	// ListPublicRooms returns up to filter.Limit directory entries after filter.Cursor
+	// in the filter's sort order.
+	ListPublicRooms(ctx context.Context, filter DirectoryFilter) ([]*DirectoryEntry, error)
+	// UpdateRoom saves the room's mutable properties and stores event, if not nil, in the same transaction.
+	UpdateRoom(ctx context.Context, room *Room, event *contracts.OutboxEvent) error
 
 	CreateMembership(ctx context.Context, membership *RoomMembership) error
 	FindMembership(ctx context.Context, roomID, userID string) (*RoomMembership, error)
 	UpdateMembership(ctx context.Context, membership *RoomMembership) error
 	DeleteMembership(ctx context.Context, roomID, userID string) error
-	ListUserRooms(ctx context.Context, userID string) ([]*Room, error)
+	ListUserRooms(ctx context.Context, userID string, filter UserRoomFilter) ([]*UserRoom, error)
 	ListMembers(ctx context.Context, roomID string) ([]*types.MemberDetail, error)
-	CountAdmins(ctx context.Context, roomID string) (int, error) 
+	// UpdateNotificationPrefs returns ErrNotMember if userID is not in the room.
+	UpdateNotificationPrefs(ctx context.Context, roomID, userID string, prefs types.NotificationPrefs) error
+	// GetRoomPrefs and UpdateRoomPrefs return ErrNotMember if userID is not in the room.
+	GetRoomPrefs(ctx context.Context, roomID, userID string) (*types.RoomPrefs, error)
+	UpdateRoomPrefs(ctx context.Context, roomID, userID string, prefs *types.RoomPrefs, event *contracts.OutboxEvent) error
+
+	// CreateFolder places the folder after the user's existing ones. It returns
+	// ErrFolderExists if the user already has a folder with the same name.
+	CreateFolder(ctx context.Context, folder *Folder, buildEvent FolderEventBuilder) error
+	FindFolder(ctx context.Context, userID, folderID string) (*Folder, error)
+	ListFolders(ctx context.Context, userID string) ([]*Folder, error)
+	UpdateFolder(ctx context.Context, folder *Folder, event *contracts.OutboxEvent) error
+	// DeleteFolder removes one of the user's folders, moving its rooms out of it.
+	DeleteFolder(ctx context.Context, userID, folderID string, event *contracts.OutboxEvent) error
+	TransferOwnership(ctx context.Context, roomID, ownerID, newOwnerID string) error
+
+	// CreateInvitation stores a pending invitation together with its notification
+	// event. It returns ErrAlreadyInvited if an unexpired one already exists.
+	CreateInvitation(ctx context.Context, inv *Invitation, event *contracts.OutboxEvent) error
+	FindInvitationByID(ctx context.Context, invitationID string) (*Invitation, error)
+	// RenewInvitation pushes out a pending invitation's expiry and stores a fresh notification event.
+	RenewInvitation(ctx context.Context, inv *Invitation, event *contracts.OutboxEvent) error
+	// SetInvitationStatus closes a pending invitation; accepting also creates the membership.
+	SetInvitationStatus(ctx context.Context, inv *Invitation, status InvitationStatus) error
+	ListRoomInvitations(ctx context.Context, roomID string) ([]*InvitationDetail, error)
+	ListUserInvitations(ctx context.Context, userID string) ([]*InvitationDetail, error)
+
+	// CreateJoinRequest stores a pending join request and its notification events.
+	// It returns ErrJoinRequestExists if the user already has one pending for the room.
+	CreateJoinRequest(ctx context.Context, req *JoinRequest, events ...*contracts.OutboxEvent) error
+	FindJoinRequestByID(ctx context.Context, requestID string) (*JoinRequest, error)
+	HasPendingJoinRequest(ctx context.Context, roomID, userID string) (bool, error)
+	// ResolveJoinRequest closes a pending request as respondedBy and stores events;
+	// approving also creates the membership. It returns ErrJoinRequestNotPending if
+	// the request was already closed.
+	ResolveJoinRequest(ctx context.Context, req *JoinRequest, status JoinRequestStatus, respondedBy *string, events ...*contracts.OutboxEvent) error
+	ListRoomJoinRequests(ctx context.Context, roomID string) ([]*JoinRequest, error)
+	ListUserJoinRequests(ctx context.Context, userID string) ([]*JoinRequest, error)
+
+	CreateInviteLink(ctx context.Context, link *InviteLink) error
+	ListInviteLinks(ctx context.Context, roomID string) ([]*InviteLink, error)
+	RevokeInviteLink(ctx context.Context, roomID, linkID string) error
+	// GetInviteLinkPreview returns ErrInviteLinkInvalid unless the link is currently usable.
+	GetInviteLinkPreview(ctx context.Context, tokenHash string) (*InviteLinkPreview, error)
+	// BanMember stores the ban, removes any membership, pending invitations and
+	// join requests the user has in the room, and stores events, all in one transaction.
+	BanMember(ctx context.Context, ban *Ban, events ...*contracts.OutboxEvent) error
+	// UnbanMember returns ErrBanNotFound unless a ban is in force.
+	UnbanMember(ctx context.Context, roomID, userID string, event *contracts.OutboxEvent) error
+	IsBanned(ctx context.Context, roomID, userID string) (bool, error)
+	ListBans(ctx context.Context, roomID string) ([]*Ban, error)
+	// SetMemberMute mutes a member until mutedUntil, or unmutes them when it is nil.
+	SetMemberMute(ctx context.Context, roomID, userID string, mutedUntil *time.Time, event *contracts.OutboxEvent) error
+	// ExpireBans and ExpireMutes lift lapsed sanctions, storing one event per member.
+	ExpireBans(ctx context.Context, buildEvent MemberEventBuilder) (int, error)
+	ExpireMutes(ctx context.Context, buildEvent MemberEventBuilder) (int, error)
+
+	// SetRoomArchived returns ErrAlreadyArchived or ErrRoomNotArchived if the room is already in the requested state.
+	SetRoomArchived(ctx context.Context, roomID string, archived bool, event *contracts.OutboxEvent) error
+	SoftDeleteRoom(ctx context.Context, roomID string, deletedAt time.Time, event *contracts.OutboxEvent) error
+	// RestoreRoom returns ErrRoomNotFound unless the room was deleted after
+	// deletedAfter and ownerID still owns it.
+	RestoreRoom(ctx context.Context, roomID, ownerID string, deletedAfter time.Time) error
+
+	ListRoomsToPurge(ctx context.Context, deletedBefore time.Time) ([]string, error)
+	ListExportFileKeys(ctx context.Context, roomID string) ([]string, error)
+	// PurgeMessagesBatch deletes up to limit messages of a room deleted before
+	// deletedBefore and returns how many were removed.
+	PurgeMessagesBatch(ctx context.Context, roomID string, deletedBefore time.Time, limit int) (int64, error)
+	PurgeRoom(ctx context.Context, roomID string, deletedBefore time.Time) error
+
+	// RedeemInviteLink adds userID to the link's room with the link's role and counts the use.
+	// The use is only counted if the link is still usable at that moment, so concurrent
+	// redemptions can never exceed max_uses.
+	RedeemInviteLink(ctx context.Context, tokenHash, userID string) (*RoomMembership, error)
 }
\ No newline at end of file
@@ -1,18 +1,83 @@
 package room
 
+import (
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// CreateRoomRequest creates a room in a workspace the caller belongs to,
+// the default workspace when WorkspaceID is left out.
 type CreateRoomRequest struct {
-	Name string   `json:"name" validate:"required,min=3,max=50"`
-	Type RoomType `json:"type" validate:"required,oneof=PRIVATE PUBLIC"`
+	Name        string   `json:"name" validate:"required,min=3,max=50"`
+	Type        RoomType `json:"type" validate:"required,oneof=PRIVATE PUBLIC"`
+	WorkspaceID string   `json:"workspace_id" validate:"omitempty,uuid"`
 }
 
 type InviteUserRequest struct {
 	UserID string `json:"user_id" validate:"required,uuid"`
 }
 
+type CreateInviteLinkRequest struct {
+	Role           MemberRole `json:"role" validate:"omitempty,oneof=ADMIN MODERATOR MEMBER"`
+	MaxUses        *int       `json:"max_uses" validate:"omitempty,min=1,max=10000"`
+	ExpiresInHours *int       `json:"expires_in_hours" validate:"omitempty,min=1,max=8760"`
+}
+
+type CreateJoinRequestRequest struct {
+	Message string `json:"message" validate:"max=500"`
+}
+
+// UpdateNotificationsRequest replaces the caller's notification settings for a room.
+// Omitting muted_until clears any mute.
+type UpdateNotificationsRequest struct {
+	Level      types.NotificationLevel `json:"level" validate:"required,oneof=ALL MENTIONS NONE"`
+	MutedUntil *time.Time              `json:"muted_until"`
+}
+
+// UpdateRoomPrefsRequest changes how a room appears in the caller's own room list.
+// Fields left out keep their current value; an empty folder_id takes the room out of its folder.
+type UpdateRoomPrefsRequest struct {
+	IsPinned   *bool   `json:"is_pinned"`
+	IsFavorite *bool   `json:"is_favorite"`
+	IsHidden   *bool   `json:"is_hidden"`
+	FolderID   *string `json:"folder_id" validate:"omitempty,uuid|len=0"`
+	Position   *int    `json:"position" validate:"omitempty,min=0,max=10000"`
+}
+
+type CreateFolderRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+type UpdateFolderRequest struct {
+	Name     *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Position *int    `json:"position" validate:"omitempty,min=0,max=10000"`
+}
+
 type UpdateMemberRoleRequest struct {
-	Role MemberRole `json:"role" validate:"required,oneof=ADMIN MEMBER"`
+	Role MemberRole `json:"role" validate:"required,oneof=OWNER ADMIN MODERATOR MEMBER"`
+}
+
+type BanMemberRequest struct {
+	UserID        string `json:"user_id" validate:"required,uuid"`
+	Reason        string `json:"reason" validate:"max=500"`
+	DurationHours *int   `json:"duration_hours" validate:"omitempty,min=1,max=8760"` // Omit for a permanent ban
+}
+
+type MuteMemberRequest struct {
+	DurationMinutes int    `json:"duration_minutes" validate:"required,min=1,max=43200"`
+	Reason          string `json:"reason" validate:"max=500"`
 }
 
 type UpdateRoomSettingsRequest struct {
-	IsBroadcastOnly *bool `json:"is_broadcast_only"`
-}
\ No newline at end of file
+	Name            *string `json:"name" validate:"omitempty,min=3,max=50"`
+	Description     *string `json:"description" validate:"omitempty,max=500"`
+	Topic           *string `json:"topic" validate:"omitempty,max=250"`
+	IsBroadcastOnly *bool   `json:"is_broadcast_only"`
+	IsDiscoverable  *bool   `json:"is_discoverable"` // Private rooms only
+	SlowModeSeconds *int    `json:"slow_mode_seconds" validate:"omitempty,min=0,max=21600"`
+	// Moderation switches individual hooks on or off; hooks not mentioned keep their current state.
+	Moderation map[string]bool `json:"moderation" validate:"omitempty,dive,keys,oneof=word_filter link_filter external,endkeys"`
+	// MemberPermissions grants or withholds permissions for the MEMBER role; permissions not mentioned keep their current state.
+	MemberPermissions map[string]bool `json:"member_permissions" validate:"omitempty,dive,keys,oneof=send_messages invite_members pin_messages,endkeys"`
+}
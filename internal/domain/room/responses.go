@@ -6,37 +6,296 @@ import (
 	"github.com/purushothdl/gochat-backend/internal/shared/types"
 )
 
-
 type RoomResponse struct {
-	ID              string    `json:"id"`
-	Name            string    `json:"name"`
-	Type            RoomType  `json:"type"`
-	IsBroadcastOnly bool      `json:"is_broadcast_only"`
-	CreatedAt       time.Time `json:"created_at"`
+	ID                string                    `json:"id"`
+	WorkspaceID       string                    `json:"workspace_id"`
+	Name              string                    `json:"name"`
+	Description       string                    `json:"description"`
+	Topic             string                    `json:"topic"`
+	AvatarURL         string                    `json:"avatar_url"`
+	Type              RoomType                  `json:"type"`
+	IsBroadcastOnly   bool                      `json:"is_broadcast_only"`
+	IsDiscoverable    bool                      `json:"is_discoverable"`
+	SlowModeSeconds   int                       `json:"slow_mode_seconds"`
+	Moderation        types.ModerationSettings  `json:"moderation"`
+	MemberPermissions types.PermissionOverrides `json:"member_permissions"`
+	ArchivedAt        *time.Time                `json:"archived_at,omitempty"`
+	CreatedAt         time.Time                 `json:"created_at"`
+}
+
+// UserRoomResponse is a room in the caller's room list, with their notification settings.
+type UserRoomResponse struct {
+	*RoomResponse
+	Notifications NotificationPrefsResponse `json:"notifications"`
+	Prefs         RoomPrefsResponse         `json:"prefs"`
+}
+
+// RoomPrefsResponse is how a room appears in the caller's own room list.
+type RoomPrefsResponse struct {
+	IsPinned   bool       `json:"is_pinned"`
+	IsFavorite bool       `json:"is_favorite"`
+	IsHidden   bool       `json:"is_hidden"`
+	HiddenAt   *time.Time `json:"hidden_at,omitempty"`
+	FolderID   *string    `json:"folder_id"`
+	Position   *int       `json:"position"`
+}
+
+type FolderResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type NotificationPrefsResponse struct {
+	Level      types.NotificationLevel `json:"level"`
+	MutedUntil *time.Time              `json:"muted_until"`
+}
+
+// PublicRoomResponse is a room as listed in the directory. PRIVATE rooms are
+// joined through a join request rather than directly.
+type PublicRoomResponse struct {
+	ID                string    `json:"id"`
+	WorkspaceID       string    `json:"workspace_id"`
+	Name              string    `json:"name"`
+	Description       string    `json:"description"`
+	Topic             string    `json:"topic"`
+	AvatarURL         string    `json:"avatar_url"`
+	Type              RoomType  `json:"type"`
+	MemberCount       int       `json:"member_count"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	IsMember          bool      `json:"is_member"`
+	HasPendingRequest bool      `json:"has_pending_request"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// DeletedRoomResponse tells the owner how long a deleted room can be restored.
+type DeletedRoomResponse struct {
+	RoomID     string    `json:"room_id"`
+	PurgeAfter time.Time `json:"purge_after"`
+}
+
+type InvitationResponse struct {
+	ID          string           `json:"id"`
+	RoomID      string           `json:"room_id"`
+	RoomName    string           `json:"room_name,omitempty"`
+	InviterID   *string          `json:"inviter_id"`
+	InviteeID   string           `json:"invitee_id"`
+	Inviter     *types.BasicUser `json:"inviter,omitempty"`
+	Invitee     *types.BasicUser `json:"invitee,omitempty"`
+	Status      InvitationStatus `json:"status"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	RespondedAt *time.Time       `json:"responded_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type JoinRequestResponse struct {
+	ID          string            `json:"id"`
+	RoomID      string            `json:"room_id"`
+	RoomName    string            `json:"room_name,omitempty"`
+	UserID      string            `json:"user_id"`
+	User        *types.BasicUser  `json:"user,omitempty"`
+	Message     string            `json:"message"`
+	Status      JoinRequestStatus `json:"status"`
+	RespondedBy *string           `json:"responded_by,omitempty"`
+	RespondedAt *time.Time        `json:"responded_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+type InviteLinkResponse struct {
+	ID        string     `json:"id"`
+	RoomID    string     `json:"room_id"`
+	Token     string     `json:"token,omitempty"` // Only returned when the link is created
+	Role      MemberRole `json:"role"`
+	MaxUses   *int       `json:"max_uses"`
+	UseCount  int        `json:"use_count"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	IsActive  bool       `json:"is_active"`
+	CreatedBy *string    `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type InviteLinkPreviewResponse struct {
+	RoomID      string     `json:"room_id"`
+	RoomName    string     `json:"room_name"`
+	MemberCount int        `json:"member_count"`
+	ExpiresAt   *time.Time `json:"expires_at"`
 }
 
 type MemberResponse struct {
-	UserID   string            `json:"user_id"`
-	Role     types.MemberRole  `json:"role"`
-	Name     string            `json:"name"`
-	ImageURL string            `json:"image_url"`
+	UserID     string           `json:"user_id"`
+	Role       types.MemberRole `json:"role"`
+	Name       string           `json:"name"`
+	ImageURL   string           `json:"image_url"`
+	IsBot      bool             `json:"is_bot"`
+	MutedUntil *time.Time       `json:"muted_until,omitempty"`
+}
+
+type BanResponse struct {
+	RoomID    string           `json:"room_id"`
+	UserID    string           `json:"user_id"`
+	User      *types.BasicUser `json:"user,omitempty"`
+	BannedBy  *string          `json:"banned_by"`
+	Reason    string           `json:"reason"`
+	ExpiresAt *time.Time       `json:"expires_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type MuteResponse struct {
+	RoomID     string    `json:"room_id"`
+	UserID     string    `json:"user_id"`
+	MutedUntil time.Time `json:"muted_until"`
 }
 
 func (r *Room) ToResponse() *RoomResponse {
 	return &RoomResponse{
-		ID:              r.ID,
-		Name:            r.Name,
-		Type:            r.Type,
-		IsBroadcastOnly: r.IsBroadcastOnly,
-		CreatedAt:       r.CreatedAt,
+		ID:                r.ID,
+		WorkspaceID:       r.WorkspaceID,
+		Name:              r.Name,
+		Description:       r.Description,
+		Topic:             r.Topic,
+		AvatarURL:         r.AvatarURL,
+		Type:              r.Type,
+		IsBroadcastOnly:   r.IsBroadcastOnly,
+		IsDiscoverable:    r.IsDiscoverable,
+		SlowModeSeconds:   r.SlowModeSeconds,
+		Moderation:        r.ModerationSettings,
+		MemberPermissions: r.MemberPermissions,
+		ArchivedAt:        r.ArchivedAt,
+		CreatedAt:         r.CreatedAt,
+	}
+}
+
+func (u *UserRoom) ToResponse() *UserRoomResponse {
+	return &UserRoomResponse{
+		RoomResponse:  u.Room.ToResponse(),
+		Notifications: NotificationPrefsToResponse(u.Notifications),
+		Prefs:         RoomPrefsToResponse(u.Prefs),
+	}
+}
+
+func RoomPrefsToResponse(p types.RoomPrefs) RoomPrefsResponse {
+	return RoomPrefsResponse{
+		IsPinned:   p.IsPinned,
+		IsFavorite: p.IsFavorite,
+		IsHidden:   p.HiddenAt != nil,
+		HiddenAt:   p.HiddenAt,
+		FolderID:   p.FolderID,
+		Position:   p.Position,
+	}
+}
+
+func (f *Folder) ToResponse() *FolderResponse {
+	return &FolderResponse{
+		ID:        f.ID,
+		Name:      f.Name,
+		Position:  f.Position,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+func NotificationPrefsToResponse(p types.NotificationPrefs) NotificationPrefsResponse {
+	return NotificationPrefsResponse{Level: p.Level, MutedUntil: p.MutedUntil}
+}
+
+func (e *DirectoryEntry) ToResponse() *PublicRoomResponse {
+	return &PublicRoomResponse{
+		ID:                e.Room.ID,
+		WorkspaceID:       e.Room.WorkspaceID,
+		Name:              e.Room.Name,
+		Description:       e.Room.Description,
+		Topic:             e.Room.Topic,
+		AvatarURL:         e.Room.AvatarURL,
+		Type:              e.Room.Type,
+		MemberCount:       e.MemberCount,
+		LastActivityAt:    e.LastActivityAt,
+		IsMember:          e.IsMember,
+		HasPendingRequest: e.HasPendingRequest,
+		CreatedAt:         e.Room.CreatedAt,
+	}
+}
+
+func (b *Ban) ToResponse() *BanResponse {
+	return &BanResponse{
+		RoomID:    b.RoomID,
+		UserID:    b.UserID,
+		User:      b.User,
+		BannedBy:  b.BannedBy,
+		Reason:    b.Reason,
+		ExpiresAt: b.ExpiresAt,
+		CreatedAt: b.CreatedAt,
 	}
 }
 
 func MemberDetailToResponse(d *types.MemberDetail) *MemberResponse {
 	return &MemberResponse{
-		UserID:   d.UserID,
-		Role:     types.MemberRole(d.Role), 
-		Name:     d.Name,
-		ImageURL: d.ImageURL,
+		UserID:     d.UserID,
+		Role:       types.MemberRole(d.Role),
+		Name:       d.Name,
+		ImageURL:   d.ImageURL,
+		IsBot:      d.IsBot,
+		MutedUntil: d.MutedUntil,
+	}
+}
+
+// ToResponse reports the invitation's effective status, so a lapsed pending invitation shows as expired.
+func (i *Invitation) ToResponse() *InvitationResponse {
+	return &InvitationResponse{
+		ID:          i.ID,
+		RoomID:      i.RoomID,
+		InviterID:   i.InviterID,
+		InviteeID:   i.InviteeID,
+		Status:      i.EffectiveStatus(time.Now()),
+		ExpiresAt:   i.ExpiresAt,
+		RespondedAt: i.RespondedAt,
+		CreatedAt:   i.CreatedAt,
+	}
+}
+
+func InvitationDetailToResponse(d *InvitationDetail) *InvitationResponse {
+	resp := d.Invitation.ToResponse()
+	resp.RoomName = d.RoomName
+	resp.Inviter = d.Inviter
+	resp.Invitee = d.Invitee
+	return resp
+}
+
+func (j *JoinRequest) ToResponse() *JoinRequestResponse {
+	return &JoinRequestResponse{
+		ID:          j.ID,
+		RoomID:      j.RoomID,
+		RoomName:    j.RoomName,
+		UserID:      j.UserID,
+		User:        j.User,
+		Message:     j.Message,
+		Status:      j.Status,
+		RespondedBy: j.RespondedBy,
+		RespondedAt: j.RespondedAt,
+		CreatedAt:   j.CreatedAt,
 	}
-}
\ No newline at end of file
+}
+
+func (l *InviteLink) ToResponse() *InviteLinkResponse {
+	return &InviteLinkResponse{
+		ID:        l.ID,
+		RoomID:    l.RoomID,
+		Role:      l.Role,
+		MaxUses:   l.MaxUses,
+		UseCount:  l.UseCount,
+		ExpiresAt: l.ExpiresAt,
+		RevokedAt: l.RevokedAt,
+		IsActive:  l.IsUsable(time.Now()),
+		CreatedBy: l.CreatedBy,
+		CreatedAt: l.CreatedAt,
+	}
+}
+
+func (p *InviteLinkPreview) ToResponse() *InviteLinkPreviewResponse {
+	return &InviteLinkPreviewResponse{
+		RoomID:      p.RoomID,
+		RoomName:    p.RoomName,
+		MemberCount: p.MemberCount,
+		ExpiresAt:   p.ExpiresAt,
+	}
+}
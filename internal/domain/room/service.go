@@ -5,31 +5,80 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"mime/multipart"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/domain/upload"
+	"github.com/purushothdl/gochat-backend/internal/shared/authz"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
 	"github.com/purushothdl/gochat-backend/internal/shared/types"
+	"github.com/purushothdl/gochat-backend/internal/websocket"
 	"github.com/purushothdl/gochat-backend/pkg/errors"
+	"github.com/purushothdl/gochat-backend/pkg/utils/tokenutil"
 )
 
 type Service struct {
-	roomRepo Repository
-	userProv UserProvider
-	config   *config.Config
-	logger   *slog.Logger
+	roomRepo       Repository
+	userProv       UserProvider
+	workspaceProv  WorkspaceProvider
+	messenger      SystemMessenger
+	avatarUploader AvatarUploader
+	auditor        AuditRecorder
+	webhooks       WebhookDispatcher
+	limiter        contracts.RateLimiter
+	config         *config.Config
+	logger         *slog.Logger
 }
 
-func NewService(repo Repository, userProv UserProvider, cfg *config.Config, logger *slog.Logger) *Service {
+func NewService(
+	repo Repository,
+	userProv UserProvider,
+	workspaceProv WorkspaceProvider,
+	messenger SystemMessenger,
+	avatarUploader AvatarUploader,
+	auditor AuditRecorder,
+	webhooks WebhookDispatcher,
+	limiter contracts.RateLimiter,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *Service {
 	return &Service{
-		roomRepo: repo,
-		userProv: userProv,
-		config:   cfg,
-		logger:   logger,
+		roomRepo:       repo,
+		userProv:       userProv,
+		workspaceProv:  workspaceProv,
+		messenger:      messenger,
+		avatarUploader: avatarUploader,
+		auditor:        auditor,
+		webhooks:       webhooks,
+		limiter:        limiter,
+		config:         cfg,
+		logger:         logger,
 	}
 }
 
 // CreateRoom handles the creation of a new room and assigns the creator as an admin.
+// The room goes into the requested workspace, or the default one, which the
+// creator must belong to.
 func (s *Service) CreateRoom(ctx context.Context, creatorID string, req CreateRoomRequest) (*Room, error) {
-	newRoom := NewPrivateRoom(req.Name)
+	workspaceID := req.WorkspaceID
+	if workspaceID == "" {
+		defaultID, err := s.workspaceProv.DefaultWorkspaceID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		workspaceID = defaultID
+	}
+	if err := s.requireWorkspaceMember(ctx, workspaceID, creatorID, ErrNotWorkspaceMember); err != nil {
+		return nil, err
+	}
+
+	newRoom := NewPrivateRoom(workspaceID, req.Name)
 	newRoom.Type = req.Type
 
 	err := s.roomRepo.CreateRoom(ctx, newRoom)
@@ -37,69 +86,290 @@ func (s *Service) CreateRoom(ctx context.Context, creatorID string, req CreateRo
 		return nil, fmt.Errorf("service failed to create room: %w", err)
 	}
 
-	// The creator automatically becomes an admin of the new room.
-	adminMembership := &RoomMembership{
+	// The creator automatically becomes the owner of the new room.
+	ownerMembership := &RoomMembership{
 		RoomID: newRoom.ID,
 		UserID: creatorID,
-		Role:   AdminRole,
+		Role:   OwnerRole,
 	}
 
-	err = s.roomRepo.CreateMembership(ctx, adminMembership)
+	err = s.roomRepo.CreateMembership(ctx, ownerMembership)
 	if err != nil {
-		return nil, fmt.Errorf("service failed to create admin membership: %w", err)
+		return nil, fmt.Errorf("service failed to create owner membership: %w", err)
 	}
 
 	s.logger.Info("new room created", "room_id", newRoom.ID, "user_id", creatorID)
 	return newRoom, nil
 }
 
-// InviteUser handles inviting a new user to a private room.
-func (s *Service) InviteUser(ctx context.Context, inviterID, roomID, inviteeID string) error {
-	// 1. Verify the person sending the invite is an admin.
-	inviterMembership, err := s.roomRepo.FindMembership(ctx, roomID, inviterID)
-	if err != nil {
-		return err // Could be ErrNotMember or a db error
+// InviteUser sends a pending invitation to a user; they only join the room once they accept it.
+func (s *Service) InviteUser(ctx context.Context, inviterID, roomID, inviteeID string) (*Invitation, error) {
+	// 1. Verify the person sending the invite may invite members.
+	if _, err := s.authorize(ctx, roomID, inviterID, authz.InviteMembers); err != nil {
+		return nil, err
 	}
-	if inviterMembership.Role != AdminRole {
-		return ErrNotAdmin
+	targetRoom, err := s.requireActive(ctx, roomID)
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. Check if the user to be invited actually exists.
+	// 2. Check if the user to be invited actually exists, and shares the room's workspace.
 	exists, err := s.userProv.ExistsByID(ctx, inviteeID)
 	if err != nil {
-		return fmt.Errorf("failed to check user existence: %w", err)
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
 	}
 	if !exists {
-		return ErrUserNotFound
+		return nil, ErrUserNotFound
 	}
-    
-    // 3. Check if the user is already a member.
-    _, err = s.roomRepo.FindMembership(ctx, roomID, inviteeID)
-    if err == nil {
-        return ErrAlreadyInRoom
-    }
-    if err != ErrNotMember {
-        // A different database error occurred
-        return err
-    }
-
-	// 4. Create the new membership.
-	newMembership := &RoomMembership{
-		RoomID: roomID,
-		UserID: inviteeID,
-		Role:   RegularRole,
+	if err := s.requireWorkspaceMember(ctx, targetRoom.WorkspaceID, inviteeID, ErrInviteeNotInWorkspace); err != nil {
+		return nil, err
 	}
-	if err := s.roomRepo.CreateMembership(ctx, newMembership); err != nil {
-		return fmt.Errorf("service failed to create membership for invitee: %w", err)
+
+	// 3. Check if the user is already a member, or is banned from the room.
+	if err := s.checkNotBanned(ctx, roomID, inviteeID, ErrInviteeBanned); err != nil {
+		return nil, err
+	}
+	_, err = s.roomRepo.FindMembership(ctx, roomID, inviteeID)
+	if err == nil {
+		return nil, ErrAlreadyInRoom
+	}
+	if err != ErrNotMember {
+		// A different database error occurred
+		return nil, err
+	}
+
+	// 4. Respect the invitee's blocks.
+	if err := s.checkNotBlocked(ctx, inviteeID, inviterID); err != nil {
+		return nil, err
+	}
+
+	// 5. Create the invitation and notify the invitee.
+	inv := NewInvitation(roomID, inviterID, inviteeID, s.config.Room.InvitationTTL)
+	event, err := s.inviteReceivedEvent(ctx, inv, inviterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.roomRepo.CreateInvitation(ctx, inv, event); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("user invited to room", "room_id", roomID, "inviter_id", inviterID, "invitee_id", inviteeID, "invitation_id", inv.ID)
+	return inv, nil
+}
+
+// ResendInvitation renews a pending invitation, including one that has lapsed, and notifies the invitee again.
+func (s *Service) ResendInvitation(ctx context.Context, actorID, roomID, invitationID string) (*Invitation, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.InviteMembers); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireActive(ctx, roomID); err != nil {
+		return nil, err
+	}
+
+	inv, err := s.findRoomInvitation(ctx, roomID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status != InvitationPending {
+		return nil, ErrInvitationNotPending
+	}
+	if err := s.checkNotBlocked(ctx, inv.InviteeID, actorID); err != nil {
+		return nil, err
+	}
+
+	inv.ExpiresAt = time.Now().Add(s.config.Room.InvitationTTL)
+	event, err := s.inviteReceivedEvent(ctx, inv, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.roomRepo.RenewInvitation(ctx, inv, event); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("room invitation resent", "room_id", roomID, "invitation_id", inv.ID, "actor_id", actorID)
+	return inv, nil
+}
+
+// RevokeInvitation withdraws a pending invitation.
+func (s *Service) RevokeInvitation(ctx context.Context, actorID, roomID, invitationID string) error {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.InviteMembers); err != nil {
+		return err
+	}
+
+	inv, err := s.findRoomInvitation(ctx, roomID, invitationID)
+	if err != nil {
+		return err
+	}
+	if err := s.roomRepo.SetInvitationStatus(ctx, inv, InvitationRevoked); err != nil {
+		return err
+	}
+
+	s.logger.Info("room invitation revoked", "room_id", roomID, "invitation_id", inv.ID, "actor_id", actorID)
+	return nil
+}
+
+// ListRoomInvitations lists a room's outstanding invitations for its admins.
+func (s *Service) ListRoomInvitations(ctx context.Context, actorID, roomID string) ([]*InvitationDetail, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.InviteMembers); err != nil {
+		return nil, err
+	}
+	return s.roomRepo.ListRoomInvitations(ctx, roomID)
+}
+
+// ListMyInvitations lists the invitations the user can still accept.
+func (s *Service) ListMyInvitations(ctx context.Context, userID string) ([]*InvitationDetail, error) {
+	return s.roomRepo.ListUserInvitations(ctx, userID)
+}
+
+// AcceptInvitation adds the invitee to the room and closes the invitation.
+func (s *Service) AcceptInvitation(ctx context.Context, userID, invitationID string) (*Invitation, error) {
+	inv, err := s.findOwnInvitation(ctx, userID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	targetRoom, err := s.requireActive(ctx, inv.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	// The invitee may have left the workspace since they were invited.
+	if err := s.requireWorkspaceMember(ctx, targetRoom.WorkspaceID, userID, ErrNotWorkspaceMember); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotBanned(ctx, inv.RoomID, userID, ErrBanned); err != nil {
+		return nil, err
+	}
+	if err := s.roomRepo.SetInvitationStatus(ctx, inv, InvitationAccepted); err != nil {
+		return nil, err
+	}
+	s.dispatchMemberEvent(ctx, types.WebhookMemberJoined, inv.RoomID, userID, map[string]any{"via": "invitation"})
+
+	s.logger.Info("room invitation accepted", "room_id", inv.RoomID, "invitation_id", inv.ID, "user_id", userID)
+	return inv, nil
+}
+
+// DeclineInvitation closes the invitation without joining the room.
+func (s *Service) DeclineInvitation(ctx context.Context, userID, invitationID string) (*Invitation, error) {
+	inv, err := s.findOwnInvitation(ctx, userID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.roomRepo.SetInvitationStatus(ctx, inv, InvitationDeclined); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("room invitation declined", "room_id", inv.RoomID, "invitation_id", inv.ID, "user_id", userID)
+	return inv, nil
+}
+
+// CreateInviteLink generates a shareable link for a private room. The plaintext
+// token is returned only here; just its hash is stored.
+func (s *Service) CreateInviteLink(ctx context.Context, actorID, roomID string, req CreateInviteLinkRequest) (*InviteLink, string, error) {
+	actor, err := s.authorize(ctx, roomID, actorID, authz.InviteMembers)
+	if err != nil {
+		return nil, "", err
+	}
+	if req.Role != "" && req.Role != RegularRole {
+		if err := authz.RequireAssignable(types.MemberRole(actor.Role), types.MemberRole(req.Role)); err != nil {
+			return nil, "", err
+		}
+	}
+
+	targetRoom, err := s.requireActive(ctx, roomID)
+	if err != nil {
+		return nil, "", err
+	}
+	if targetRoom.Type != PrivateRoom {
+		return nil, "", ErrInviteLinkNotAllowed
+	}
+
+	token, tokenHash, err := tokenutil.Generate()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate invite token: %w", err)
+	}
+
+	link := &InviteLink{
+		ID:        uuid.NewString(),
+		RoomID:    roomID,
+		CreatedBy: &actorID,
+		TokenHash: tokenHash,
+		Role:      RegularRole,
+		MaxUses:   req.MaxUses,
+	}
+	if req.Role != "" {
+		link.Role = req.Role
+	}
+	if req.ExpiresInHours != nil {
+		expiresAt := time.Now().Add(time.Duration(*req.ExpiresInHours) * time.Hour)
+		link.ExpiresAt = &expiresAt
+	}
+
+	if err := s.roomRepo.CreateInviteLink(ctx, link); err != nil {
+		return nil, "", err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionInviteLinkCreated, "invite_link", link.ID, map[string]any{
+		"role":       link.Role,
+		"max_uses":   link.MaxUses,
+		"expires_at": link.ExpiresAt,
+	}))
+	s.logger.Info("invite link created", "room_id", roomID, "link_id", link.ID, "actor_id", actorID)
+	return link, token, nil
+}
+
+// ListInviteLinks lists all of a room's invite links, including revoked and used-up ones.
+func (s *Service) ListInviteLinks(ctx context.Context, actorID, roomID string) ([]*InviteLink, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.InviteMembers); err != nil {
+		return nil, err
+	}
+	return s.roomRepo.ListInviteLinks(ctx, roomID)
+}
+
+// RevokeInviteLink disables an invite link immediately.
+func (s *Service) RevokeInviteLink(ctx context.Context, actorID, roomID, linkID string) error {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.InviteMembers); err != nil {
+		return err
+	}
+	if err := s.roomRepo.RevokeInviteLink(ctx, roomID, linkID); err != nil {
+		return err
 	}
 
-	s.logger.Info("user invited to room", "room_id", roomID, "inviter_id", inviterID, "invitee_id", inviteeID)
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionInviteLinkRevoked, "invite_link", linkID, nil))
+	s.logger.Info("invite link revoked", "room_id", roomID, "link_id", linkID, "actor_id", actorID)
 	return nil
 }
 
+// PreviewInviteLink shows what room a link leads to without requiring authentication.
+func (s *Service) PreviewInviteLink(ctx context.Context, token string) (*InviteLinkPreview, error) {
+	return s.roomRepo.GetInviteLinkPreview(ctx, tokenutil.Hash(token))
+}
+
+// AcceptInviteLink joins the user to the link's room with the role the link grants, returning the room.
+func (s *Service) AcceptInviteLink(ctx context.Context, userID, token string) (*Room, error) {
+	preview, err := s.roomRepo.GetInviteLinkPreview(ctx, tokenutil.Hash(token))
+	if err != nil {
+		return nil, err
+	}
+	targetRoom, err := s.roomRepo.FindRoomByID(ctx, preview.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireWorkspaceMember(ctx, targetRoom.WorkspaceID, userID, ErrNotWorkspaceMember); err != nil {
+		return nil, err
+	}
+
+	membership, err := s.roomRepo.RedeemInviteLink(ctx, tokenutil.Hash(token), userID)
+	if err != nil {
+		return nil, err
+	}
+	s.dispatchMemberEvent(ctx, types.WebhookMemberJoined, membership.RoomID, userID, map[string]any{"via": "invite_link"})
+
+	s.logger.Info("user joined room via invite link", "room_id", membership.RoomID, "user_id", userID, "role", membership.Role)
+	return s.roomRepo.FindRoomByID(ctx, membership.RoomID)
+}
+
 // JoinPublicRoom allows a user to become a member of a public room.
 func (s *Service) JoinPublicRoom(ctx context.Context, userID, roomID string) error {
-	targetRoom, err := s.roomRepo.FindRoomByID(ctx, roomID)
+	targetRoom, err := s.requireActive(ctx, roomID)
 	if err != nil {
 		return err 
 	}
@@ -107,6 +377,12 @@ func (s *Service) JoinPublicRoom(ctx context.Context, userID, roomID string) err
 	if targetRoom.Type != PublicRoom {
 		return errors.New("NOT_PUBLIC", "This room is not public.", 403)
 	}
+	if err := s.requireWorkspaceMember(ctx, targetRoom.WorkspaceID, userID, ErrNotWorkspaceMember); err != nil {
+		return err
+	}
+	if err := s.checkNotBanned(ctx, roomID, userID, ErrBanned); err != nil {
+		return err
+	}
     
     // Check if user is already a member to prevent constraint violation errors.
 	if _, err := s.roomRepo.FindMembership(ctx, roomID, userID); err != ErrNotMember {
@@ -122,128 +398,1134 @@ func (s *Service) JoinPublicRoom(ctx context.Context, userID, roomID string) err
 		UserID: userID,
 		Role:   RegularRole,
 	}
-	return s.roomRepo.CreateMembership(ctx, newMembership)
+	if err := s.roomRepo.CreateMembership(ctx, newMembership); err != nil {
+		return err
+	}
+	s.dispatchMemberEvent(ctx, types.WebhookMemberJoined, roomID, userID, map[string]any{"via": "public"})
+	return nil
 }
 
-// ListUserRooms retrieves a list of rooms the given user is a member of.
-func (s *Service) ListUserRooms(ctx context.Context, userID string) ([]*Room, error) {
-	return s.roomRepo.ListUserRooms(ctx, userID)
-}
+// RequestToJoin files a request to join a discoverable private room and notifies
+// the members who can approve it.
+func (s *Service) RequestToJoin(ctx context.Context, userID, roomID string, req CreateJoinRequestRequest) (*JoinRequest, error) {
+	targetRoom, err := s.requireActive(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if targetRoom.Type != PrivateRoom || !targetRoom.IsDiscoverable {
+		return nil, ErrJoinRequestNotAllowed
+	}
+	if err := s.requireWorkspaceMember(ctx, targetRoom.WorkspaceID, userID, ErrNotWorkspaceMember); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotBanned(ctx, roomID, userID, ErrBanned); err != nil {
+		return nil, err
+	}
+	if _, err := s.roomRepo.FindMembership(ctx, roomID, userID); err != ErrNotMember {
+		if err == nil {
+			return nil, ErrAlreadyInRoom
+		}
+		return nil, err
+	}
+	// A duplicate is turned away before the rate limit so it costs nothing.
+	pending, err := s.roomRepo.HasPendingJoinRequest(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrJoinRequestExists
+	}
+	if err := s.enforceJoinRequestLimit(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	requester, err := s.userProv.GetByIDShared(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load requester: %w", err)
+	}
+	joinRequest := NewJoinRequest(roomID, userID, req.Message)
+
+	channels, err := s.approverChannels(ctx, targetRoom)
+	if err != nil {
+		return nil, err
+	}
+	events, err := joinRequestEvents(websocket.EventJoinRequest, websocket.JoinRequestPayload{
+		RequestID: joinRequest.ID,
+		RoomID:    roomID,
+		RoomName:  targetRoom.Name,
+		UserID:    userID,
+		UserName:  requester.Name,
+		Message:   joinRequest.Message,
+	}, channels)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.roomRepo.CreateJoinRequest(ctx, joinRequest, events...); err != nil {
+		if err == ErrJoinRequestExists {
+			// A concurrent request won the insert; this one filed nothing.
+			s.refundJoinRequestLimit(ctx, userID)
+		}
+		return nil, err
+	}
 
-// ListPublicRooms retrieves all rooms that are open for any user to join.
-func (s *Service) ListPublicRooms(ctx context.Context) ([]*Room, error) {
-	return s.roomRepo.ListPublicRooms(ctx)
+	s.logger.Info("join request filed", "room_id", roomID, "request_id", joinRequest.ID, "user_id", userID)
+	return joinRequest, nil
 }
 
-// ListMembers retrieves the member list for a room, ensuring the requester is a member.
-func (s *Service) ListMembers(ctx context.Context, requesterID, roomID string) ([]*types.MemberDetail, error) {
-	// Authorization: Verify the user requesting the list is a member of the room.
-	if _, err := s.roomRepo.FindMembership(ctx, roomID, requesterID); err != nil {
-		return nil, err 
+// ListJoinRequests lists a room's pending join requests for members who can approve them.
+func (s *Service) ListJoinRequests(ctx context.Context, actorID, roomID string) ([]*JoinRequest, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.InviteMembers); err != nil {
+		return nil, err
 	}
-	return s.roomRepo.ListMembers(ctx, roomID)
+	return s.roomRepo.ListRoomJoinRequests(ctx, roomID)
 }
 
-// UpdateMemberRole changes the role of a user within a room.
-func (s *Service) UpdateMemberRole(ctx context.Context, actorID, roomID, targetUserID string, newRole MemberRole) error {
-	// 1. Authorize: Ensure the actor is an admin.
-	actorMembership, err := s.roomRepo.FindMembership(ctx, roomID, actorID)
-	if err != nil {
-		return err
+// ApproveJoinRequest lets the requester into the room as a regular member.
+func (s *Service) ApproveJoinRequest(ctx context.Context, actorID, roomID, requestID string) (*JoinRequest, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.InviteMembers); err != nil {
+		return nil, err
 	}
-	if actorMembership.Role != AdminRole {
-		return ErrNotAdmin
+	targetRoom, err := s.requireActive(ctx, roomID)
+	if err != nil {
+		return nil, err
 	}
-    
-    // Prevent an admin from demoting themselves if they are the last one.
-    if actorID == targetUserID && newRole != AdminRole {
-        adminCount, err := s.roomRepo.CountAdmins(ctx, roomID)
-        if err != nil {
-            return err
-        }
-        if adminCount <= 1 {
-            return errors.New("LAST_ADMIN", "You cannot demote yourself as the last admin.", 403)
-        }
-    }
-
-	// 2. Find the target user's membership to update.
-	targetMembership, err := s.roomRepo.FindMembership(ctx, roomID, targetUserID)
+	joinRequest, err := s.findRoomJoinRequest(ctx, roomID, requestID)
 	if err != nil {
-		return err 
+		return nil, err
 	}
+	if err := s.requireWorkspaceMember(ctx, targetRoom.WorkspaceID, joinRequest.UserID, ErrInviteeNotInWorkspace); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotBanned(ctx, roomID, joinRequest.UserID, ErrInviteeBanned); err != nil {
+		return nil, err
+	}
+	if err := s.resolveJoinRequest(ctx, joinRequest, JoinRequestApproved, &actorID); err != nil {
+		return nil, err
+	}
+	s.dispatchMemberEvent(ctx, types.WebhookMemberJoined, roomID, joinRequest.UserID, map[string]any{"via": "join_request", "actor_id": actorID})
 
-	// 3. Update and save.
-	targetMembership.Role = newRole
-	return s.roomRepo.UpdateMembership(ctx, targetMembership)
+	s.logger.Info("join request approved", "room_id", roomID, "request_id", requestID, "actor_id", actorID)
+	return joinRequest, nil
 }
 
-// RemoveMember kicks a user from a room.
-func (s *Service) RemoveMember(ctx context.Context, actorID, roomID, targetUserID string) error {
-	// 1. Authorize: Ensure the actor is an admin.
-	actorMembership, err := s.roomRepo.FindMembership(ctx, roomID, actorID)
+// DenyJoinRequest turns down a pending join request. The user may file a new one later.
+func (s *Service) DenyJoinRequest(ctx context.Context, actorID, roomID, requestID string) (*JoinRequest, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.InviteMembers); err != nil {
+		return nil, err
+	}
+	joinRequest, err := s.findRoomJoinRequest(ctx, roomID, requestID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if actorMembership.Role != AdminRole {
-		return ErrNotAdmin
+	if err := s.resolveJoinRequest(ctx, joinRequest, JoinRequestDenied, &actorID); err != nil {
+		return nil, err
 	}
-    
-    // An admin cannot kick themselves. They must use the LeaveRoom functionality.
-    if actorID == targetUserID {
-        return errors.New("CANNOT_KICK_SELF", "Admins cannot kick themselves. Please use the 'Leave Room' option.", 400)
-    }
 
-	// 2. Delete the target user's membership.
-	return s.roomRepo.DeleteMembership(ctx, roomID, targetUserID)
+	s.logger.Info("join request denied", "room_id", roomID, "request_id", requestID, "actor_id", actorID)
+	return joinRequest, nil
 }
 
-// LeaveRoom allows a user to remove themselves from a room.
-func (s *Service) LeaveRoom(ctx context.Context, userID, roomID string) error {
-	// 1. Check if user is the last admin.
-	membership, err := s.roomRepo.FindMembership(ctx, roomID, userID)
+// ListMyJoinRequests returns the join requests the user is still waiting on.
+func (s *Service) ListMyJoinRequests(ctx context.Context, userID string) ([]*JoinRequest, error) {
+	return s.roomRepo.ListUserJoinRequests(ctx, userID)
+}
+
+// CancelJoinRequest withdraws one of the user's own pending join requests.
+func (s *Service) CancelJoinRequest(ctx context.Context, userID, requestID string) (*JoinRequest, error) {
+	joinRequest, err := s.roomRepo.FindJoinRequestByID(ctx, requestID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if joinRequest.UserID != userID {
+		return nil, ErrJoinRequestNotFound
+	}
+	if err := s.resolveJoinRequest(ctx, joinRequest, JoinRequestCanceled, nil); err != nil {
+		return nil, err
 	}
 
-	if membership.Role == AdminRole {
-		adminCount, err := s.roomRepo.CountAdmins(ctx, roomID)
-		if err != nil {
-			return err
-		}
-		if adminCount <= 1 {
-			return errors.New("LAST_ADMIN_LEAVE", "You cannot leave as the last admin. Promote another member first.", 403)
+	s.logger.Info("join request canceled", "room_id", joinRequest.RoomID, "request_id", requestID, "user_id", userID)
+	return joinRequest, nil
+}
+
+// ListUserRooms retrieves a list of rooms the given user is a member of, with
+// their own settings for each. Archived rooms are only included when asked for.
+func (s *Service) ListUserRooms(ctx context.Context, userID string, filter UserRoomFilter) ([]*UserRoom, error) {
+	if filter.FolderID != nil && *filter.FolderID != "" {
+		if _, err := s.roomRepo.FindFolder(ctx, userID, *filter.FolderID); err != nil {
+			return nil, err
 		}
 	}
-
-	// 2. Delete the user's membership.
-	return s.roomRepo.DeleteMembership(ctx, roomID, userID)
+	return s.roomRepo.ListUserRooms(ctx, userID, filter)
 }
 
-func (s *Service) UpdateRoomSettings(ctx context.Context, actorID, roomID string, req UpdateRoomSettingsRequest) (*Room, error) {
-	// 1. Authorize: Ensure the actor is an admin.
-	actorMembership, err := s.roomRepo.FindMembership(ctx, roomID, actorID)
+// UpdateRoomPrefs changes how a room appears in the user's own room list and
+// syncs the change to their other devices.
+func (s *Service) UpdateRoomPrefs(ctx context.Context, userID, roomID string, req UpdateRoomPrefsRequest) (*types.RoomPrefs, error) {
+	prefs, err := s.roomRepo.GetRoomPrefs(ctx, roomID, userID)
 	if err != nil {
 		return nil, err
 	}
-	if actorMembership.Role != AdminRole {
-		return nil, ErrNotAdmin
+
+	if req.IsPinned != nil {
+		prefs.IsPinned = *req.IsPinned
+	}
+	if req.IsFavorite != nil {
+		prefs.IsFavorite = *req.IsFavorite
+	}
+	if req.IsHidden != nil {
+		switch {
+		case !*req.IsHidden:
+			prefs.HiddenAt = nil
+		case prefs.HiddenAt == nil:
+			now := time.Now()
+			prefs.HiddenAt = &now
+		}
+	}
+	if req.FolderID != nil {
+		if *req.FolderID == "" {
+			prefs.FolderID = nil
+		} else {
+			if _, err := s.roomRepo.FindFolder(ctx, userID, *req.FolderID); err != nil {
+				return nil, err
+			}
+			prefs.FolderID = req.FolderID
+		}
+	}
+	if req.Position != nil {
+		prefs.Position = req.Position
 	}
 
-	// 2. Fetch the current state of the room.
-	targetRoom, err := s.roomRepo.FindRoomByID(ctx, roomID)
+	event, err := roomPrefsChangedEvent(userID, websocket.NewRoomPrefsPayload(roomID, *prefs))
 	if err != nil {
 		return nil, err
 	}
+	if err := s.roomRepo.UpdateRoomPrefs(ctx, roomID, userID, prefs, event); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
 
-	// 3. Apply changes if they were provided in the request.
-	if req.IsBroadcastOnly != nil {
-		targetRoom.IsBroadcastOnly = *req.IsBroadcastOnly
+// ListFolders returns the user's room folders in their chosen order.
+func (s *Service) ListFolders(ctx context.Context, userID string) ([]*Folder, error) {
+	return s.roomRepo.ListFolders(ctx, userID)
+}
+
+// CreateFolder adds a folder at the end of the user's folder list.
+func (s *Service) CreateFolder(ctx context.Context, userID string, req CreateFolderRequest) (*Folder, error) {
+	folder := NewFolder(userID, req.Name)
+	buildEvent := func(f *Folder) (*contracts.OutboxEvent, error) {
+		return roomPrefsChangedEvent(userID, folderChangedPayload(f, false))
+	}
+	if err := s.roomRepo.CreateFolder(ctx, folder, buildEvent); err != nil {
+		return nil, err
 	}
+	return folder, nil
+}
 
-	// 4. Persist the changes.
-	if err := s.roomRepo.UpdateRoom(ctx, targetRoom); err != nil {
+// UpdateFolder renames or reorders one of the user's folders.
+func (s *Service) UpdateFolder(ctx context.Context, userID, folderID string, req UpdateFolderRequest) (*Folder, error) {
+	folder, err := s.roomRepo.FindFolder(ctx, userID, folderID)
+	if err != nil {
 		return nil, err
 	}
+	if req.Name != nil {
+		folder.Name = *req.Name
+	}
+	if req.Position != nil {
+		folder.Position = *req.Position
+	}
 
-	return targetRoom, nil
-}
\ No newline at end of file
+	event, err := roomPrefsChangedEvent(userID, folderChangedPayload(folder, false))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.roomRepo.UpdateFolder(ctx, folder, event); err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// DeleteFolder removes one of the user's folders. Its rooms stay in the user's
+// list, outside any folder.
+func (s *Service) DeleteFolder(ctx context.Context, userID, folderID string) error {
+	event, err := roomPrefsChangedEvent(userID, folderChangedPayload(&Folder{ID: folderID}, true))
+	if err != nil {
+		return err
+	}
+	return s.roomRepo.DeleteFolder(ctx, userID, folderID, event)
+}
+
+// UpdateNotifications replaces the user's notification settings for a room.
+func (s *Service) UpdateNotifications(ctx context.Context, userID, roomID string, req UpdateNotificationsRequest) (types.NotificationPrefs, error) {
+	if req.MutedUntil != nil && !req.MutedUntil.After(time.Now()) {
+		return types.NotificationPrefs{}, ErrInvalidMuteUntil
+	}
+	prefs := types.NotificationPrefs{Level: req.Level, MutedUntil: req.MutedUntil}
+	if err := s.roomRepo.UpdateNotificationPrefs(ctx, roomID, userID, prefs); err != nil {
+		return types.NotificationPrefs{}, err
+	}
+	return prefs, nil
+}
+
+// ListPublicRooms returns a page of the public room directory of the viewer's
+// workspaces, flagging the rooms the viewer has already joined.
+func (s *Service) ListPublicRooms(ctx context.Context, filter DirectoryFilter) (*response.CursorPage[*PublicRoomResponse], error) {
+	if filter.WorkspaceID != "" {
+		if err := s.requireWorkspaceMember(ctx, filter.WorkspaceID, filter.ViewerID, ErrNotWorkspaceMember); err != nil {
+			return nil, err
+		}
+	}
+	if filter.Sort == "" {
+		filter.Sort = SortByMembers
+	}
+	if !filter.Sort.IsValid() {
+		return nil, ErrInvalidDirectorySort
+	}
+	// A cursor only makes sense for the sort order it was issued under.
+	if filter.Cursor != nil && (filter.Cursor.Count != nil) != (filter.Sort == SortByMembers) {
+		return nil, ErrInvalidCursor
+	}
+
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	entries, err := s.roomRepo.ListPublicRooms(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*PublicRoomResponse, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, e.ToResponse())
+	}
+	return response.NewCursorPage(items, limit, func(r *PublicRoomResponse) response.Cursor {
+		switch filter.Sort {
+		case SortByMembers:
+			count := int64(r.MemberCount)
+			return response.Cursor{ID: r.ID, Count: &count}
+		case SortByActivity:
+			return response.Cursor{CreatedAt: r.LastActivityAt, ID: r.ID}
+		default:
+			return response.Cursor{CreatedAt: r.CreatedAt, ID: r.ID}
+		}
+	}), nil
+}
+
+// ListMembers retrieves the member list for a room, ensuring the requester is a member.
+func (s *Service) ListMembers(ctx context.Context, requesterID, roomID string) ([]*types.MemberDetail, error) {
+	// Authorization: Verify the user requesting the list is a member of the room.
+	if _, err := s.roomRepo.FindMembership(ctx, roomID, requesterID); err != nil {
+		return nil, err 
+	}
+	return s.roomRepo.ListMembers(ctx, roomID)
+}
+
+// UpdateMemberRole changes the role of a user within a room. Assigning OWNER
+// transfers ownership, which only the current owner can do.
+func (s *Service) UpdateMemberRole(ctx context.Context, actorID, roomID, targetUserID string, newRole MemberRole) error {
+	// 1. Authorize: Ensure the actor may manage roles.
+	actorMembership, err := s.authorize(ctx, roomID, actorID, authz.ManageRoles)
+	if err != nil {
+		return err
+	}
+
+	// 2. Find the target user's membership to update.
+	targetMembership, err := s.roomRepo.FindMembership(ctx, roomID, targetUserID)
+	if err != nil {
+		return err
+	}
+
+	if newRole == OwnerRole {
+		if actorMembership.Role != OwnerRole {
+			return authz.ErrRoleNotAssignable
+		}
+		if actorID == targetUserID {
+			return nil
+		}
+		if err := s.roomRepo.TransferOwnership(ctx, roomID, actorID, targetUserID); err != nil {
+			return err
+		}
+		s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionOwnershipTransferred, "user", targetUserID, nil).
+			WithChanges(map[string]any{"role": targetMembership.Role}, map[string]any{"role": OwnerRole}))
+		return nil
+	}
+
+	// 3. Check the actor outranks the target and may grant the new role. Members
+	// may lower their own role, but the owner has to transfer ownership instead.
+	actorRole := types.MemberRole(actorMembership.Role)
+	if actorID == targetUserID {
+		if actorMembership.Role == OwnerRole {
+			return ErrOwnerMustTransfer
+		}
+	} else if err := authz.RequireOutrank(actorRole, types.MemberRole(targetMembership.Role)); err != nil {
+		return err
+	}
+	if err := authz.RequireAssignable(actorRole, types.MemberRole(newRole)); err != nil {
+		return err
+	}
+
+	// 4. Update and save.
+	oldRole := targetMembership.Role
+	targetMembership.Role = newRole
+	if err := s.roomRepo.UpdateMembership(ctx, targetMembership); err != nil {
+		return err
+	}
+	if oldRole != newRole {
+		s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionMemberRoleChanged, "user", targetUserID, nil).
+			WithChanges(map[string]any{"role": oldRole}, map[string]any{"role": newRole}))
+	}
+	return nil
+}
+
+// RemoveMember kicks a user from a room.
+func (s *Service) RemoveMember(ctx context.Context, actorID, roomID, targetUserID string) error {
+	// 1. Authorize: Ensure the actor may kick members.
+	actorMembership, err := s.authorize(ctx, roomID, actorID, authz.KickMembers)
+	if err != nil {
+		return err
+	}
+
+	// A member cannot kick themselves. They must use the LeaveRoom functionality.
+	if actorID == targetUserID {
+		return errors.New("CANNOT_KICK_SELF", "You cannot kick yourself. Please use the 'Leave Room' option.", 400)
+	}
+
+	// 2. Only members ranked below the actor can be kicked.
+	targetMembership, err := s.roomRepo.FindMembership(ctx, roomID, targetUserID)
+	if err != nil {
+		return err
+	}
+	if err := authz.RequireOutrank(types.MemberRole(actorMembership.Role), types.MemberRole(targetMembership.Role)); err != nil {
+		return err
+	}
+
+	// 3. Delete the target user's membership.
+	return s.removeMember(ctx, actorID, targetMembership, "kicked")
+}
+
+// RemoveMemberAsAdmin removes a member on behalf of a system admin, who need
+// not belong to the room, as when a report against the member is upheld. The
+// owner can still only be removed by transferring ownership first.
+func (s *Service) RemoveMemberAsAdmin(ctx context.Context, actorID, roomID, targetUserID, reason string) error {
+	targetMembership, err := s.roomRepo.FindMembership(ctx, roomID, targetUserID)
+	if err != nil {
+		return err
+	}
+	if targetMembership.Role == OwnerRole {
+		return ErrCannotRemoveOwner
+	}
+	return s.removeMember(ctx, actorID, targetMembership, reason)
+}
+
+// BanMember bans a user from a room, removing them if they are a member. Unlike
+// a kick, the user cannot rejoin or be invited back until the ban expires or is lifted.
+func (s *Service) BanMember(ctx context.Context, actorID, roomID string, req BanMemberRequest) (*Ban, error) {
+	actorMembership, err := s.authorize(ctx, roomID, actorID, authz.KickMembers)
+	if err != nil {
+		return nil, err
+	}
+	if actorID == req.UserID {
+		return nil, errors.New("CANNOT_BAN_SELF", "You cannot ban yourself.", 400)
+	}
+
+	// Members can only be banned by someone who outranks them; non-members may be banned pre-emptively.
+	targetMembership, err := s.roomRepo.FindMembership(ctx, roomID, req.UserID)
+	switch {
+	case err == nil:
+		if err := authz.RequireOutrank(types.MemberRole(actorMembership.Role), types.MemberRole(targetMembership.Role)); err != nil {
+			return nil, err
+		}
+	case err == ErrNotMember:
+		exists, err := s.userProv.ExistsByID(ctx, req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check user existence: %w", err)
+		}
+		if !exists {
+			return nil, ErrUserNotFound
+		}
+	default:
+		return nil, err
+	}
+
+	ban := &Ban{
+		ID:       uuid.NewString(),
+		RoomID:   roomID,
+		UserID:   req.UserID,
+		BannedBy: &actorID,
+		Reason:   req.Reason,
+	}
+	if req.DurationHours != nil {
+		expiresAt := time.Now().Add(time.Duration(*req.DurationHours) * time.Hour)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	// The banned user hears about it on their own channel too, as they may not be watching the room.
+	payload := websocket.MemberSanctionPayload{RoomID: roomID, UserID: req.UserID, ActorID: actorID, Reason: req.Reason, ExpiresAt: ban.ExpiresAt}
+	roomEvent, err := memberSanctionEvent(websocket.EventMemberBanned, payload, websocket.RoomChannel(roomID))
+	if err != nil {
+		return nil, err
+	}
+	userEvent, err := memberSanctionEvent(websocket.EventMemberBanned, payload, "user:"+req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.roomRepo.BanMember(ctx, ban, roomEvent, userEvent); err != nil {
+		return nil, err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionMemberBanned, "user", req.UserID, map[string]any{
+		"reason":     req.Reason,
+		"expires_at": ban.ExpiresAt,
+	}))
+	if targetMembership != nil {
+		s.dispatchMemberEvent(ctx, types.WebhookMemberRemoved, roomID, req.UserID, map[string]any{"reason": "banned", "actor_id": actorID})
+	}
+	s.logger.Info("member banned", "room_id", roomID, "user_id", req.UserID, "actor_id", actorID, "expires_at", ban.ExpiresAt)
+	return ban, nil
+}
+
+// UnbanMember lifts a ban before it expires.
+func (s *Service) UnbanMember(ctx context.Context, actorID, roomID, userID string) error {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.KickMembers); err != nil {
+		return err
+	}
+
+	payload := websocket.MemberSanctionPayload{RoomID: roomID, UserID: userID, ActorID: actorID}
+	event, err := memberSanctionEvent(websocket.EventMemberUnbanned, payload, websocket.RoomChannel(roomID))
+	if err != nil {
+		return err
+	}
+	if err := s.roomRepo.UnbanMember(ctx, roomID, userID, event); err != nil {
+		return err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionMemberUnbanned, "user", userID, nil))
+	s.logger.Info("member unbanned", "room_id", roomID, "user_id", userID, "actor_id", actorID)
+	return nil
+}
+
+// ListBans lists the bans currently in force in a room.
+func (s *Service) ListBans(ctx context.Context, actorID, roomID string) ([]*Ban, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.KickMembers); err != nil {
+		return nil, err
+	}
+	return s.roomRepo.ListBans(ctx, roomID)
+}
+
+// MuteMember stops a member from sending messages for a while. They keep read access.
+func (s *Service) MuteMember(ctx context.Context, actorID, roomID, targetUserID string, req MuteMemberRequest) (time.Time, error) {
+	if err := s.authorizeAgainst(ctx, actorID, roomID, targetUserID, authz.MuteMembers); err != nil {
+		return time.Time{}, err
+	}
+
+	mutedUntil := time.Now().Add(time.Duration(req.DurationMinutes) * time.Minute)
+	payload := websocket.MemberSanctionPayload{RoomID: roomID, UserID: targetUserID, ActorID: actorID, Reason: req.Reason, ExpiresAt: &mutedUntil}
+	event, err := memberSanctionEvent(websocket.EventMemberMuted, payload, websocket.RoomChannel(roomID))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := s.roomRepo.SetMemberMute(ctx, roomID, targetUserID, &mutedUntil, event); err != nil {
+		return time.Time{}, err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionMemberMuted, "user", targetUserID, map[string]any{
+		"reason":      req.Reason,
+		"muted_until": mutedUntil,
+	}))
+	s.logger.Info("member muted", "room_id", roomID, "user_id", targetUserID, "actor_id", actorID, "muted_until", mutedUntil)
+	return mutedUntil, nil
+}
+
+// UnmuteMember lifts a mute before it runs out.
+func (s *Service) UnmuteMember(ctx context.Context, actorID, roomID, targetUserID string) error {
+	if err := s.authorizeAgainst(ctx, actorID, roomID, targetUserID, authz.MuteMembers); err != nil {
+		return err
+	}
+
+	payload := websocket.MemberSanctionPayload{RoomID: roomID, UserID: targetUserID, ActorID: actorID}
+	event, err := memberSanctionEvent(websocket.EventMemberUnmuted, payload, websocket.RoomChannel(roomID))
+	if err != nil {
+		return err
+	}
+	if err := s.roomRepo.SetMemberMute(ctx, roomID, targetUserID, nil, event); err != nil {
+		return err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionMemberUnmuted, "user", targetUserID, nil))
+	s.logger.Info("member unmuted", "room_id", roomID, "user_id", targetUserID, "actor_id", actorID)
+	return nil
+}
+
+// LeaveRoom allows a user to remove themselves from a room.
+func (s *Service) LeaveRoom(ctx context.Context, userID, roomID string) error {
+	// 1. The owner has to hand the room over before leaving it.
+	membership, err := s.roomRepo.FindMembership(ctx, roomID, userID)
+	if err != nil {
+		return err
+	}
+	if membership.Role == OwnerRole {
+		return ErrOwnerMustTransfer
+	}
+
+	// 2. Delete the user's membership.
+	if err := s.roomRepo.DeleteMembership(ctx, roomID, userID); err != nil {
+		return err
+	}
+	s.dispatchMemberEvent(ctx, types.WebhookMemberLeft, roomID, userID, nil)
+	return nil
+}
+
+func (s *Service) UpdateRoomSettings(ctx context.Context, actorID, roomID string, req UpdateRoomSettingsRequest) (*Room, error) {
+	// 1. Authorize: Ensure the actor may edit settings, and manage roles if member permissions change.
+	actorMembership, err := s.authorize(ctx, roomID, actorID, authz.EditSettings)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.MemberPermissions) > 0 {
+		if err := authz.Require(types.MemberRole(actorMembership.Role), nil, authz.ManageRoles); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Fetch the current state of the room; archived rooms must be unarchived first.
+	targetRoom, err := s.requireActive(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	before := settingsSnapshot(targetRoom)
+
+	// 3. Apply changes if they were provided in the request.
+	if req.IsBroadcastOnly != nil {
+		targetRoom.IsBroadcastOnly = *req.IsBroadcastOnly
+	}
+	if req.IsDiscoverable != nil {
+		if *req.IsDiscoverable && targetRoom.Type != PrivateRoom {
+			return nil, ErrNotDiscoverable
+		}
+		targetRoom.IsDiscoverable = *req.IsDiscoverable
+	}
+	if req.SlowModeSeconds != nil {
+		targetRoom.SlowModeSeconds = *req.SlowModeSeconds
+	}
+	if len(req.Moderation) > 0 {
+		if targetRoom.ModerationSettings == nil {
+			targetRoom.ModerationSettings = types.ModerationSettings{}
+		}
+		for hook, enabled := range req.Moderation {
+			targetRoom.ModerationSettings[hook] = enabled
+		}
+	}
+	if len(req.MemberPermissions) > 0 {
+		if targetRoom.MemberPermissions == nil {
+			targetRoom.MemberPermissions = types.PermissionOverrides{}
+		}
+		for perm, allowed := range req.MemberPermissions {
+			targetRoom.MemberPermissions[perm] = allowed
+		}
+	}
+
+	changes := applyDetailChanges(targetRoom, req)
+
+	// 4. Persist the changes, notifying subscribers if the room's details changed.
+	var event *contracts.OutboxEvent
+	if len(changes) > 0 {
+		if event, err = roomUpdatedEvent(targetRoom, req); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.roomRepo.UpdateRoom(ctx, targetRoom, event); err != nil {
+		return nil, err
+	}
+
+	// 5. Audit what changed and announce detail changes in the room itself.
+	if entry := audit.NewEntry(roomID, actorID, audit.ActionRoomSettingsUpdated, "room", roomID, nil).
+		WithChanges(before, settingsSnapshot(targetRoom)); entry.HasChanges() {
+		s.auditor.Record(ctx, entry)
+	}
+	if len(changes) > 0 {
+		s.announceChanges(ctx, actorID, roomID, changes)
+	}
+
+	return targetRoom, nil
+}
+
+// UploadAvatar queues a new room avatar for processing. The room is updated,
+// and ROOM_UPDATED sent, once the upload worker has processed the image.
+func (s *Service) UploadAvatar(ctx context.Context, actorID, roomID string, file multipart.File, header *multipart.FileHeader) (*upload.JobResponse, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.EditSettings); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireActive(ctx, roomID); err != nil {
+		return nil, err
+	}
+	return s.avatarUploader.InitiateRoomAvatarUpload(ctx, actorID, roomID, file, header)
+}
+
+// ArchiveRoom makes a room read-only. Members can still read its history, but it
+// takes no new messages or members until it is unarchived.
+func (s *Service) ArchiveRoom(ctx context.Context, actorID, roomID string) (*Room, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.EditSettings); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireActive(ctx, roomID); err != nil {
+		if err == ErrRoomArchived {
+			return nil, ErrAlreadyArchived
+		}
+		return nil, err
+	}
+
+	event, err := roomStateEvent(websocket.EventRoomArchived, roomID, actorID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.roomRepo.SetRoomArchived(ctx, roomID, true, event); err != nil {
+		return nil, err
+	}
+	// Subscribers leave the room's channels on ROOM_ARCHIVED, so the notice
+	// reaches them through the room's history rather than live.
+	s.announceChanges(ctx, actorID, roomID, []string{"archived the room"})
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionRoomArchived, "room", roomID, nil))
+	s.logger.Info("room archived", "room_id", roomID, "actor_id", actorID)
+	return s.roomRepo.FindRoomByID(ctx, roomID)
+}
+
+// UnarchiveRoom makes an archived room writable again.
+func (s *Service) UnarchiveRoom(ctx context.Context, actorID, roomID string) (*Room, error) {
+	if _, err := s.authorize(ctx, roomID, actorID, authz.EditSettings); err != nil {
+		return nil, err
+	}
+
+	event, err := roomStateEvent(websocket.EventRoomUnarchived, roomID, actorID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.roomRepo.SetRoomArchived(ctx, roomID, false, event); err != nil {
+		return nil, err
+	}
+	s.announceChanges(ctx, actorID, roomID, []string{"unarchived the room"})
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionRoomUnarchived, "room", roomID, nil))
+	s.logger.Info("room unarchived", "room_id", roomID, "actor_id", actorID)
+	return s.roomRepo.FindRoomByID(ctx, roomID)
+}
+
+// DeleteRoom soft-deletes a room on behalf of its owner and returns the time
+// after which it will be purged. Until then the owner can restore it.
+func (s *Service) DeleteRoom(ctx context.Context, actorID, roomID string) (time.Time, error) {
+	membership, err := s.roomRepo.FindMembership(ctx, roomID, actorID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if membership.Role != OwnerRole {
+		return time.Time{}, ErrNotOwner
+	}
+
+	deletedAt := time.Now()
+	purgeAfter := deletedAt.Add(s.config.Room.RestoreWindow)
+	event, err := roomStateEvent(websocket.EventRoomDeleted, roomID, actorID, &purgeAfter)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := s.roomRepo.SoftDeleteRoom(ctx, roomID, deletedAt, event); err != nil {
+		return time.Time{}, err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionRoomDeleted, "room", roomID, map[string]any{
+		"purge_after": purgeAfter,
+	}))
+	s.logger.Info("room deleted", "room_id", roomID, "actor_id", actorID, "purge_after", purgeAfter)
+	return purgeAfter, nil
+}
+
+// RestoreRoom brings back a room its owner deleted, as long as it has not been purged yet.
+func (s *Service) RestoreRoom(ctx context.Context, actorID, roomID string) (*Room, error) {
+	deletedAfter := time.Now().Add(-s.config.Room.RestoreWindow)
+	if err := s.roomRepo.RestoreRoom(ctx, roomID, actorID, deletedAfter); err != nil {
+		return nil, err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionRoomRestored, "room", roomID, nil))
+	s.logger.Info("room restored", "room_id", roomID, "actor_id", actorID)
+	return s.roomRepo.FindRoomByID(ctx, roomID)
+}
+
+// applyDetailChanges copies the requested name, description and topic onto
+// targetRoom and describes each one that actually changed.
+func applyDetailChanges(targetRoom *Room, req UpdateRoomSettingsRequest) []string {
+	var changes []string
+	if req.Name != nil && *req.Name != targetRoom.Name {
+		targetRoom.Name = *req.Name
+		changes = append(changes, fmt.Sprintf("renamed the room to %q", targetRoom.Name))
+	}
+	if req.Description != nil && *req.Description != targetRoom.Description {
+		targetRoom.Description = *req.Description
+		changes = append(changes, "updated the room description")
+	}
+	if req.Topic != nil && *req.Topic != targetRoom.Topic {
+		targetRoom.Topic = *req.Topic
+		if targetRoom.Topic == "" {
+			changes = append(changes, "cleared the topic")
+		} else {
+			changes = append(changes, fmt.Sprintf("set the topic to %q", targetRoom.Topic))
+		}
+	}
+	return changes
+}
+
+// settingsSnapshot captures the settings UpdateRoomSettings can change, for the
+// audit log's before and after values. Maps are copied because updates modify
+// them in place.
+func settingsSnapshot(r *Room) map[string]any {
+	return map[string]any{
+		"name":               r.Name,
+		"description":        r.Description,
+		"topic":              r.Topic,
+		"is_broadcast_only":  r.IsBroadcastOnly,
+		"is_discoverable":    r.IsDiscoverable,
+		"slow_mode_seconds":  r.SlowModeSeconds,
+		"moderation":         maps.Clone(r.ModerationSettings),
+		"member_permissions": maps.Clone(r.MemberPermissions),
+	}
+}
+
+// announceChanges posts a system message describing what the actor changed.
+// The update has already been saved, so failures are only logged.
+func (s *Service) announceChanges(ctx context.Context, actorID, roomID string, changes []string) {
+	actorName := "Someone"
+	if actor, err := s.userProv.GetByIDShared(ctx, actorID); err == nil {
+		actorName = actor.Name
+	}
+
+	notice := fmt.Sprintf("%s %s.", actorName, strings.Join(changes, " and "))
+	if _, err := s.messenger.PostSystemMessage(ctx, roomID, notice); err != nil {
+		s.logger.Error("failed to post room update notice", "error", err, "room_id", roomID)
+	}
+}
+
+// roomStateEvent builds a ROOM_ARCHIVED, ROOM_UNARCHIVED or ROOM_DELETED event for the room's channel.
+func roomStateEvent(eventType websocket.EventType, roomID, actorID string, purgeAfter *time.Time) (*contracts.OutboxEvent, error) {
+	event, err := websocket.NewEvent(eventType, websocket.RoomStatePayload{
+		RoomID:     roomID,
+		ActorID:    actorID,
+		PurgeAfter: purgeAfter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build room state event: %w", err)
+	}
+	return event.ToOutbox(websocket.RoomChannel(roomID))
+}
+
+// roomPrefsChangedEvent builds a ROOM_PREFS_CHANGED event for the user's own channel.
+func roomPrefsChangedEvent(userID string, payload websocket.RoomPrefsChangedPayload) (*contracts.OutboxEvent, error) {
+	event, err := websocket.NewEvent(websocket.EventRoomPrefsChanged, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build room prefs event: %w", err)
+	}
+	return event.ToOutbox(fmt.Sprintf("user:%s", userID))
+}
+
+func folderChangedPayload(folder *Folder, deleted bool) websocket.RoomPrefsChangedPayload {
+	return websocket.RoomPrefsChangedPayload{Folder: &websocket.FolderPayload{
+		FolderID: folder.ID,
+		Name:     folder.Name,
+		Position: folder.Position,
+		Deleted:  deleted,
+	}}
+}
+
+// memberSanctionEvent builds a ban or mute event for channel.
+func memberSanctionEvent(eventType websocket.EventType, payload websocket.MemberSanctionPayload, channel string) (*contracts.OutboxEvent, error) {
+	event, err := websocket.NewEvent(eventType, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build member sanction event: %w", err)
+	}
+	return event.ToOutbox(channel)
+}
+
+// roomUpdatedEvent builds the ROOM_UPDATED event carrying the details changed by req.
+func roomUpdatedEvent(targetRoom *Room, req UpdateRoomSettingsRequest) (*contracts.OutboxEvent, error) {
+	payload := websocket.RoomUpdatedPayload{RoomID: targetRoom.ID}
+	if req.Name != nil {
+		payload.Name = &targetRoom.Name
+	}
+	if req.Description != nil {
+		payload.Description = &targetRoom.Description
+	}
+	if req.Topic != nil {
+		payload.Topic = &targetRoom.Topic
+	}
+
+	event, err := websocket.NewEvent(websocket.EventRoomUpdated, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build room update event: %w", err)
+	}
+	return event.ToOutbox(websocket.RoomChannel(targetRoom.ID))
+}
+
+// authorize loads the user's membership and checks it grants perm. The room's
+// MEMBER overrides are only looked up when they can affect the outcome.
+func (s *Service) authorize(ctx context.Context, roomID, userID string, perm authz.Permission) (*RoomMembership, error) {
+	membership, err := s.roomRepo.FindMembership(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides types.PermissionOverrides
+	if membership.Role == RegularRole {
+		targetRoom, err := s.roomRepo.FindRoomByID(ctx, roomID)
+		if err != nil {
+			return nil, err
+		}
+		overrides = targetRoom.MemberPermissions
+	}
+
+	if err := authz.Require(types.MemberRole(membership.Role), overrides, perm); err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// findRoomJoinRequest loads a join request, treating one filed against another room as not found.
+func (s *Service) findRoomJoinRequest(ctx context.Context, roomID, requestID string) (*JoinRequest, error) {
+	joinRequest, err := s.roomRepo.FindJoinRequestByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if joinRequest.RoomID != roomID {
+		return nil, ErrJoinRequestNotFound
+	}
+	return joinRequest, nil
+}
+
+// resolveJoinRequest closes a join request, telling the requester and the
+// approvers, whose queues no longer need to show it.
+func (s *Service) resolveJoinRequest(ctx context.Context, joinRequest *JoinRequest, status JoinRequestStatus, actorID *string) error {
+	targetRoom, err := s.roomRepo.FindRoomByID(ctx, joinRequest.RoomID)
+	if err != nil {
+		return err
+	}
+	channels, err := s.approverChannels(ctx, targetRoom)
+	if err != nil {
+		return err
+	}
+	channels = append(channels, fmt.Sprintf("user:%s", joinRequest.UserID))
+
+	payload := websocket.JoinRequestResolvedPayload{
+		RequestID: joinRequest.ID,
+		RoomID:    joinRequest.RoomID,
+		UserID:    joinRequest.UserID,
+		Status:    string(status),
+	}
+	if actorID != nil {
+		payload.ActorID = *actorID
+	}
+	events, err := joinRequestEvents(websocket.EventJoinRequestResolved, payload, channels)
+	if err != nil {
+		return err
+	}
+	return s.roomRepo.ResolveJoinRequest(ctx, joinRequest, status, actorID, events...)
+}
+
+// approverChannels returns the user channels of the members who can approve
+// join requests, which are those allowed to invite.
+func (s *Service) approverChannels(ctx context.Context, targetRoom *Room) ([]string, error) {
+	members, err := s.roomRepo.ListMembers(ctx, targetRoom.ID)
+	if err != nil {
+		return nil, err
+	}
+	var channels []string
+	for _, member := range members {
+		if authz.Can(member.Role, targetRoom.MemberPermissions, authz.InviteMembers) {
+			channels = append(channels, fmt.Sprintf("user:%s", member.UserID))
+		}
+	}
+	return channels, nil
+}
+
+// joinRequestEvents builds one copy of a join request event per channel.
+func joinRequestEvents(eventType websocket.EventType, payload any, channels []string) ([]*contracts.OutboxEvent, error) {
+	events := make([]*contracts.OutboxEvent, 0, len(channels))
+	for _, channel := range channels {
+		event, err := websocket.NewEvent(eventType, payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build join request event: %w", err)
+		}
+		outboxEvent, err := event.ToOutbox(channel)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, outboxEvent)
+	}
+	return events, nil
+}
+
+// enforceJoinRequestLimit caps how many join requests a user can file per
+// window. Like the message limits, it fails open if the limiter is unavailable.
+func (s *Service) enforceJoinRequestLimit(ctx context.Context, userID string) error {
+	limits := s.config.Room
+	if limits.JoinRequestsPerWindow <= 0 {
+		return nil
+	}
+	allowed, retryAfter, err := s.limiter.Allow(ctx, joinRequestLimitKey(userID), limits.JoinRequestsPerWindow, limits.JoinRequestWindow)
+	if err != nil {
+		s.logger.Error("failed to check join request rate limit", "error", err, "user_id", userID)
+		return nil
+	}
+	if !allowed {
+		return NewJoinRequestLimitError(retryAfter)
+	}
+	return nil
+}
+
+// refundJoinRequestLimit gives back the rate limit hit of a request that was not filed.
+func (s *Service) refundJoinRequestLimit(ctx context.Context, userID string) {
+	if s.config.Room.JoinRequestsPerWindow <= 0 {
+		return
+	}
+	if err := s.limiter.Refund(ctx, joinRequestLimitKey(userID)); err != nil {
+		s.logger.Error("failed to refund join request rate limit", "error", err, "user_id", userID)
+	}
+}
+
+func joinRequestLimitKey(userID string) string {
+	return fmt.Sprintf("join_request:%s", userID)
+}
+
+// authorizeAgainst checks that actorID holds perm and outranks targetUserID, who must be a member.
+func (s *Service) authorizeAgainst(ctx context.Context, actorID, roomID, targetUserID string, perm authz.Permission) error {
+	actorMembership, err := s.authorize(ctx, roomID, actorID, perm)
+	if err != nil {
+		return err
+	}
+	targetMembership, err := s.roomRepo.FindMembership(ctx, roomID, targetUserID)
+	if err != nil {
+		return err
+	}
+	return authz.RequireOutrank(types.MemberRole(actorMembership.Role), types.MemberRole(targetMembership.Role))
+}
+
+// checkNotBanned returns banErr if userID is banned from the room.
+func (s *Service) checkNotBanned(ctx context.Context, roomID, userID string, banErr error) error {
+	banned, err := s.roomRepo.IsBanned(ctx, roomID, userID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return banErr
+	}
+	return nil
+}
+
+// requireWorkspaceMember returns notMemberErr unless userID belongs to the workspace.
+func (s *Service) requireWorkspaceMember(ctx context.Context, workspaceID, userID string, notMemberErr error) error {
+	isMember, err := s.workspaceProv.IsWorkspaceMember(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return notMemberErr
+	}
+	return nil
+}
+
+// removeMember deletes a membership and records who removed it and why.
+func (s *Service) removeMember(ctx context.Context, actorID string, membership *RoomMembership, reason string) error {
+	if err := s.roomRepo.DeleteMembership(ctx, membership.RoomID, membership.UserID); err != nil {
+		return err
+	}
+	s.auditor.Record(ctx, audit.NewEntry(membership.RoomID, actorID, audit.ActionMemberRemoved, "user", membership.UserID, map[string]any{
+		"role":   membership.Role,
+		"reason": reason,
+	}))
+	s.dispatchMemberEvent(ctx, types.WebhookMemberRemoved, membership.RoomID, membership.UserID, map[string]any{"reason": reason, "actor_id": actorID})
+	return nil
+}
+
+// dispatchMemberEvent tells the room's outgoing webhooks that userID joined or
+// left it; extra describes how.
+func (s *Service) dispatchMemberEvent(ctx context.Context, eventType types.WebhookEventType, roomID, userID string, extra map[string]any) {
+	data := map[string]any{"user_id": userID}
+	maps.Copy(data, extra)
+	s.webhooks.Dispatch(ctx, &types.WebhookEvent{Type: eventType, RoomID: roomID, Data: data})
+}
+
+// requireActive loads a room, rejecting changes to it while it is archived.
+func (s *Service) requireActive(ctx context.Context, roomID string) (*Room, error) {
+	targetRoom, err := s.roomRepo.FindRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if targetRoom.IsArchived() {
+		return nil, ErrRoomArchived
+	}
+	return targetRoom, nil
+}
+
+// checkNotBlocked refuses an invitation from a user the invitee has blocked.
+func (s *Service) checkNotBlocked(ctx context.Context, inviteeID, inviterID string) error {
+	blocked, err := s.userProv.HasBlocked(ctx, inviteeID, inviterID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrInviteBlocked
+	}
+	return nil
+}
+
+// findRoomInvitation loads an invitation, hiding invitations that belong to other rooms.
+func (s *Service) findRoomInvitation(ctx context.Context, roomID, invitationID string) (*Invitation, error) {
+	inv, err := s.roomRepo.FindInvitationByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.RoomID != roomID {
+		return nil, ErrInvitationNotFound
+	}
+	return inv, nil
+}
+
+// findOwnInvitation loads an invitation addressed to userID that can still be answered.
+func (s *Service) findOwnInvitation(ctx context.Context, userID, invitationID string) (*Invitation, error) {
+	inv, err := s.roomRepo.FindInvitationByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.InviteeID != userID {
+		return nil, ErrInvitationNotFound
+	}
+
+	switch inv.EffectiveStatus(time.Now()) {
+	case InvitationPending:
+		return inv, nil
+	case InvitationExpired:
+		return nil, ErrInvitationExpired
+	default:
+		return nil, ErrInvitationNotPending
+	}
+}
+
+// inviteReceivedEvent builds the INVITE_RECEIVED event for the invitee's own channel.
+func (s *Service) inviteReceivedEvent(ctx context.Context, inv *Invitation, inviterID string) (*contracts.OutboxEvent, error) {
+	targetRoom, err := s.roomRepo.FindRoomByID(ctx, inv.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	inviter, err := s.userProv.GetByIDShared(ctx, inviterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load inviter: %w", err)
+	}
+
+	event, err := websocket.NewEvent(websocket.EventInviteReceived, websocket.InviteReceivedPayload{
+		InvitationID: inv.ID,
+		RoomID:       inv.RoomID,
+		RoomName:     targetRoom.Name,
+		InviterID:    inviter.ID,
+		InviterName:  inviter.Name,
+		ExpiresAt:    inv.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build invite event: %w", err)
+	}
+	return event.ToOutbox(fmt.Sprintf("user:%s", inv.InviteeID))
+}
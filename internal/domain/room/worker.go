@@ -0,0 +1,155 @@
+package room
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/websocket"
+)
+
+// Worker periodically lifts bans and mutes that have run out, and purges
+// deleted rooms whose restore window has passed, removing their messages,
+// stored files and finally the room itself.
+type Worker struct {
+	roomRepo Repository
+	storage  contracts.FileStorage
+	config   *config.Config
+	logger   *slog.Logger
+}
+
+func NewWorker(
+	roomRepo Repository,
+	storage contracts.FileStorage,
+	config *config.Config,
+	logger *slog.Logger,
+) *Worker {
+	return &Worker{
+		roomRepo: roomRepo,
+		storage:  storage,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	w.logger.Info("starting room maintenance worker...", "interval", w.config.Room.PurgeInterval, "restore_window", w.config.Room.RestoreWindow)
+
+	ticker := time.NewTicker(w.config.Room.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("room maintenance worker shutting down")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) runOnce(ctx context.Context) {
+	w.expireSanctions(ctx)
+
+	deletedBefore := time.Now().Add(-w.config.Room.RestoreWindow)
+	roomIDs, err := w.roomRepo.ListRoomsToPurge(ctx, deletedBefore)
+	if err != nil {
+		w.logger.Error("failed to list rooms to purge", "error", err)
+		return
+	}
+
+	for _, roomID := range roomIDs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger := w.logger.With("room_id", roomID)
+		if err := w.purgeRoom(ctx, roomID, deletedBefore, logger); err != nil {
+			logger.Error("failed to purge room", "error", err)
+		}
+	}
+}
+
+// expireSanctions lifts lapsed bans and mutes, telling the room about each one.
+// Reads already ignore expired sanctions, so this only tidies up and notifies.
+func (w *Worker) expireSanctions(ctx context.Context) {
+	unbanned, err := w.roomRepo.ExpireBans(ctx, expiredSanctionEvent(websocket.EventMemberUnbanned))
+	if err != nil {
+		w.logger.Error("failed to expire bans", "error", err)
+	} else if unbanned > 0 {
+		w.logger.Info("expired bans", "count", unbanned)
+	}
+
+	unmuted, err := w.roomRepo.ExpireMutes(ctx, expiredSanctionEvent(websocket.EventMemberUnmuted))
+	if err != nil {
+		w.logger.Error("failed to expire mutes", "error", err)
+	} else if unmuted > 0 {
+		w.logger.Info("expired mutes", "count", unmuted)
+	}
+}
+
+// expiredSanctionEvent builds events for sanctions lifted by the worker, which have no actor.
+func expiredSanctionEvent(eventType websocket.EventType) MemberEventBuilder {
+	return func(roomID, userID string) (*contracts.OutboxEvent, error) {
+		payload := websocket.MemberSanctionPayload{RoomID: roomID, UserID: userID}
+		return memberSanctionEvent(eventType, payload, websocket.RoomChannel(roomID))
+	}
+}
+
+// purgeRoom deletes the room's messages in small batches so that no single
+// statement holds row locks for long, then its files, then the room row.
+// Messages carry no file attachments in this schema; the room's files are its
+// avatar and any history exports.
+func (w *Worker) purgeRoom(ctx context.Context, roomID string, deletedBefore time.Time, logger *slog.Logger) error {
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := w.roomRepo.PurgeMessagesBatch(ctx, roomID, deletedBefore, w.config.Room.PurgeBatchSize)
+		if err != nil {
+			return fmt.Errorf("purge stopped after %d messages: %w", total, err)
+		}
+		total += deleted
+		if deleted < int64(w.config.Room.PurgeBatchSize) {
+			break
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	exportKeys, err := w.roomRepo.ListExportFileKeys(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	avatarKey := fmt.Sprintf("%s/%s.webp", w.config.Upload.RoomAvatarPath, roomID)
+	for _, key := range append(exportKeys, avatarKey) {
+		if err := w.deleteFile(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	if err := w.roomRepo.PurgeRoom(ctx, roomID, deletedBefore); err != nil {
+		return err
+	}
+
+	logger.Info("purged deleted room", "deleted_messages", total, "deleted_files", len(exportKeys))
+	return nil
+}
+
+func (w *Worker) deleteFile(ctx context.Context, key string) error {
+	exists, err := w.storage.FileExists(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to check file %s: %w", key, err)
+	}
+	if !exists {
+		return nil
+	}
+	if err := w.storage.Delete(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete file %s: %w", key, err)
+	}
+	return nil
+}
@@ -1,11 +1,22 @@
 package upload
 
+// JobType identifies what an uploaded image is for.
+type JobType string
+
+const (
+	JobTypeProfileImage JobType = "PROFILE_IMAGE"
+	JobTypeRoomAvatar   JobType = "ROOM_AVATAR"
+)
+
 // ProfileUploadJob defines the data structure for an image processing job.
+// Jobs queued before Type existed have no type and are profile images.
 type ProfileUploadJob struct {
-	JobID        string `json:"job_id"`
-	UserID       string `json:"user_id"`
-	StagingKey   string `json:"staging_key"`
-	OriginalName string `json:"original_name"`
+	JobID        string  `json:"job_id"`
+	Type         JobType `json:"type,omitempty"`
+	UserID       string  `json:"user_id"`
+	RoomID       string  `json:"room_id,omitempty"` // Set for room avatar jobs
+	StagingKey   string  `json:"staging_key"`
+	OriginalName string  `json:"original_name"`
 }
 
 // JobResponse is the immediate response after initiating an upload.
@@ -1,8 +1,19 @@
 package upload
 
-import "context"
+import (
+	"context"
+
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+)
 
 // UserProfileUpdater defines the contract the upload worker needs from the user domain
 type UserProfileUpdater interface {
-	UpdateUserImageURL(ctx context.Context, userID, imageURL string) error
+	// UpdateUserImageURL saves the new image URL and stores event in the same transaction.
+	UpdateUserImageURL(ctx context.Context, userID, imageURL string, event *contracts.OutboxEvent) error
+}
+
+// RoomAvatarUpdater defines the contract the upload worker needs from the room domain
+type RoomAvatarUpdater interface {
+	// UpdateRoomAvatarURL saves the new avatar URL and stores event in the same transaction.
+	UpdateRoomAvatarURL(ctx context.Context, roomID, avatarURL string, event *contracts.OutboxEvent) error
 }
\ No newline at end of file
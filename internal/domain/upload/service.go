@@ -39,6 +39,24 @@ func NewService(
 }
 
 func (s *Service) InitiateProfileImageUpload(ctx context.Context, userID string, file multipart.File, header *multipart.FileHeader) (*JobResponse, error) {
+	return s.initiateUpload(ctx, ProfileUploadJob{
+		Type:   JobTypeProfileImage,
+		UserID: userID,
+	}, file, header)
+}
+
+// InitiateRoomAvatarUpload queues an avatar for a room. The caller is responsible
+// for checking that userID may change the room's settings.
+func (s *Service) InitiateRoomAvatarUpload(ctx context.Context, userID, roomID string, file multipart.File, header *multipart.FileHeader) (*JobResponse, error) {
+	return s.initiateUpload(ctx, ProfileUploadJob{
+		Type:   JobTypeRoomAvatar,
+		UserID: userID,
+		RoomID: roomID,
+	}, file, header)
+}
+
+// initiateUpload validates the image, stages it and queues job for the worker.
+func (s *Service) initiateUpload(ctx context.Context, job ProfileUploadJob, file multipart.File, header *multipart.FileHeader) (*JobResponse, error) {
 	if header.Size > s.config.Upload.MaxFileSize {
 		return nil, fmt.Errorf("file size exceeds %d bytes", s.config.Upload.MaxFileSize)
 	}
@@ -60,12 +78,9 @@ func (s *Service) InitiateProfileImageUpload(ctx context.Context, userID string,
 		return nil, fmt.Errorf("failed to upload to staging: %w", err)
 	}
 
-	job := ProfileUploadJob{
-		JobID:        jobID,
-		UserID:       userID,
-		StagingKey:   stagingKey,
-		OriginalName: header.Filename,
-	}
+	job.JobID = jobID
+	job.StagingKey = stagingKey
+	job.OriginalName = header.Filename
 
 	if err := s.queue.Enqueue(ctx, s.config.Upload.QueueName, job); err != nil {
 		s.logger.Error("failed to enqueue processing job", "error", err, "job_id", jobID)
@@ -3,7 +3,6 @@ package upload
 import (
 	"bytes"
 	"context"
-	"encoding/json" 
 	"fmt"
 	"log/slog"
 	"time"
@@ -18,39 +17,39 @@ type Worker struct {
 	queue       contracts.Queue
 	storage     contracts.FileStorage
 	userUpdater UserProfileUpdater
+	roomUpdater RoomAvatarUpdater
 	processor   *imageproc.Processor
 	config      *config.Config
 	logger      *slog.Logger
-	pubsub      contracts.PubSub 
 }
 
 func NewWorker(
 	queue       contracts.Queue,
 	storage     contracts.FileStorage,
 	userUpdater UserProfileUpdater,
+	roomUpdater RoomAvatarUpdater,
 	processor   *imageproc.Processor,
 	config      *config.Config,
 	logger      *slog.Logger,
-	pubsub      contracts.PubSub,
 ) *Worker {
 	return &Worker{
 		queue:       queue,
 		storage:     storage,
 		userUpdater: userUpdater,
+		roomUpdater: roomUpdater,
 		processor:   processor,
 		config:      config,
 		logger:      logger,
-		pubsub:      pubsub,
 	}
 }
 
 func (w *Worker) Start(ctx context.Context) {
-	w.logger.Info("starting profile upload worker...")
+	w.logger.Info("starting image upload worker...")
 
 	for {
 		select {
 		case <-ctx.Done():
-			w.logger.Info("profile upload worker shutting down")
+			w.logger.Info("image upload worker shutting down")
 			return
 		default:
 			w.processNextJob(ctx)
@@ -70,6 +69,9 @@ func (w *Worker) processNextJob(ctx context.Context) {
 	}
 
 	logger := w.logger.With("job_id", job.JobID, "user_id", job.UserID)
+	if job.RoomID != "" {
+		logger = logger.With("room_id", job.RoomID)
+	}
 	logger.Info("processing new upload job")
 
 	defer func() {
@@ -98,6 +100,10 @@ func (w *Worker) processUpload(ctx context.Context, job *ProfileUploadJob, logge
 		return fmt.Errorf("failed to process image: %w", err)
 	}
 
+	if job.Type == JobTypeRoomAvatar {
+		return w.saveRoomAvatar(ctx, job, processedImage, logger)
+	}
+
 	finalKey := fmt.Sprintf("%s/%s.webp", w.config.Upload.ProfileImagePath, job.UserID)
 
 	if err := w.storage.Upload(ctx, finalKey, processedImage.ContentType, bytes.NewReader(processedImage.Data), true); err != nil {
@@ -105,43 +111,60 @@ func (w *Worker) processUpload(ctx context.Context, job *ProfileUploadJob, logge
 	}
 
 	imageURL := w.storage.GetPublicURL(finalKey)
-	if err := w.userUpdater.UpdateUserImageURL(ctx, job.UserID, imageURL); err != nil {
+
+	// The notification is stored with the update and relayed to Redis by the outbox worker.
+	event, err := profileUpdatedEvent(job.UserID, imageURL)
+	if err != nil {
+		return fmt.Errorf("failed to build profile update event: %w", err)
+	}
+	if err := w.userUpdater.UpdateUserImageURL(ctx, job.UserID, imageURL, event); err != nil {
 		return fmt.Errorf("failed to update user record: %w", err)
 	}
 
-	// Publish the notification event to Redis.
-	w.publishProfileUpdate(job.UserID, imageURL)
-
 	logger.Info("user profile image updated", "url", imageURL)
 	return nil
 }
 
-func (w *Worker) publishProfileUpdate(userID, newImageURL string) {
-	payload := websocket.ProfileUpdatedPayload{
-		NewImageURL: newImageURL,
+func (w *Worker) saveRoomAvatar(ctx context.Context, job *ProfileUploadJob, processedImage *imageproc.ProcessedImage, logger *slog.Logger) error {
+	finalKey := fmt.Sprintf("%s/%s.webp", w.config.Upload.RoomAvatarPath, job.RoomID)
+
+	if err := w.storage.Upload(ctx, finalKey, processedImage.ContentType, bytes.NewReader(processedImage.Data), true); err != nil {
+		return fmt.Errorf("failed to upload final avatar: %w", err)
 	}
 
-	payloadBytes, err := json.Marshal(payload)
+	avatarURL := w.storage.GetPublicURL(finalKey)
+
+	event, err := roomAvatarUpdatedEvent(job.RoomID, avatarURL)
 	if err != nil {
-		w.logger.Error("failed to marshal profile update payload", "error", err, "user_id", userID)
-		return
+		return fmt.Errorf("failed to build room update event: %w", err)
 	}
-
-	event := websocket.Event{
-		Type:    websocket.EventProfileUpdated,
-		Payload: payloadBytes,
+	if err := w.roomUpdater.UpdateRoomAvatarURL(ctx, job.RoomID, avatarURL, event); err != nil {
+		return fmt.Errorf("failed to update room record: %w", err)
 	}
 
-	eventBytes, err := json.Marshal(event)
+	logger.Info("room avatar updated", "url", avatarURL)
+	return nil
+}
+
+// roomAvatarUpdatedEvent builds the ROOM_UPDATED event for the room's channel.
+func roomAvatarUpdatedEvent(roomID, avatarURL string) (*contracts.OutboxEvent, error) {
+	event, err := websocket.NewEvent(websocket.EventRoomUpdated, websocket.RoomUpdatedPayload{
+		RoomID:    roomID,
+		AvatarURL: &avatarURL,
+	})
 	if err != nil {
-		w.logger.Error("failed to marshal profile update event", "error", err, "user_id", userID)
-		return
+		return nil, err
 	}
+	return event.ToOutbox(websocket.RoomChannel(roomID))
+}
 
-	channel := fmt.Sprintf("user:%s", userID)
-	if err := w.pubsub.Publish(context.Background(), channel, string(eventBytes)); err != nil {
-		w.logger.Error("failed to publish profile update event", "error", err, "user_id", userID, "channel", channel)
-	} else {
-		w.logger.Info("published profile update event", "user_id", userID, "channel", channel)
+// profileUpdatedEvent builds the PROFILE_UPDATED event for the user's own channel.
+func profileUpdatedEvent(userID, newImageURL string) (*contracts.OutboxEvent, error) {
+	event, err := websocket.NewEvent(websocket.EventProfileUpdated, websocket.ProfileUpdatedPayload{
+		NewImageURL: newImageURL,
+	})
+	if err != nil {
+		return nil, err
 	}
-}
\ No newline at end of file
+	return event.ToOutbox(fmt.Sprintf("user:%s", userID))
+}
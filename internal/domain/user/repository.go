@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 
+	"github.com/purushothdl/gochat-backend/internal/contracts"
 	"github.com/purushothdl/gochat-backend/internal/shared/types"
 )
 
@@ -13,7 +14,7 @@ type Repository interface {
     GetByEmail(ctx context.Context, email string) (*User, error)
     Update(ctx context.Context, user *User) error
     Delete(ctx context.Context, id string) error
-    UpdateUserImageURL(ctx context.Context, userID string, imageURL string) error
+    UpdateUserImageURL(ctx context.Context, userID string, imageURL string, event *contracts.OutboxEvent) error
 
     // User Block Methods
 	BlockUser(ctx context.Context, blockerID, blockedID string) error
@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// Dispatcher turns room events into deliveries for the outgoing webhooks that
+// subscribe to them. It is kept apart from Service so that the message and
+// room services can use it without depending on the webhook service, which
+// itself posts through the message service.
+type Dispatcher struct {
+	webhookRepo Repository
+	queue       contracts.Queue
+	config      *config.Config
+	logger      *slog.Logger
+}
+
+func NewDispatcher(webhookRepo Repository, queue contracts.Queue, cfg *config.Config, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		webhookRepo: webhookRepo,
+		queue:       queue,
+		config:      cfg,
+		logger:      logger,
+	}
+}
+
+// envelope is the JSON body delivered to outgoing webhooks.
+type envelope struct {
+	ID        string                 `json:"id"` // Shared by every delivery of the same event
+	Type      types.WebhookEventType `json:"type"`
+	RoomID    string                 `json:"room_id,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	Data      map[string]any         `json:"data"`
+}
+
+// Dispatch stores a delivery of the event for every subscribed webhook of the
+// room and its workspace, and queues them for the delivery worker. Like audit
+// entries, webhook deliveries never fail the action that caused them, so
+// errors are only logged.
+func (d *Dispatcher) Dispatch(ctx context.Context, event *types.WebhookEvent) {
+	hooks, err := d.webhookRepo.ListSubscribedWebhooks(ctx, event.RoomID, event.Type)
+	if err != nil {
+		d.logger.Error("failed to find subscribed webhooks", "error", err, "event", event.Type, "room_id", event.RoomID)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	eventID, payload, err := newEventPayload(event)
+	if err != nil {
+		d.logger.Error("failed to encode webhook event", "error", err, "event", event.Type)
+		return
+	}
+
+	deliveries := make([]*Delivery, 0, len(hooks))
+	for _, hook := range hooks {
+		deliveries = append(deliveries, newDelivery(hook.ID, eventID, event.Type, payload))
+	}
+	if err := d.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		d.logger.Error("failed to store webhook deliveries", "error", err, "event", event.Type, "room_id", event.RoomID)
+		return
+	}
+	for _, delivery := range deliveries {
+		d.enqueue(ctx, delivery.ID)
+	}
+}
+
+// DispatchTo delivers an event to a single webhook, whether or not it
+// subscribes to the event's type. Unlike Dispatch it reports failures, as the
+// caller is waiting on the webhook's answer.
+func (d *Dispatcher) DispatchTo(ctx context.Context, webhookID string, event *types.WebhookEvent) error {
+	eventID, payload, err := newEventPayload(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	delivery := newDelivery(webhookID, eventID, event.Type, payload)
+	if err := d.webhookRepo.CreateDeliveries(ctx, []*Delivery{delivery}); err != nil {
+		return err
+	}
+	d.enqueue(ctx, delivery.ID)
+	return nil
+}
+
+// enqueue hands a delivery to the worker. A failure is only logged: the
+// worker's scheduler queues the delivery again once the lease has passed.
+func (d *Dispatcher) enqueue(ctx context.Context, deliveryID string) {
+	if err := d.queue.Enqueue(ctx, d.config.Webhook.DeliveryQueueName, DeliveryJob{DeliveryID: deliveryID}); err != nil {
+		d.logger.Error("failed to queue webhook delivery", "error", err, "delivery_id", deliveryID)
+	}
+}
+
+func newDelivery(webhookID, eventID string, eventType types.WebhookEventType, payload []byte) *Delivery {
+	return &Delivery{
+		ID:        uuid.NewString(),
+		WebhookID: webhookID,
+		EventID:   eventID,
+		EventType: eventType,
+		Payload:   payload,
+		Status:    DeliveryPending,
+	}
+}
+
+// newEventPayload builds the body delivered for an event, with the id shared
+// by all of its deliveries.
+func newEventPayload(event *types.WebhookEvent) (string, []byte, error) {
+	eventID := uuid.NewString()
+	payload, err := json.Marshal(&envelope{
+		ID:        eventID,
+		Type:      event.Type,
+		RoomID:    event.RoomID,
+		CreatedAt: time.Now().UTC(),
+		Data:      event.Data,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return eventID, payload, nil
+}
+
+// newPingPayload builds the body of a ping, which is delivered like any other
+// event but only to the webhook being pinged.
+func newPingPayload(hook *OutgoingWebhook) (string, []byte, error) {
+	eventID := uuid.NewString()
+	payload, err := json.Marshal(&envelope{
+		ID:        eventID,
+		Type:      PingEvent,
+		CreatedAt: time.Now().UTC(),
+		Data:      map[string]any{"webhook_id": hook.ID},
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode ping: %w", err)
+	}
+	return eventID, payload, nil
+}
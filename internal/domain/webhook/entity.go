@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// IncomingWebhook lets an external service post messages into a room through
+// a secret URL. Only a hash of the URL's token is stored.
+type IncomingWebhook struct {
+	ID         string
+	RoomID     string
+	Name       string // Default sender name for the webhook's messages
+	AvatarURL  string // Default sender avatar; may be empty
+	TokenHash  string
+	CreatedBy  *string
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewIncomingWebhook creates a webhook for a room with the hash of its token.
+func NewIncomingWebhook(roomID, createdBy, name, avatarURL, tokenHash string) *IncomingWebhook {
+	return &IncomingWebhook{
+		ID:        uuid.NewString(),
+		RoomID:    roomID,
+		Name:      name,
+		AvatarURL: avatarURL,
+		TokenHash: tokenHash,
+		CreatedBy: &createdBy,
+	}
+}
+
+// OutgoingWebhook delivers the events it subscribes to, signed with its secret,
+// to an HTTPS endpoint. It belongs to either a room or a workspace; a
+// workspace's webhooks receive the events of all of its rooms.
+type OutgoingWebhook struct {
+	ID          string
+	RoomID      *string
+	WorkspaceID *string
+	URL         string
+	Secret      string
+	Events      []types.WebhookEventType
+	IsActive    bool
+	CreatedBy   *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewOutgoingWebhook creates an active webhook for a room or, when roomID is
+// empty, for a workspace.
+func NewOutgoingWebhook(roomID, workspaceID, createdBy, url, secret string, events []types.WebhookEventType) *OutgoingWebhook {
+	hook := &OutgoingWebhook{
+		ID:        uuid.NewString(),
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		IsActive:  true,
+		CreatedBy: &createdBy,
+	}
+	if roomID != "" {
+		hook.RoomID = &roomID
+	} else {
+		hook.WorkspaceID = &workspaceID
+	}
+	return hook
+}
+
+// Subscribes reports whether the webhook receives events of the given type.
+func (h *OutgoingWebhook) Subscribes(eventType types.WebhookEventType) bool {
+	return slices.Contains(h.Events, eventType)
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliverySucceeded DeliveryStatus = "SUCCEEDED"
+	DeliveryFailed    DeliveryStatus = "FAILED" // Out of attempts; the dead-letter list
+)
+
+// PingEvent is sent by the ping endpoint to check that a webhook's receiver
+// is reachable. Webhooks cannot subscribe to it.
+const PingEvent types.WebhookEventType = "ping"
+
+// Delivery is one event on its way to one outgoing webhook. Payload holds the
+// exact JSON body that is signed and sent on every attempt.
+type Delivery struct {
+	ID             string
+	WebhookID      string
+	EventID        string
+	EventType      types.WebhookEventType
+	Payload        []byte
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// DeliveryAttempt records one HTTP request made for a delivery.
+type DeliveryAttempt struct {
+	ID           string
+	DeliveryID   string
+	Attempt      int
+	StatusCode   *int // Nil when no response was received
+	Error        *string
+	ResponseBody string
+	Duration     time.Duration
+	CreatedAt    time.Time
+}
+
+// DeliveryFilter narrows and pages a webhook's delivery log.
+type DeliveryFilter struct {
+	WebhookID string
+	Status    DeliveryStatus // Empty for every status
+	Cursor    *response.Cursor
+	Limit     int
+}
+
+// DeliveryJob is queued for the delivery worker to make the next attempt at a delivery.
+type DeliveryJob struct {
+	DeliveryID string `json:"delivery_id"`
+}
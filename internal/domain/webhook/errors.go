@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("WEBHOOK_NOT_FOUND", "The requested webhook was not found", 404)
+	ErrInvalidToken     = errors.New("INVALID_WEBHOOK_TOKEN", "This webhook URL is not valid", 404)
+	ErrInvalidAvatarURL = errors.New("INVALID_AVATAR_URL", "The avatar URL must be an absolute http or https URL", 400)
+
+	ErrInvalidTargetURL   = errors.New("INVALID_WEBHOOK_URL", "The webhook URL must be an absolute https URL", 400)
+	ErrInvalidEvent       = errors.New("INVALID_WEBHOOK_EVENT", "The webhook subscribes to an unknown event", 400)
+	ErrDeliveryNotFound   = errors.New("DELIVERY_NOT_FOUND", "The requested delivery was not found", 404)
+	ErrDeliveryInProgress = errors.New("DELIVERY_IN_PROGRESS", "The delivery is still being attempted", 409)
+	ErrInvalidStatus      = errors.New("INVALID_DELIVERY_STATUS", "The status must be PENDING, SUCCEEDED or FAILED", 400)
+)
+
+// NewRateLimitedError reports how long a webhook must wait before posting again.
+func NewRateLimitedError(wait time.Duration) *errors.AppError {
+	secs := max(1, int(math.Ceil(wait.Seconds())))
+	return errors.New("WEBHOOK_RATE_LIMITED", fmt.Sprintf("This webhook is posting too quickly. Try again in %d seconds.", secs), 429).
+		WithDetails(map[string]any{"retry_after_seconds": secs})
+}
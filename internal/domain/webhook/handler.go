@@ -0,0 +1,395 @@
+package webhook
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/validator"
+	authMiddleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+type Handler struct {
+	service   *Service
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewHandler(service *Service, logger *slog.Logger, v *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		logger:    logger,
+		validator: v,
+	}
+}
+
+// CreateIncomingWebhook handles POST /api/rooms/{room_id}/webhooks
+func (h *Handler) CreateIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req CreateIncomingWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	hook, err := h.service.CreateIncomingWebhook(r.Context(), actorID, roomID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, hook)
+}
+
+// ListIncomingWebhooks handles GET /api/rooms/{room_id}/webhooks
+func (h *Handler) ListIncomingWebhooks(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	hooks, err := h.service.ListIncomingWebhooks(r.Context(), actorID, chi.URLParam(r, "room_id"))
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, hooks)
+}
+
+// UpdateIncomingWebhook handles PATCH /api/rooms/{room_id}/webhooks/{webhook_id}
+func (h *Handler) UpdateIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+	webhookID := chi.URLParam(r, "webhook_id")
+
+	var req UpdateIncomingWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	hook, err := h.service.UpdateIncomingWebhook(r.Context(), actorID, roomID, webhookID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, hook)
+}
+
+// DeleteIncomingWebhook handles DELETE /api/rooms/{room_id}/webhooks/{webhook_id}
+func (h *Handler) DeleteIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	err := h.service.DeleteIncomingWebhook(r.Context(), actorID, chi.URLParam(r, "room_id"), chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ExecuteIncomingWebhook handles POST /api/hooks/{token}. It is unauthenticated;
+// the token in the URL is the credential.
+func (h *Handler) ExecuteIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	var req ExecuteIncomingWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	result, err := h.service.ExecuteIncomingWebhook(r.Context(), chi.URLParam(r, "token"), req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, result)
+}
+
+// CreateRoomOutgoingWebhook handles POST /api/rooms/{room_id}/outgoing-webhooks
+func (h *Handler) CreateRoomOutgoingWebhook(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	roomID := chi.URLParam(r, "room_id")
+
+	var req CreateOutgoingWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	hook, err := h.service.CreateRoomOutgoingWebhook(r.Context(), actorID, roomID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, hook)
+}
+
+// CreateWorkspaceOutgoingWebhook handles POST /api/workspaces/{workspace_id}/outgoing-webhooks
+func (h *Handler) CreateWorkspaceOutgoingWebhook(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	workspaceID := chi.URLParam(r, "workspace_id")
+
+	var req CreateOutgoingWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	hook, err := h.service.CreateWorkspaceOutgoingWebhook(r.Context(), actorID, workspaceID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, hook)
+}
+
+// ListRoomOutgoingWebhooks handles GET /api/rooms/{room_id}/outgoing-webhooks
+func (h *Handler) ListRoomOutgoingWebhooks(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	hooks, err := h.service.ListRoomOutgoingWebhooks(r.Context(), actorID, chi.URLParam(r, "room_id"))
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, hooks)
+}
+
+// ListWorkspaceOutgoingWebhooks handles GET /api/workspaces/{workspace_id}/outgoing-webhooks
+func (h *Handler) ListWorkspaceOutgoingWebhooks(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	hooks, err := h.service.ListWorkspaceOutgoingWebhooks(r.Context(), actorID, chi.URLParam(r, "workspace_id"))
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, hooks)
+}
+
+// GetOutgoingWebhook handles GET /api/outgoing-webhooks/{webhook_id}
+func (h *Handler) GetOutgoingWebhook(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	hook, err := h.service.GetOutgoingWebhook(r.Context(), actorID, chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, hook)
+}
+
+// UpdateOutgoingWebhook handles PATCH /api/outgoing-webhooks/{webhook_id}
+func (h *Handler) UpdateOutgoingWebhook(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	var req UpdateOutgoingWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	hook, err := h.service.UpdateOutgoingWebhook(r.Context(), actorID, chi.URLParam(r, "webhook_id"), req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, hook)
+}
+
+// DeleteOutgoingWebhook handles DELETE /api/outgoing-webhooks/{webhook_id}
+func (h *Handler) DeleteOutgoingWebhook(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.service.DeleteOutgoingWebhook(r.Context(), actorID, chi.URLParam(r, "webhook_id")); err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RotateOutgoingWebhookSecret handles POST /api/outgoing-webhooks/{webhook_id}/secret
+func (h *Handler) RotateOutgoingWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	hook, err := h.service.RotateOutgoingWebhookSecret(r.Context(), actorID, chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, hook)
+}
+
+// PingOutgoingWebhook handles POST /api/outgoing-webhooks/{webhook_id}/ping
+func (h *Handler) PingOutgoingWebhook(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	delivery, err := h.service.PingOutgoingWebhook(r.Context(), actorID, chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusAccepted, delivery)
+}
+
+// ListDeliveries handles GET /api/outgoing-webhooks/{webhook_id}/deliveries
+func (h *Handler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	cursor, err := response.DecodeCursor(query.Get("cursor"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	filter := DeliveryFilter{
+		WebhookID: chi.URLParam(r, "webhook_id"),
+		Status:    DeliveryStatus(strings.ToUpper(query.Get("status"))),
+		Cursor:    cursor,
+		Limit:     response.ParseLimit(r, 25, 100),
+	}
+	switch filter.Status {
+	case "", DeliveryPending, DeliverySucceeded, DeliveryFailed:
+	default:
+		response.Error(w, 0, ErrInvalidStatus)
+		return
+	}
+
+	page, err := h.service.ListDeliveries(r.Context(), actorID, filter)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, page)
+}
+
+// GetDelivery handles GET /api/outgoing-webhooks/{webhook_id}/deliveries/{delivery_id}
+func (h *Handler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	delivery, err := h.service.GetDelivery(r.Context(), actorID, chi.URLParam(r, "webhook_id"), chi.URLParam(r, "delivery_id"))
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, delivery)
+}
+
+// RedeliverDelivery handles POST /api/outgoing-webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver
+func (h *Handler) RedeliverDelivery(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), actorID, chi.URLParam(r, "webhook_id"), chi.URLParam(r, "delivery_id"))
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusAccepted, delivery)
+}
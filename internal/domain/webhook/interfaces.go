@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/domain/workspace"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// RoomProvider defines the methods the webhook service needs about rooms.
+type RoomProvider interface {
+	GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error)
+}
+
+// MessagePoster posts webhook payloads into rooms through the message pipeline.
+type MessagePoster interface {
+	PostWebhookMessage(ctx context.Context, post message.WebhookPost) (*message.Message, error)
+}
+
+// AuditRecorder records webhooks being created, changed and deleted.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *audit.Entry)
+}
+
+// WorkspaceProvider looks up workspace roles for workspace-wide webhooks.
+type WorkspaceProvider interface {
+	FindMember(ctx context.Context, workspaceID, userID string) (*workspace.Member, error)
+}
@@ -0,0 +1,338 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/domain/workspace"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+	"github.com/purushothdl/gochat-backend/pkg/utils/tokenutil"
+)
+
+// CreateRoomOutgoingWebhook registers an endpoint for a room's events. The
+// returned secret signs every delivery and is only shown here and on rotation.
+func (s *Service) CreateRoomOutgoingWebhook(ctx context.Context, actorID, roomID string, req CreateOutgoingWebhookRequest) (*OutgoingWebhookResponse, error) {
+	if err := s.authorize(ctx, roomID, actorID); err != nil {
+		return nil, err
+	}
+	return s.createOutgoingWebhook(ctx, actorID, roomID, "", req)
+}
+
+// CreateWorkspaceOutgoingWebhook registers an endpoint for the events of every
+// room in a workspace. Only workspace admins may do so.
+func (s *Service) CreateWorkspaceOutgoingWebhook(ctx context.Context, actorID, workspaceID string, req CreateOutgoingWebhookRequest) (*OutgoingWebhookResponse, error) {
+	if err := s.authorizeWorkspace(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+	return s.createOutgoingWebhook(ctx, actorID, "", workspaceID, req)
+}
+
+func (s *Service) createOutgoingWebhook(ctx context.Context, actorID, roomID, workspaceID string, req CreateOutgoingWebhookRequest) (*OutgoingWebhookResponse, error) {
+	if err := s.validateTargetURL(req.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalizeEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+	secret, _, err := tokenutil.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	hook := NewOutgoingWebhook(roomID, workspaceID, actorID, req.URL, secret, events)
+	if err := s.webhookRepo.CreateOutgoingWebhook(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionWebhookCreated, "outgoing_webhook", hook.ID, map[string]any{
+		"url":          hook.URL,
+		"events":       hook.Events,
+		"workspace_id": hook.WorkspaceID,
+	}))
+	s.logger.Info("outgoing webhook created", "webhook_id", hook.ID, "room_id", roomID, "workspace_id", workspaceID, "actor_id", actorID)
+	return toOutgoingWebhookResponse(hook, secret), nil
+}
+
+// ListRoomOutgoingWebhooks lists a room's own outgoing webhooks, without
+// those it inherits from its workspace. Secrets are never returned.
+func (s *Service) ListRoomOutgoingWebhooks(ctx context.Context, actorID, roomID string) ([]*OutgoingWebhookResponse, error) {
+	if err := s.authorize(ctx, roomID, actorID); err != nil {
+		return nil, err
+	}
+	hooks, err := s.webhookRepo.ListRoomOutgoingWebhooks(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return toOutgoingWebhookResponses(hooks), nil
+}
+
+// ListWorkspaceOutgoingWebhooks lists a workspace's outgoing webhooks.
+func (s *Service) ListWorkspaceOutgoingWebhooks(ctx context.Context, actorID, workspaceID string) ([]*OutgoingWebhookResponse, error) {
+	if err := s.authorizeWorkspace(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+	hooks, err := s.webhookRepo.ListWorkspaceOutgoingWebhooks(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return toOutgoingWebhookResponses(hooks), nil
+}
+
+func (s *Service) GetOutgoingWebhook(ctx context.Context, actorID, webhookID string) (*OutgoingWebhookResponse, error) {
+	hook, err := s.findOutgoingWebhook(ctx, actorID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return toOutgoingWebhookResponse(hook, ""), nil
+}
+
+// UpdateOutgoingWebhook changes a webhook's URL or events, or pauses and
+// resumes it. Deliveries of a paused webhook are dead-lettered when they
+// come up for their next attempt.
+func (s *Service) UpdateOutgoingWebhook(ctx context.Context, actorID, webhookID string, req UpdateOutgoingWebhookRequest) (*OutgoingWebhookResponse, error) {
+	hook, err := s.findOutgoingWebhook(ctx, actorID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := outgoingSnapshot(hook)
+	if req.URL != nil {
+		if err := s.validateTargetURL(*req.URL); err != nil {
+			return nil, err
+		}
+		hook.URL = *req.URL
+	}
+	if req.Events != nil {
+		events, err := normalizeEvents(req.Events)
+		if err != nil {
+			return nil, err
+		}
+		hook.Events = events
+	}
+	if req.IsActive != nil {
+		hook.IsActive = *req.IsActive
+	}
+	if err := s.webhookRepo.UpdateOutgoingWebhook(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	if entry := audit.NewEntry(derefString(hook.RoomID), actorID, audit.ActionWebhookUpdated, "outgoing_webhook", hook.ID, nil).
+		WithChanges(before, outgoingSnapshot(hook)); entry.HasChanges() {
+		s.auditor.Record(ctx, entry)
+	}
+	return toOutgoingWebhookResponse(hook, ""), nil
+}
+
+// DeleteOutgoingWebhook deletes a webhook along with its delivery log.
+func (s *Service) DeleteOutgoingWebhook(ctx context.Context, actorID, webhookID string) error {
+	hook, err := s.findOutgoingWebhook(ctx, actorID, webhookID)
+	if err != nil {
+		return err
+	}
+	if err := s.webhookRepo.DeleteOutgoingWebhook(ctx, hook.ID); err != nil {
+		return err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(derefString(hook.RoomID), actorID, audit.ActionWebhookDeleted, "outgoing_webhook", hook.ID, map[string]any{
+		"url":          hook.URL,
+		"workspace_id": hook.WorkspaceID,
+	}))
+	s.logger.Info("outgoing webhook deleted", "webhook_id", hook.ID, "actor_id", actorID)
+	return nil
+}
+
+// RotateOutgoingWebhookSecret replaces a webhook's signing secret. Deliveries
+// attempted from now on, including retries, are signed with the new secret.
+func (s *Service) RotateOutgoingWebhookSecret(ctx context.Context, actorID, webhookID string) (*OutgoingWebhookResponse, error) {
+	hook, err := s.findOutgoingWebhook(ctx, actorID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	secret, _, err := tokenutil.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	hook.Secret = secret
+	if err := s.webhookRepo.UpdateOutgoingWebhook(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(derefString(hook.RoomID), actorID, audit.ActionWebhookSecretRotated, "outgoing_webhook", hook.ID, nil))
+	return toOutgoingWebhookResponse(hook, secret), nil
+}
+
+// PingOutgoingWebhook queues a ping event for a webhook so its receiver can be
+// checked. The ping shows up in the delivery log like any other delivery.
+func (s *Service) PingOutgoingWebhook(ctx context.Context, actorID, webhookID string) (*DeliveryResponse, error) {
+	hook, err := s.findOutgoingWebhook(ctx, actorID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	eventID, payload, err := newPingPayload(hook)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := newDelivery(hook.ID, eventID, PingEvent, payload)
+	if err := s.webhookRepo.CreateDeliveries(ctx, []*Delivery{delivery}); err != nil {
+		return nil, err
+	}
+	s.dispatcher.enqueue(ctx, delivery.ID)
+	return toDeliveryResponse(delivery), nil
+}
+
+// ListDeliveries returns a page of a webhook's delivery log, newest first.
+// Filtering on the FAILED status lists its dead letters.
+func (s *Service) ListDeliveries(ctx context.Context, actorID string, filter DeliveryFilter) (*response.CursorPage[*DeliveryResponse], error) {
+	if _, err := s.findOutgoingWebhook(ctx, actorID, filter.WebhookID); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	deliveries, err := s.webhookRepo.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*DeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		items = append(items, toDeliveryResponse(d))
+	}
+	return response.NewCursorPage(items, limit, func(d *DeliveryResponse) response.Cursor {
+		return response.Cursor{CreatedAt: d.CreatedAt, ID: d.ID}
+	}), nil
+}
+
+// GetDelivery returns a delivery with its payload and every attempt made.
+func (s *Service) GetDelivery(ctx context.Context, actorID, webhookID, deliveryID string) (*DeliveryDetailResponse, error) {
+	if _, err := s.findOutgoingWebhook(ctx, actorID, webhookID); err != nil {
+		return nil, err
+	}
+	delivery, err := s.webhookRepo.FindDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.webhookRepo.ListDeliveryAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	return toDeliveryDetailResponse(delivery, attempts), nil
+}
+
+// Redeliver sends a finished delivery again with a fresh set of attempts. It
+// is how dead letters are retried once the receiver has been fixed; the same
+// payload and delivery id are sent so receivers can deduplicate.
+func (s *Service) Redeliver(ctx context.Context, actorID, webhookID, deliveryID string) (*DeliveryResponse, error) {
+	if _, err := s.findOutgoingWebhook(ctx, actorID, webhookID); err != nil {
+		return nil, err
+	}
+	if _, err := s.webhookRepo.FindDelivery(ctx, webhookID, deliveryID); err != nil {
+		return nil, err
+	}
+	if err := s.webhookRepo.ResetDelivery(ctx, deliveryID); err != nil {
+		return nil, err
+	}
+	s.dispatcher.enqueue(ctx, deliveryID)
+
+	delivery, err := s.webhookRepo.FindDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("webhook delivery redelivered", "delivery_id", deliveryID, "webhook_id", webhookID, "actor_id", actorID)
+	return toDeliveryResponse(delivery), nil
+}
+
+// FindManagedOutgoingWebhook returns a webhook the user may manage, for
+// features such as slash commands that are configured per webhook.
+func (s *Service) FindManagedOutgoingWebhook(ctx context.Context, actorID, webhookID string) (*OutgoingWebhook, error) {
+	return s.findOutgoingWebhook(ctx, actorID, webhookID)
+}
+
+// findOutgoingWebhook loads a webhook the user may manage: through the room's
+// ManageWebhooks permission or as an admin of the workspace.
+func (s *Service) findOutgoingWebhook(ctx context.Context, actorID, webhookID string) (*OutgoingWebhook, error) {
+	hook, err := s.webhookRepo.FindOutgoingWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if hook.RoomID != nil {
+		err = s.authorize(ctx, *hook.RoomID, actorID)
+	} else {
+		err = s.authorizeWorkspace(ctx, *hook.WorkspaceID, actorID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// authorizeWorkspace checks that the user is an admin of the workspace.
+func (s *Service) authorizeWorkspace(ctx context.Context, workspaceID, userID string) error {
+	member, err := s.workspaceProv.FindMember(ctx, workspaceID, userID)
+	if err != nil {
+		if err == workspace.ErrMemberNotFound {
+			return workspace.ErrNotMember
+		}
+		return err
+	}
+	if member.Role != workspace.RoleAdmin {
+		return workspace.ErrNotAdmin
+	}
+	return nil
+}
+
+// validateTargetURL requires an absolute https URL. Plain http is accepted
+// only when local targets are allowed, for testing against a local receiver.
+func (s *Service) validateTargetURL(raw string) error {
+	u, err := url.ParseRequestURI(raw)
+	if err != nil || u.Host == "" || u.User != nil {
+		return ErrInvalidTargetURL
+	}
+	if u.Scheme != "https" && !(u.Scheme == "http" && s.config.Webhook.AllowLocalTargets) {
+		return ErrInvalidTargetURL
+	}
+	return nil
+}
+
+// normalizeEvents checks the subscribed events and drops duplicates.
+func normalizeEvents(events []types.WebhookEventType) ([]types.WebhookEventType, error) {
+	normalized := make([]types.WebhookEventType, 0, len(events))
+	for _, event := range events {
+		if !slices.Contains(types.WebhookEvents, event) {
+			return nil, ErrInvalidEvent.WithDetails(map[string]any{"event": event})
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, nil
+}
+
+func outgoingSnapshot(hook *OutgoingWebhook) map[string]any {
+	return map[string]any{
+		"url":       hook.URL,
+		"events":    slices.Clone(hook.Events),
+		"is_active": hook.IsActive,
+	}
+}
+
+func toOutgoingWebhookResponses(hooks []*OutgoingWebhook) []*OutgoingWebhookResponse {
+	items := make([]*OutgoingWebhookResponse, 0, len(hooks))
+	for _, hook := range hooks {
+		items = append(items, toOutgoingWebhookResponse(hook, ""))
+	}
+	return items
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
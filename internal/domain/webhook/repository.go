@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type Repository interface {
+	CreateIncomingWebhook(ctx context.Context, hook *IncomingWebhook) error
+	ListIncomingWebhooks(ctx context.Context, roomID string) ([]*IncomingWebhook, error)
+	FindIncomingWebhook(ctx context.Context, roomID, webhookID string) (*IncomingWebhook, error)
+	FindIncomingWebhookByTokenHash(ctx context.Context, tokenHash string) (*IncomingWebhook, error)
+	UpdateIncomingWebhook(ctx context.Context, hook *IncomingWebhook) error
+	DeleteIncomingWebhook(ctx context.Context, roomID, webhookID string) error
+	TouchIncomingWebhook(ctx context.Context, webhookID string) error
+
+	// Outgoing Webhook Methods
+	CreateOutgoingWebhook(ctx context.Context, hook *OutgoingWebhook) error
+	ListRoomOutgoingWebhooks(ctx context.Context, roomID string) ([]*OutgoingWebhook, error)
+	ListWorkspaceOutgoingWebhooks(ctx context.Context, workspaceID string) ([]*OutgoingWebhook, error)
+	FindOutgoingWebhook(ctx context.Context, webhookID string) (*OutgoingWebhook, error)
+	UpdateOutgoingWebhook(ctx context.Context, hook *OutgoingWebhook) error
+	DeleteOutgoingWebhook(ctx context.Context, webhookID string) error
+	// ListSubscribedWebhooks returns the active webhooks of the room and of its
+	// workspace that subscribe to eventType.
+	ListSubscribedWebhooks(ctx context.Context, roomID string, eventType types.WebhookEventType) ([]*OutgoingWebhook, error)
+
+	// Delivery Methods
+	// CreateDeliveries stores the deliveries as pending and already queued.
+	CreateDeliveries(ctx context.Context, deliveries []*Delivery) error
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error)
+	FindDelivery(ctx context.Context, webhookID, deliveryID string) (*Delivery, error)
+	ListDeliveryAttempts(ctx context.Context, deliveryID string) ([]*DeliveryAttempt, error)
+	// ClaimDelivery leases a due pending delivery to the caller and counts the
+	// attempt about to be made. It returns nil when the delivery is not due,
+	// already finished or leased to another worker.
+	ClaimDelivery(ctx context.Context, deliveryID string, lease time.Duration) (*Delivery, error)
+	// CompleteAttempt stores the attempt together with the delivery's new
+	// status, releasing its lease.
+	CompleteAttempt(ctx context.Context, delivery *Delivery, attempt *DeliveryAttempt) error
+	// ResetDelivery makes a finished delivery pending again with a fresh set of
+	// attempts, returning ErrDeliveryInProgress if it is still pending.
+	ResetDelivery(ctx context.Context, deliveryID string) error
+	// RequeueDueDeliveries marks up to limit due pending deliveries as queued
+	// and returns their ids. Deliveries queued after staleBefore are skipped, as
+	// their job is presumably still waiting on the queue.
+	RequeueDueDeliveries(ctx context.Context, staleBefore time.Time, limit int) ([]string, error)
+	DeleteFinishedDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import "github.com/purushothdl/gochat-backend/internal/shared/types"
+
+type CreateIncomingWebhookRequest struct {
+	Name      string `json:"name" validate:"required,min=1,max=80"`
+	AvatarURL string `json:"avatar_url" validate:"omitempty,url,max=2048"`
+}
+
+type UpdateIncomingWebhookRequest struct {
+	Name      *string `json:"name" validate:"omitempty,min=1,max=80"`
+	AvatarURL *string `json:"avatar_url" validate:"omitempty,max=2048"` // An empty string clears the avatar
+}
+
+// ExecuteIncomingWebhookRequest is the payload an external service posts to a
+// webhook URL. Username and AvatarURL override the webhook's defaults for this message.
+type ExecuteIncomingWebhookRequest struct {
+	Content   string `json:"content" validate:"required,min=1,max=2000"`
+	Username  string `json:"username" validate:"omitempty,max=80"`
+	AvatarURL string `json:"avatar_url" validate:"omitempty,url,max=2048"`
+}
+
+type CreateOutgoingWebhookRequest struct {
+	URL    string                   `json:"url" validate:"required,url,max=2048"`
+	Events []types.WebhookEventType `json:"events" validate:"required,min=1,dive,required"`
+}
+
+// UpdateOutgoingWebhookRequest changes only the fields that are set.
+type UpdateOutgoingWebhookRequest struct {
+	URL      *string                  `json:"url" validate:"omitempty,url,max=2048"`
+	Events   []types.WebhookEventType `json:"events" validate:"omitempty,min=1,dive,required"`
+	IsActive *bool                    `json:"is_active"`
+}
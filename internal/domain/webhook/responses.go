@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type IncomingWebhookResponse struct {
+	ID         string     `json:"id"`
+	RoomID     string     `json:"room_id"`
+	Name       string     `json:"name"`
+	AvatarURL  string     `json:"avatar_url"`
+	Token      string     `json:"token,omitempty"` // Only returned when the webhook is created
+	CreatedBy  *string    `json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// ExecuteResponse acknowledges a message posted through a webhook.
+type ExecuteResponse struct {
+	MessageID string    `json:"message_id"`
+	RoomID    string    `json:"room_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toIncomingWebhookResponse(hook *IncomingWebhook, token string) *IncomingWebhookResponse {
+	return &IncomingWebhookResponse{
+		ID:         hook.ID,
+		RoomID:     hook.RoomID,
+		Name:       hook.Name,
+		AvatarURL:  hook.AvatarURL,
+		Token:      token,
+		CreatedBy:  hook.CreatedBy,
+		LastUsedAt: hook.LastUsedAt,
+		CreatedAt:  hook.CreatedAt,
+		UpdatedAt:  hook.UpdatedAt,
+	}
+}
+
+type OutgoingWebhookResponse struct {
+	ID          string                   `json:"id"`
+	RoomID      *string                  `json:"room_id"`
+	WorkspaceID *string                  `json:"workspace_id"`
+	URL         string                   `json:"url"`
+	Events      []types.WebhookEventType `json:"events"`
+	IsActive    bool                     `json:"is_active"`
+	Secret      string                   `json:"secret,omitempty"` // Only returned when the webhook is created or its secret rotated
+	CreatedBy   *string                  `json:"created_by"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+type DeliveryResponse struct {
+	ID             string                 `json:"id"`
+	WebhookID      string                 `json:"webhook_id"`
+	EventID        string                 `json:"event_id"`
+	EventType      types.WebhookEventType `json:"event_type"`
+	Status         DeliveryStatus         `json:"status"`
+	Attempts       int                    `json:"attempts"`
+	NextAttemptAt  *time.Time             `json:"next_attempt_at"` // Only set while the delivery is pending
+	LastStatusCode *int                   `json:"last_status_code"`
+	LastError      *string                `json:"last_error"`
+	DeliveredAt    *time.Time             `json:"delivered_at"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// DeliveryDetailResponse adds the signed payload and every attempt made to a delivery.
+type DeliveryDetailResponse struct {
+	*DeliveryResponse
+	Payload        json.RawMessage            `json:"payload"`
+	AttemptHistory []*DeliveryAttemptResponse `json:"attempt_history"`
+}
+
+type DeliveryAttemptResponse struct {
+	Attempt      int       `json:"attempt"`
+	StatusCode   *int      `json:"status_code"`
+	Error        *string   `json:"error"`
+	ResponseBody string    `json:"response_body"`
+	DurationMS   int64     `json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func toOutgoingWebhookResponse(hook *OutgoingWebhook, secret string) *OutgoingWebhookResponse {
+	return &OutgoingWebhookResponse{
+		ID:          hook.ID,
+		RoomID:      hook.RoomID,
+		WorkspaceID: hook.WorkspaceID,
+		URL:         hook.URL,
+		Events:      hook.Events,
+		IsActive:    hook.IsActive,
+		Secret:      secret,
+		CreatedBy:   hook.CreatedBy,
+		CreatedAt:   hook.CreatedAt,
+		UpdatedAt:   hook.UpdatedAt,
+	}
+}
+
+func toDeliveryResponse(d *Delivery) *DeliveryResponse {
+	resp := &DeliveryResponse{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+	if d.Status == DeliveryPending {
+		resp.NextAttemptAt = &d.NextAttemptAt
+	}
+	return resp
+}
+
+func toDeliveryDetailResponse(d *Delivery, attempts []*DeliveryAttempt) *DeliveryDetailResponse {
+	history := make([]*DeliveryAttemptResponse, 0, len(attempts))
+	for _, a := range attempts {
+		history = append(history, &DeliveryAttemptResponse{
+			Attempt:      a.Attempt,
+			StatusCode:   a.StatusCode,
+			Error:        a.Error,
+			ResponseBody: a.ResponseBody,
+			DurationMS:   a.Duration.Milliseconds(),
+			CreatedAt:    a.CreatedAt,
+		})
+	}
+	return &DeliveryDetailResponse{
+		DeliveryResponse: toDeliveryResponse(d),
+		Payload:          d.Payload,
+		AttemptHistory:   history,
+	}
+}
@@ -0,0 +1,208 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/shared/authz"
+	"github.com/purushothdl/gochat-backend/pkg/utils/tokenutil"
+)
+
+type Service struct {
+	webhookRepo   Repository
+	roomProv      RoomProvider
+	workspaceProv WorkspaceProvider
+	poster        MessagePoster
+	dispatcher    *Dispatcher
+	auditor       AuditRecorder
+	limiter       contracts.RateLimiter
+	config        *config.Config
+	logger        *slog.Logger
+}
+
+func NewService(
+	webhookRepo Repository,
+	roomProv RoomProvider,
+	workspaceProv WorkspaceProvider,
+	poster MessagePoster,
+	dispatcher *Dispatcher,
+	auditor AuditRecorder,
+	limiter contracts.RateLimiter,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		webhookRepo:   webhookRepo,
+		roomProv:      roomProv,
+		workspaceProv: workspaceProv,
+		poster:        poster,
+		dispatcher:    dispatcher,
+		auditor:       auditor,
+		limiter:       limiter,
+		config:        cfg,
+		logger:        logger,
+	}
+}
+
+// CreateIncomingWebhook creates a webhook for a room. The returned token is the
+// secret part of the webhook's URL and is only ever shown here.
+func (s *Service) CreateIncomingWebhook(ctx context.Context, actorID, roomID string, req CreateIncomingWebhookRequest) (*IncomingWebhookResponse, error) {
+	if err := s.authorize(ctx, roomID, actorID); err != nil {
+		return nil, err
+	}
+	if err := validateAvatarURL(req.AvatarURL); err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := tokenutil.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook token: %w", err)
+	}
+	hook := NewIncomingWebhook(roomID, actorID, req.Name, req.AvatarURL, tokenHash)
+	if err := s.webhookRepo.CreateIncomingWebhook(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionWebhookCreated, "webhook", hook.ID, map[string]any{
+		"name": hook.Name,
+	}))
+	s.logger.Info("incoming webhook created", "room_id", roomID, "webhook_id", hook.ID, "actor_id", actorID)
+	return toIncomingWebhookResponse(hook, token), nil
+}
+
+// ListIncomingWebhooks lists a room's webhooks. Tokens are never returned.
+func (s *Service) ListIncomingWebhooks(ctx context.Context, actorID, roomID string) ([]*IncomingWebhookResponse, error) {
+	if err := s.authorize(ctx, roomID, actorID); err != nil {
+		return nil, err
+	}
+	hooks, err := s.webhookRepo.ListIncomingWebhooks(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*IncomingWebhookResponse, 0, len(hooks))
+	for _, hook := range hooks {
+		items = append(items, toIncomingWebhookResponse(hook, ""))
+	}
+	return items, nil
+}
+
+// UpdateIncomingWebhook changes a webhook's default name or avatar.
+func (s *Service) UpdateIncomingWebhook(ctx context.Context, actorID, roomID, webhookID string, req UpdateIncomingWebhookRequest) (*IncomingWebhookResponse, error) {
+	if err := s.authorize(ctx, roomID, actorID); err != nil {
+		return nil, err
+	}
+	hook, err := s.webhookRepo.FindIncomingWebhook(ctx, roomID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := map[string]any{"name": hook.Name, "avatar_url": hook.AvatarURL}
+	if req.Name != nil {
+		hook.Name = *req.Name
+	}
+	if req.AvatarURL != nil {
+		if err := validateAvatarURL(*req.AvatarURL); err != nil {
+			return nil, err
+		}
+		hook.AvatarURL = *req.AvatarURL
+	}
+	if err := s.webhookRepo.UpdateIncomingWebhook(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	if entry := audit.NewEntry(roomID, actorID, audit.ActionWebhookUpdated, "webhook", hook.ID, nil).
+		WithChanges(before, map[string]any{"name": hook.Name, "avatar_url": hook.AvatarURL}); entry.HasChanges() {
+		s.auditor.Record(ctx, entry)
+	}
+	return toIncomingWebhookResponse(hook, ""), nil
+}
+
+// DeleteIncomingWebhook deletes a webhook; its URL stops working immediately.
+// Messages it already posted stay in the room.
+func (s *Service) DeleteIncomingWebhook(ctx context.Context, actorID, roomID, webhookID string) error {
+	if err := s.authorize(ctx, roomID, actorID); err != nil {
+		return err
+	}
+	if err := s.webhookRepo.DeleteIncomingWebhook(ctx, roomID, webhookID); err != nil {
+		return err
+	}
+
+	s.auditor.Record(ctx, audit.NewEntry(roomID, actorID, audit.ActionWebhookDeleted, "webhook", webhookID, nil))
+	s.logger.Info("incoming webhook deleted", "room_id", roomID, "webhook_id", webhookID, "actor_id", actorID)
+	return nil
+}
+
+// ExecuteIncomingWebhook posts a payload sent to a webhook URL into the
+// webhook's room. Each webhook has its own rate limit; limiter outages fail
+// open, as they do for members' messages.
+func (s *Service) ExecuteIncomingWebhook(ctx context.Context, token string, req ExecuteIncomingWebhookRequest) (*ExecuteResponse, error) {
+	hook, err := s.webhookRepo.FindIncomingWebhookByTokenHash(ctx, tokenutil.Hash(token))
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAvatarURL(req.AvatarURL); err != nil {
+		return nil, err
+	}
+
+	limits := s.config.Webhook
+	if limits.IncomingPerWindow > 0 {
+		key := fmt.Sprintf("webhook:%s", hook.ID)
+		allowed, retryAfter, err := s.limiter.Allow(ctx, key, limits.IncomingPerWindow, limits.IncomingWindow)
+		if err != nil {
+			s.logger.Error("failed to check webhook rate limit", "error", err, "webhook_id", hook.ID)
+		} else if !allowed {
+			return nil, NewRateLimitedError(retryAfter)
+		}
+	}
+
+	post := message.WebhookPost{
+		RoomID:          hook.RoomID,
+		WebhookID:       hook.ID,
+		SenderName:      hook.Name,
+		SenderAvatarURL: hook.AvatarURL,
+		Content:         req.Content,
+	}
+	if req.Username != "" {
+		post.SenderName = req.Username
+	}
+	if req.AvatarURL != "" {
+		post.SenderAvatarURL = req.AvatarURL
+	}
+
+	msg, err := s.poster.PostWebhookMessage(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.webhookRepo.TouchIncomingWebhook(ctx, hook.ID); err != nil {
+		s.logger.Error("failed to record webhook use", "error", err, "webhook_id", hook.ID)
+	}
+
+	return &ExecuteResponse{MessageID: msg.ID, RoomID: msg.RoomID, CreatedAt: msg.CreatedAt}, nil
+}
+
+// authorize checks that the user may manage the room's webhooks.
+func (s *Service) authorize(ctx context.Context, roomID, userID string) error {
+	membership, err := s.roomProv.GetMembershipInfo(ctx, roomID, userID)
+	if err != nil {
+		return err
+	}
+	return authz.Require(membership.Role, nil, authz.ManageWebhooks)
+}
+
+func validateAvatarURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.ParseRequestURI(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidAvatarURL
+	}
+	return nil
+}
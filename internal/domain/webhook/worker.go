@@ -0,0 +1,281 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/pkg/utils/signutil"
+)
+
+const (
+	requeueBatchSize    = 500
+	maxResponseBodySize = 1024 // Bytes of a receiver's response kept in the delivery log
+	cleanupInterval     = time.Hour
+)
+
+// Worker delivers queued webhook events to their receivers. Each delivery is
+// leased before it is attempted, so a job that is queued twice is only sent
+// once; failures are retried with exponential backoff until MaxAttempts, after
+// which the delivery is marked FAILED and stays in the dead-letter list.
+type Worker struct {
+	webhookRepo Repository
+	queue       contracts.Queue
+	client      *http.Client
+	config      *config.Config
+	logger      *slog.Logger
+}
+
+func NewWorker(webhookRepo Repository, queue contracts.Queue, cfg *config.Config, logger *slog.Logger) *Worker {
+	return &Worker{
+		webhookRepo: webhookRepo,
+		queue:       queue,
+		client:      newDeliveryClient(&cfg.Webhook),
+		config:      cfg,
+		logger:      logger,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	w.logger.Info("starting webhook delivery worker...")
+	go w.schedule(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("webhook delivery worker shutting down")
+			return
+		default:
+			w.processNextJob(ctx)
+		}
+	}
+}
+
+func (w *Worker) processNextJob(ctx context.Context) {
+	var job DeliveryJob
+	if err := w.queue.Dequeue(ctx, w.config.Webhook.DeliveryQueueName, &job); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		w.logger.Error("failed to dequeue webhook delivery", "error", err)
+		time.Sleep(5 * time.Second)
+		return
+	}
+
+	logger := w.logger.With("delivery_id", job.DeliveryID)
+	delivery, err := w.webhookRepo.ClaimDelivery(ctx, job.DeliveryID, w.config.Webhook.DeliveryLease)
+	if err != nil {
+		logger.Error("failed to claim webhook delivery", "error", err)
+		return
+	}
+	if delivery == nil {
+		// Already delivered, not yet due, or being attempted by another worker.
+		return
+	}
+
+	hook, err := w.webhookRepo.FindOutgoingWebhook(ctx, delivery.WebhookID)
+	if err != nil {
+		logger.Error("failed to load webhook for delivery", "error", err, "webhook_id", delivery.WebhookID)
+		return
+	}
+	logger = logger.With("webhook_id", hook.ID, "event", delivery.EventType, "attempt", delivery.Attempts)
+
+	attempt := w.attempt(ctx, hook, delivery)
+	w.applyOutcome(delivery, attempt, hook.IsActive)
+	if err := w.webhookRepo.CompleteAttempt(ctx, delivery, attempt); err != nil {
+		// The lease expires on its own, so the delivery is attempted again either way.
+		logger.Error("failed to record webhook delivery attempt", "error", err)
+		return
+	}
+
+	switch delivery.Status {
+	case DeliverySucceeded:
+		logger.Info("webhook delivered", "status_code", *attempt.StatusCode)
+	case DeliveryFailed:
+		logger.Warn("webhook delivery failed permanently", "error", *attempt.Error)
+	default:
+		logger.Warn("webhook delivery failed, will retry", "error", *attempt.Error, "retry_at", delivery.NextAttemptAt)
+	}
+}
+
+// attempt sends the delivery's payload to the webhook, signed with its
+// current secret. A paused webhook is not contacted at all.
+func (w *Worker) attempt(ctx context.Context, hook *OutgoingWebhook, delivery *Delivery) *DeliveryAttempt {
+	attempt := &DeliveryAttempt{
+		ID:         uuid.NewString(),
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+	}
+	if !hook.IsActive {
+		attempt.Error = stringPtr("webhook is paused")
+		return attempt
+	}
+
+	start := time.Now()
+	statusCode, body, err := w.send(ctx, hook, delivery)
+	attempt.Duration = time.Since(start)
+	attempt.ResponseBody = body
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+	switch {
+	case err != nil:
+		attempt.Error = stringPtr(err.Error())
+	case statusCode < 200 || statusCode > 299:
+		attempt.Error = stringPtr(fmt.Sprintf("receiver responded with status %d", statusCode))
+	}
+	return attempt
+}
+
+func (w *Worker) send(ctx context.Context, hook *OutgoingWebhook, delivery *Delivery) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to build request: %w", err)
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GoChat-Webhooks/1.0")
+	req.Header.Set("X-Gochat-Event", string(delivery.EventType))
+	req.Header.Set("X-Gochat-Delivery", delivery.ID)
+	req.Header.Set("X-Gochat-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Gochat-Signature", signutil.Sign(hook.Secret, timestamp, delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	return resp.StatusCode, string(body), nil
+}
+
+// applyOutcome moves the delivery on after an attempt: done when it
+// succeeded, otherwise back to pending with a backoff, or dead-lettered once
+// it is out of attempts or its webhook is paused.
+func (w *Worker) applyOutcome(delivery *Delivery, attempt *DeliveryAttempt, hookActive bool) {
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = attempt.Error
+
+	switch {
+	case attempt.Error == nil:
+		now := time.Now()
+		delivery.Status = DeliverySucceeded
+		delivery.DeliveredAt = &now
+	case !hookActive || delivery.Attempts >= w.config.Webhook.MaxAttempts:
+		delivery.Status = DeliveryFailed
+	default:
+		delivery.Status = DeliveryPending
+		delivery.NextAttemptAt = time.Now().Add(w.backoff(delivery.Attempts))
+	}
+}
+
+// backoff doubles the retry delay with every failed attempt, capped at MaxBackoff.
+func (w *Worker) backoff(attempts int) time.Duration {
+	cfg := w.config.Webhook
+	delay := cfg.MinBackoff
+	for i := 1; i < attempts && delay < cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, cfg.MaxBackoff)
+}
+
+// schedule puts retries back on the queue once they are due, along with any
+// delivery whose job was lost, and periodically removes old finished
+// deliveries.
+func (w *Worker) schedule(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Webhook.RetryInterval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.requeueDue(ctx)
+			if time.Since(lastCleanup) >= cleanupInterval {
+				w.cleanup(ctx)
+				lastCleanup = time.Now()
+			}
+		}
+	}
+}
+
+func (w *Worker) requeueDue(ctx context.Context) {
+	cfg := w.config.Webhook
+	ids, err := w.webhookRepo.RequeueDueDeliveries(ctx, time.Now().Add(-cfg.DeliveryLease), requeueBatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("failed to find due webhook deliveries", "error", err)
+		}
+		return
+	}
+	for _, id := range ids {
+		if err := w.queue.Enqueue(ctx, cfg.DeliveryQueueName, DeliveryJob{DeliveryID: id}); err != nil {
+			// Still marked as queued; it is picked up again once the lease has passed.
+			w.logger.Error("failed to queue webhook delivery", "error", err, "delivery_id", id)
+		}
+	}
+}
+
+func (w *Worker) cleanup(ctx context.Context) {
+	cutoff := time.Now().Add(-w.config.Webhook.DeliveryRetention)
+	deleted, err := w.webhookRepo.DeleteFinishedDeliveriesBefore(ctx, cutoff)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("failed to clean up webhook deliveries", "error", err)
+		}
+		return
+	}
+	if deleted > 0 {
+		w.logger.Info("cleaned up webhook deliveries", "count", deleted)
+	}
+}
+
+// newDeliveryClient builds the HTTP client used for deliveries. Redirects are
+// not followed, and unless local targets are allowed, connections to
+// loopback, private and link-local addresses are refused so that webhooks
+// cannot be pointed at internal services.
+func newDeliveryClient(cfg *config.WebhookConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: cfg.DeliveryTimeout}
+	if !cfg.AllowLocalTargets {
+		dialer.Control = rejectInternalAddress
+	}
+	return &http.Client{
+		Timeout:   cfg.DeliveryTimeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// rejectInternalAddress runs after DNS resolution, so it also catches public
+// hostnames that resolve to internal addresses.
+func rejectInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("webhook target %s is not a public address", host)
+	}
+	return nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,66 @@
+package workspace
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type Role string
+
+const (
+	RoleAdmin  Role = "ADMIN"
+	RoleMember Role = "MEMBER"
+)
+
+// Workspace groups users and the rooms they share. Every room belongs to one
+// workspace, and only its members can join, be invited to or find its rooms.
+type Workspace struct {
+	ID          string
+	Name        string
+	Description string
+	IsDefault   bool // New accounts join the default workspace on sign-up
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewWorkspace creates a new, non-default Workspace entity.
+func NewWorkspace(name, description string) *Workspace {
+	return &Workspace{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Description: description,
+	}
+}
+
+// Member links a user to a workspace with a specific role.
+type Member struct {
+	WorkspaceID string
+	UserID      string
+	Role        Role
+	JoinedAt    time.Time
+	User        *types.BasicUser // Populated when listing a workspace's members
+}
+
+// UserWorkspace is a workspace as listed for one of its members.
+type UserWorkspace struct {
+	Workspace *Workspace
+	Role      Role
+}
+
+// MemberFilter narrows and pages a workspace's member list. Query matches
+// member names and email addresses.
+type MemberFilter struct {
+	Query  string
+	Cursor *response.Cursor
+	Limit  int
+}
+
+// DefaultRoom is a public room that new workspace members join automatically.
+type DefaultRoom struct {
+	ID          string
+	Name        string
+	MemberCount int
+}
@@ -0,0 +1,16 @@
+package workspace
+
+import "github.com/purushothdl/gochat-backend/pkg/errors"
+
+var (
+	ErrWorkspaceNotFound  = errors.New("WORKSPACE_NOT_FOUND", "The requested workspace was not found", 404)
+	ErrNoDefaultWorkspace = errors.New("NO_DEFAULT_WORKSPACE", "No workspace is set up to take in new accounts", 404)
+	ErrNotMember          = errors.New("NOT_WORKSPACE_MEMBER", "You are not a member of this workspace", 403)
+	ErrNotAdmin           = errors.New("NOT_WORKSPACE_ADMIN", "Only workspace admins can do this", 403)
+	ErrMemberNotFound     = errors.New("MEMBER_NOT_FOUND", "The user is not a member of this workspace", 404)
+	ErrAlreadyMember      = errors.New("ALREADY_MEMBER", "The user is already a member of this workspace", 409)
+	ErrUserNotFound       = errors.New("USER_NOT_FOUND", "The user was not found", 404)
+	ErrLastAdmin          = errors.New("LAST_ADMIN", "A workspace must keep at least one admin", 409)
+	ErrMemberOwnsRooms    = errors.New("MEMBER_OWNS_ROOMS", "The user owns rooms in this workspace; transfer their ownership first", 409)
+	ErrInvalidDefaultRoom = errors.New("INVALID_DEFAULT_ROOM", "Only active public rooms in this workspace can be default rooms", 400)
+)
@@ -0,0 +1,267 @@
+package workspace
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	"github.com/purushothdl/gochat-backend/internal/shared/validator"
+	authMiddleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+type Handler struct {
+	service   *Service
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewHandler(service *Service, logger *slog.Logger, v *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		logger:    logger,
+		validator: v,
+	}
+}
+
+// CreateWorkspace handles POST /api/workspaces
+func (h *Handler) CreateWorkspace(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	var req CreateWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	ws, err := h.service.CreateWorkspace(r.Context(), userID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, ws)
+}
+
+// ListMyWorkspaces handles GET /api/workspaces
+func (h *Handler) ListMyWorkspaces(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+
+	workspaces, err := h.service.ListMyWorkspaces(r.Context(), userID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, workspaces)
+}
+
+// GetWorkspace handles GET /api/workspaces/{workspace_id}
+func (h *Handler) GetWorkspace(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	workspaceID := chi.URLParam(r, "workspace_id")
+
+	ws, err := h.service.GetWorkspace(r.Context(), userID, workspaceID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ws)
+}
+
+// UpdateWorkspace handles PATCH /api/workspaces/{workspace_id}
+func (h *Handler) UpdateWorkspace(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	workspaceID := chi.URLParam(r, "workspace_id")
+
+	var req UpdateWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	ws, err := h.service.UpdateWorkspace(r.Context(), actorID, workspaceID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ws)
+}
+
+// ListMembers handles GET /api/workspaces/{workspace_id}/members?q=&cursor=&limit=
+func (h *Handler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	workspaceID := chi.URLParam(r, "workspace_id")
+
+	query := r.URL.Query()
+	cursor, err := response.DecodeCursor(query.Get("cursor"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	filter := MemberFilter{
+		Query:  strings.TrimSpace(query.Get("q")),
+		Cursor: cursor,
+		Limit:  response.ParseLimit(r, 25, 100),
+	}
+
+	page, err := h.service.ListMembers(r.Context(), userID, workspaceID, filter)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, page)
+}
+
+// AddMember handles POST /api/workspaces/{workspace_id}/members
+func (h *Handler) AddMember(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	workspaceID := chi.URLParam(r, "workspace_id")
+
+	var req AddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	member, err := h.service.AddMember(r.Context(), actorID, workspaceID, req)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, member)
+}
+
+// UpdateMemberRole handles PUT /api/workspaces/{workspace_id}/members/{user_id}/role
+func (h *Handler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	workspaceID := chi.URLParam(r, "workspace_id")
+	targetUserID := chi.URLParam(r, "user_id")
+
+	var req UpdateMemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	if validationErrs := h.validator.Validate(req); validationErrs != nil {
+		response.ErrorJSON(w, http.StatusUnprocessableEntity, validationErrs)
+		return
+	}
+
+	if err := h.service.UpdateMemberRole(r.Context(), actorID, workspaceID, targetUserID, req); err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveMember handles DELETE /api/workspaces/{workspace_id}/members/{user_id}
+func (h *Handler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	workspaceID := chi.URLParam(r, "workspace_id")
+	targetUserID := chi.URLParam(r, "user_id")
+
+	if err := h.service.RemoveMember(r.Context(), actorID, workspaceID, targetUserID); err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDefaultRooms handles GET /api/workspaces/{workspace_id}/default-rooms
+func (h *Handler) ListDefaultRooms(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	workspaceID := chi.URLParam(r, "workspace_id")
+
+	rooms, err := h.service.ListDefaultRooms(r.Context(), userID, workspaceID)
+	if err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, rooms)
+}
+
+// AddDefaultRoom handles PUT /api/workspaces/{workspace_id}/default-rooms/{room_id}
+func (h *Handler) AddDefaultRoom(w http.ResponseWriter, r *http.Request) {
+	h.setDefaultRoom(w, r, true)
+}
+
+// RemoveDefaultRoom handles DELETE /api/workspaces/{workspace_id}/default-rooms/{room_id}
+func (h *Handler) RemoveDefaultRoom(w http.ResponseWriter, r *http.Request) {
+	h.setDefaultRoom(w, r, false)
+}
+
+func (h *Handler) setDefaultRoom(w http.ResponseWriter, r *http.Request, isDefault bool) {
+	actorID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	}
+	workspaceID := chi.URLParam(r, "workspace_id")
+	roomID := chi.URLParam(r, "room_id")
+
+	if err := h.service.SetDefaultRoom(r.Context(), actorID, workspaceID, roomID, isDefault); err != nil {
+		response.Error(w, 0, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
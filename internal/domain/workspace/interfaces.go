@@ -0,0 +1,13 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+// UserProvider defines the methods the workspace service needs about accounts.
+type UserProvider interface {
+	GetByIDShared(ctx context.Context, id string) (*types.User, error)
+	ExistsByID(ctx context.Context, id string) (bool, error)
+}
@@ -0,0 +1,31 @@
+package workspace
+
+import "context"
+
+type Repository interface {
+	// CreateWorkspace stores a new workspace with creatorID as its first admin.
+	CreateWorkspace(ctx context.Context, ws *Workspace, creatorID string) error
+	FindWorkspace(ctx context.Context, workspaceID string) (*Workspace, error)
+	UpdateWorkspace(ctx context.Context, ws *Workspace) error
+	ListUserWorkspaces(ctx context.Context, userID string) ([]*UserWorkspace, error)
+	// DefaultWorkspaceID returns ErrNoDefaultWorkspace when no workspace takes in new accounts.
+	DefaultWorkspaceID(ctx context.Context) (string, error)
+
+	// Member Methods
+	FindMember(ctx context.Context, workspaceID, userID string) (*Member, error)
+	ListMembers(ctx context.Context, workspaceID string, filter MemberFilter) ([]*Member, error)
+	// AddMember stores the membership and joins the user to the workspace's
+	// default rooms, skipping any they are banned from.
+	AddMember(ctx context.Context, member *Member) error
+	UpdateMemberRole(ctx context.Context, workspaceID, userID string, role Role) error
+	// RemoveMember takes the user out of the workspace and all of its rooms. It
+	// returns ErrMemberOwnsRooms if they still own one of those rooms.
+	RemoveMember(ctx context.Context, workspaceID, userID string) error
+	CountAdmins(ctx context.Context, workspaceID string) (int, error)
+
+	// Default Room Methods
+	ListDefaultRooms(ctx context.Context, workspaceID string) ([]*DefaultRoom, error)
+	// SetDefaultRoom marks or unmarks a room for auto-join, returning
+	// ErrInvalidDefaultRoom unless it is an active public room in the workspace.
+	SetDefaultRoom(ctx context.Context, workspaceID, roomID string, isDefault bool) error
+}
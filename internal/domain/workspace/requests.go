@@ -0,0 +1,21 @@
+package workspace
+
+type CreateWorkspaceRequest struct {
+	Name        string `json:"name" validate:"required,min=3,max=100"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+type UpdateWorkspaceRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=3,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=500"`
+}
+
+// AddMemberRequest adds a user to a workspace. Role defaults to MEMBER.
+type AddMemberRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+	Role   Role   `json:"role" validate:"omitempty,oneof=ADMIN MEMBER"`
+}
+
+type UpdateMemberRoleRequest struct {
+	Role Role `json:"role" validate:"required,oneof=ADMIN MEMBER"`
+}
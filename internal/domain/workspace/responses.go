@@ -0,0 +1,59 @@
+package workspace
+
+import (
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type WorkspaceResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsDefault   bool      `json:"is_default"`
+	Role        Role      `json:"role,omitempty"` // The caller's role, when they are a member
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type MemberResponse struct {
+	UserID   string           `json:"user_id"`
+	Role     Role             `json:"role"`
+	JoinedAt time.Time        `json:"joined_at"`
+	User     *types.BasicUser `json:"user,omitempty"`
+}
+
+type DefaultRoomResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	MemberCount int    `json:"member_count"`
+}
+
+func toWorkspaceResponse(ws *Workspace, role Role) *WorkspaceResponse {
+	return &WorkspaceResponse{
+		ID:          ws.ID,
+		Name:        ws.Name,
+		Description: ws.Description,
+		IsDefault:   ws.IsDefault,
+		Role:        role,
+		CreatedAt:   ws.CreatedAt,
+		UpdatedAt:   ws.UpdatedAt,
+	}
+}
+
+func toMemberResponse(m *Member) *MemberResponse {
+	return &MemberResponse{
+		UserID:   m.UserID,
+		Role:     m.Role,
+		JoinedAt: m.JoinedAt,
+		User:     m.User,
+	}
+}
+
+func toDefaultRoomResponse(r *DefaultRoom) *DefaultRoomResponse {
+	return &DefaultRoomResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		MemberCount: r.MemberCount,
+	}
+}
@@ -0,0 +1,290 @@
+// internal/domain/workspace/service.go
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/shared/response"
+	pointer "github.com/purushothdl/gochat-backend/pkg/utils/pointer"
+)
+
+type Service struct {
+	workspaceRepo Repository
+	userProv      UserProvider
+	config        *config.Config
+	logger        *slog.Logger
+}
+
+func NewService(repo Repository, userProv UserProvider, cfg *config.Config, logger *slog.Logger) *Service {
+	return &Service{
+		workspaceRepo: repo,
+		userProv:      userProv,
+		config:        cfg,
+		logger:        logger,
+	}
+}
+
+// CreateWorkspace creates a workspace with its creator as the first admin.
+func (s *Service) CreateWorkspace(ctx context.Context, creatorID string, req CreateWorkspaceRequest) (*WorkspaceResponse, error) {
+	ws := NewWorkspace(req.Name, req.Description)
+	if err := s.workspaceRepo.CreateWorkspace(ctx, ws, creatorID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("workspace created", "workspace_id", ws.ID, "user_id", creatorID)
+	return toWorkspaceResponse(ws, RoleAdmin), nil
+}
+
+// ListMyWorkspaces returns the workspaces the user belongs to, with their role in each.
+func (s *Service) ListMyWorkspaces(ctx context.Context, userID string) ([]*WorkspaceResponse, error) {
+	workspaces, err := s.workspaceRepo.ListUserWorkspaces(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*WorkspaceResponse, len(workspaces))
+	for i, uw := range workspaces {
+		items[i] = toWorkspaceResponse(uw.Workspace, uw.Role)
+	}
+	return items, nil
+}
+
+// GetWorkspace returns a workspace to one of its members.
+func (s *Service) GetWorkspace(ctx context.Context, userID, workspaceID string) (*WorkspaceResponse, error) {
+	member, err := s.requireMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := s.workspaceRepo.FindWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return toWorkspaceResponse(ws, member.Role), nil
+}
+
+// UpdateWorkspace changes a workspace's name or description.
+func (s *Service) UpdateWorkspace(ctx context.Context, actorID, workspaceID string, req UpdateWorkspaceRequest) (*WorkspaceResponse, error) {
+	role, err := s.requireAdmin(ctx, workspaceID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := s.workspaceRepo.FindWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pointer.UpdatePointerField(&ws.Name, req.Name)
+	pointer.UpdatePointerField(&ws.Description, req.Description)
+	if err := s.workspaceRepo.UpdateWorkspace(ctx, ws); err != nil {
+		return nil, err
+	}
+	return toWorkspaceResponse(ws, role), nil
+}
+
+// ListMembers returns a page of a workspace's members, newest first. It doubles
+// as the workspace's user search: Query matches names and email addresses.
+func (s *Service) ListMembers(ctx context.Context, userID, workspaceID string, filter MemberFilter) (*response.CursorPage[*MemberResponse], error) {
+	if _, err := s.requireMember(ctx, workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	members, err := s.workspaceRepo.ListMembers(ctx, workspaceID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*MemberResponse, 0, len(members))
+	for _, m := range members {
+		items = append(items, toMemberResponse(m))
+	}
+	return response.NewCursorPage(items, limit, func(m *MemberResponse) response.Cursor {
+		return response.Cursor{CreatedAt: m.JoinedAt, ID: m.UserID}
+	}), nil
+}
+
+// AddMember adds a user to the workspace, joining them to its default rooms.
+func (s *Service) AddMember(ctx context.Context, actorID, workspaceID string, req AddMemberRequest) (*MemberResponse, error) {
+	if _, err := s.requireAdmin(ctx, workspaceID, actorID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.userProv.ExistsByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	}
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+
+	role := req.Role
+	if role == "" {
+		role = RoleMember
+	}
+	member := &Member{WorkspaceID: workspaceID, UserID: req.UserID, Role: role}
+	if err := s.workspaceRepo.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("user added to workspace", "workspace_id", workspaceID, "actor_id", actorID, "user_id", req.UserID, "role", role)
+	return toMemberResponse(member), nil
+}
+
+// JoinDefaultWorkspace enrolls a new account in the default workspace and its
+// default rooms. Without a default workspace there is nothing to join.
+func (s *Service) JoinDefaultWorkspace(ctx context.Context, userID string) error {
+	workspaceID, err := s.workspaceRepo.DefaultWorkspaceID(ctx)
+	if err != nil {
+		if err == ErrNoDefaultWorkspace {
+			return nil
+		}
+		return err
+	}
+
+	member := &Member{WorkspaceID: workspaceID, UserID: userID, Role: RoleMember}
+	if err := s.workspaceRepo.AddMember(ctx, member); err != nil && err != ErrAlreadyMember {
+		return err
+	}
+	return nil
+}
+
+// UpdateMemberRole promotes or demotes a workspace member, keeping at least one admin.
+func (s *Service) UpdateMemberRole(ctx context.Context, actorID, workspaceID, targetUserID string, req UpdateMemberRoleRequest) error {
+	if _, err := s.requireAdmin(ctx, workspaceID, actorID); err != nil {
+		return err
+	}
+	target, err := s.workspaceRepo.FindMember(ctx, workspaceID, targetUserID)
+	if err != nil {
+		return err
+	}
+	if target.Role == req.Role {
+		return nil
+	}
+	if err := s.checkNotLastAdmin(ctx, target); err != nil {
+		return err
+	}
+
+	if err := s.workspaceRepo.UpdateMemberRole(ctx, workspaceID, targetUserID, req.Role); err != nil {
+		return err
+	}
+
+	s.logger.Info("workspace member role updated", "workspace_id", workspaceID, "actor_id", actorID, "user_id", targetUserID, "role", req.Role)
+	return nil
+}
+
+// RemoveMember takes a user out of the workspace and every room in it. Members
+// may remove themselves; removing anyone else takes an admin.
+func (s *Service) RemoveMember(ctx context.Context, actorID, workspaceID, targetUserID string) error {
+	if actorID != targetUserID {
+		if _, err := s.requireAdmin(ctx, workspaceID, actorID); err != nil {
+			return err
+		}
+	}
+	target, err := s.workspaceRepo.FindMember(ctx, workspaceID, targetUserID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkNotLastAdmin(ctx, target); err != nil {
+		return err
+	}
+
+	if err := s.workspaceRepo.RemoveMember(ctx, workspaceID, targetUserID); err != nil {
+		return err
+	}
+
+	s.logger.Info("user removed from workspace", "workspace_id", workspaceID, "actor_id", actorID, "user_id", targetUserID)
+	return nil
+}
+
+// ListDefaultRooms returns the rooms new members of the workspace join automatically.
+func (s *Service) ListDefaultRooms(ctx context.Context, userID, workspaceID string) ([]*DefaultRoomResponse, error) {
+	if _, err := s.requireMember(ctx, workspaceID, userID); err != nil {
+		return nil, err
+	}
+	rooms, err := s.workspaceRepo.ListDefaultRooms(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*DefaultRoomResponse, len(rooms))
+	for i, r := range rooms {
+		items[i] = toDefaultRoomResponse(r)
+	}
+	return items, nil
+}
+
+// SetDefaultRoom adds a public room to, or removes it from, the workspace's
+// auto-join list. Existing members are not joined retroactively.
+func (s *Service) SetDefaultRoom(ctx context.Context, actorID, workspaceID, roomID string, isDefault bool) error {
+	if _, err := s.requireAdmin(ctx, workspaceID, actorID); err != nil {
+		return err
+	}
+	if err := s.workspaceRepo.SetDefaultRoom(ctx, workspaceID, roomID, isDefault); err != nil {
+		return err
+	}
+
+	s.logger.Info("workspace default room updated", "workspace_id", workspaceID, "room_id", roomID, "is_default", isDefault)
+	return nil
+}
+
+// requireMember loads the user's membership, reporting ErrNotMember for
+// workspaces they do not belong to.
+func (s *Service) requireMember(ctx context.Context, workspaceID, userID string) (*Member, error) {
+	member, err := s.workspaceRepo.FindMember(ctx, workspaceID, userID)
+	if err != nil {
+		if err == ErrMemberNotFound {
+			return nil, ErrNotMember
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+// requireAdmin allows workspace admins, and system admins on any workspace,
+// returning the actor's role in the workspace, if any.
+func (s *Service) requireAdmin(ctx context.Context, workspaceID, actorID string) (Role, error) {
+	member, err := s.workspaceRepo.FindMember(ctx, workspaceID, actorID)
+	if err != nil && err != ErrMemberNotFound {
+		return "", err
+	}
+	if member != nil && member.Role == RoleAdmin {
+		return RoleAdmin, nil
+	}
+
+	actor, err := s.userProv.GetByIDShared(ctx, actorID)
+	if err != nil {
+		return "", err
+	}
+	if !actor.IsAdmin {
+		if member == nil {
+			return "", ErrNotMember
+		}
+		return "", ErrNotAdmin
+	}
+	if _, err := s.workspaceRepo.FindWorkspace(ctx, workspaceID); err != nil {
+		return "", err
+	}
+	if member == nil {
+		return "", nil
+	}
+	return member.Role, nil
+}
+
+// checkNotLastAdmin refuses to demote or remove a workspace's only admin.
+func (s *Service) checkNotLastAdmin(ctx context.Context, target *Member) error {
+	if target.Role != RoleAdmin {
+		return nil
+	}
+	admins, err := s.workspaceRepo.CountAdmins(ctx, target.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package importer
+
+import "time"
+
+// ImportedRoom is a room created from a source channel. IDs are derived
+// deterministically from the source so reruns address the same rows.
+type ImportedRoom struct {
+	ID        string
+	Name      string
+	Type      string
+	CreatedAt time.Time
+}
+
+// ImportedMembership links a mapped user to an imported room.
+type ImportedMembership struct {
+	UserID string
+	Role   string
+}
+
+// ImportedMessage is a message row ready for bulk insertion.
+type ImportedMessage struct {
+	ID        string
+	RoomID    string
+	UserID    *string
+	Content   string
+	Type      string
+	CreatedAt time.Time
+}
+
+// Summary reports what a single import run did.
+type Summary struct {
+	Rooms            int
+	MappedUsers      int
+	UnmappedUsers    int
+	FilesImported    int
+	FilesSkipped     int
+	MessagesInserted int64
+}
@@ -0,0 +1,229 @@
+package importer
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxRoomNameLength = 100
+
+// importNamespace seeds the deterministic UUIDs assigned to imported rows.
+var importNamespace = uuid.MustParse("6f1c1c64-3c4e-4a55-9a57-2f0cf4c1b5a1")
+
+// Importer loads a Slack-format export archive into the database.
+type Importer struct {
+	repo   Repository
+	source string
+	logger *slog.Logger
+}
+
+// New creates an importer. The source label scopes generated IDs and
+// checkpoints, so the same archive must always be imported under the same label.
+func New(repo Repository, source string, logger *slog.Logger) *Importer {
+	return &Importer{
+		repo:   repo,
+		source: source,
+		logger: logger,
+	}
+}
+
+// Run imports every channel in the archive, skipping day files that a previous run already committed.
+func (i *Importer) Run(ctx context.Context, archive *zip.Reader) (*Summary, error) {
+	slack := openSlackArchive(archive)
+	summary := &Summary{}
+
+	users, err := slack.Users()
+	if err != nil {
+		return nil, err
+	}
+	userMap, names, err := i.mapUsers(ctx, users, summary)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := slack.Channels()
+	if err != nil {
+		return nil, err
+	}
+
+	done, err := i.repo.CompletedCheckpoints(ctx, i.source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load import checkpoints: %w", err)
+	}
+
+	for _, channel := range channels {
+		imported := i.buildRoom(channel)
+		if err := i.repo.UpsertRoom(ctx, imported, i.buildMemberships(channel, userMap)); err != nil {
+			return nil, fmt.Errorf("failed to import channel %s: %w", channel.Name, err)
+		}
+		summary.Rooms++
+
+		for _, dayFile := range slack.DayFiles(channel.Name) {
+			checkpoint := fmt.Sprintf("channel:%s:%s", channel.ID, dayFile)
+			if done[checkpoint] {
+				summary.FilesSkipped++
+				continue
+			}
+
+			raw, err := slack.Messages(dayFile)
+			if err != nil {
+				return nil, err
+			}
+
+			messages := i.buildMessages(imported.ID, channel.ID, raw, userMap, names)
+			inserted, err := i.repo.ImportMessages(ctx, i.source, checkpoint, messages)
+			if err != nil {
+				return nil, fmt.Errorf("failed to import %s: %w", dayFile, err)
+			}
+
+			summary.FilesImported++
+			summary.MessagesInserted += inserted
+			i.logger.Info("imported day file", "file", dayFile, "messages", inserted)
+		}
+	}
+
+	return summary, nil
+}
+
+// mapUsers resolves Slack users to existing accounts by email and collects display names for mentions.
+func (i *Importer) mapUsers(ctx context.Context, users []*slackUser, summary *Summary) (map[string]string, map[string]string, error) {
+	names := make(map[string]string, len(users))
+	var emails []string
+	for _, u := range users {
+		names[u.ID] = u.DisplayName()
+		if u.Profile.Email != "" {
+			emails = append(emails, strings.ToLower(u.Profile.Email))
+		}
+	}
+
+	byEmail, err := i.repo.FindUserIDsByEmail(ctx, emails)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to map users by email: %w", err)
+	}
+
+	userMap := make(map[string]string, len(byEmail))
+	for _, u := range users {
+		if id, ok := byEmail[strings.ToLower(u.Profile.Email)]; ok && u.Profile.Email != "" {
+			userMap[u.ID] = id
+			summary.MappedUsers++
+			continue
+		}
+		summary.UnmappedUsers++
+		i.logger.Warn("no account found for slack user", "slack_user", u.Name, "email", u.Profile.Email)
+	}
+	return userMap, names, nil
+}
+
+func (i *Importer) buildRoom(channel *slackChannel) *ImportedRoom {
+	roomType := "PUBLIC"
+	if channel.Private {
+		roomType = "PRIVATE"
+	}
+
+	name := channel.Name
+	if len(name) > maxRoomNameLength {
+		name = name[:maxRoomNameLength]
+	}
+
+	return &ImportedRoom{
+		ID:        i.deterministicID("channel", channel.ID),
+		Name:      name,
+		Type:      roomType,
+		CreatedAt: time.Unix(channel.Created, 0).UTC(),
+	}
+}
+
+// buildMemberships makes the channel creator the room's owner. When the creator
+// has no account here, the first mapped member is promoted so the room stays manageable.
+func (i *Importer) buildMemberships(channel *slackChannel, userMap map[string]string) []ImportedMembership {
+	adminID, hasAdmin := userMap[channel.Creator]
+	seen := make(map[string]bool)
+	var members []ImportedMembership
+
+	for _, slackID := range channel.Members {
+		userID, ok := userMap[slackID]
+		if !ok || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		role := "MEMBER"
+		if !hasAdmin {
+			adminID, hasAdmin = userID, true
+		}
+		if userID == adminID {
+			role = "OWNER"
+		}
+		members = append(members, ImportedMembership{UserID: userID, Role: role})
+	}
+
+	if hasAdmin && !seen[adminID] {
+		members = append(members, ImportedMembership{UserID: adminID, Role: "OWNER"})
+	}
+	return members
+}
+
+func (i *Importer) buildMessages(roomID, channelID string, raw []*slackMessage, userMap, names map[string]string) []*ImportedMessage {
+	messages := make([]*ImportedMessage, 0, len(raw))
+	for _, m := range raw {
+		if m.Type != "message" {
+			continue
+		}
+		createdAt, err := parseSlackTimestamp(m.Ts)
+		if err != nil {
+			i.logger.Warn("skipping message with invalid timestamp", "channel_id", channelID, "error", err)
+			continue
+		}
+
+		content := convertSlackText(m.Text, names)
+		for _, f := range m.Files {
+			link := f.Permalink
+			if link == "" {
+				link = f.URLPrivate
+			}
+			content = strings.TrimSpace(fmt.Sprintf("%s\n[attachment] %s %s", content, f.Name, link))
+		}
+		if content == "" {
+			continue
+		}
+
+		msgType := "TEXT"
+		var userID *string
+		switch {
+		case systemSubtypes[m.Subtype]:
+			msgType = "SYSTEM"
+		case m.User != "" && userMap[m.User] != "":
+			id := userMap[m.User]
+			userID = &id
+		default:
+			// Keep attribution for senders without an account here, e.g. deactivated users or bots.
+			author := m.Username
+			if name, ok := names[m.User]; ok {
+				author = name
+			}
+			if author != "" {
+				content = fmt.Sprintf("%s: %s", author, content)
+			}
+		}
+
+		messages = append(messages, &ImportedMessage{
+			ID:        i.deterministicID("message", channelID+":"+m.Ts),
+			RoomID:    roomID,
+			UserID:    userID,
+			Content:   content,
+			Type:      msgType,
+			CreatedAt: createdAt,
+		})
+	}
+	return messages
+}
+
+func (i *Importer) deterministicID(kind, sourceID string) string {
+	return uuid.NewSHA1(importNamespace, []byte(fmt.Sprintf("%s:%s:%s", i.source, kind, sourceID))).String()
+}
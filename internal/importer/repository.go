@@ -0,0 +1,13 @@
+package importer
+
+import "context"
+
+// Repository defines the persistence operations the importer needs. Every
+// write must be idempotent so a partially completed run can simply be rerun.
+type Repository interface {
+	FindUserIDsByEmail(ctx context.Context, emails []string) (map[string]string, error)
+	UpsertRoom(ctx context.Context, room *ImportedRoom, members []ImportedMembership) error
+
+	CompletedCheckpoints(ctx context.Context, source string) (map[string]bool, error)
+	ImportMessages(ctx context.Context, source, checkpoint string, messages []*ImportedMessage) (int64, error)
+}
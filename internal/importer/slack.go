@@ -0,0 +1,209 @@
+package importer
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// slackUser is the subset of a users.json entry the importer relies on.
+type slackUser struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted"`
+	IsBot   bool   `json:"is_bot"`
+	Profile struct {
+		Email       string `json:"email"`
+		RealName    string `json:"real_name"`
+		DisplayName string `json:"display_name"`
+	} `json:"profile"`
+}
+
+// DisplayName returns the most human-friendly name Slack recorded for the user.
+func (u *slackUser) DisplayName() string {
+	switch {
+	case u.Profile.DisplayName != "":
+		return u.Profile.DisplayName
+	case u.Profile.RealName != "":
+		return u.Profile.RealName
+	default:
+		return u.Name
+	}
+}
+
+// slackChannel is a channels.json (public) or groups.json (private) entry.
+type slackChannel struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Created int64    `json:"created"`
+	Creator string   `json:"creator"`
+	Members []string `json:"members"`
+	Private bool     `json:"-"`
+}
+
+type slackFile struct {
+	Name       string `json:"name"`
+	URLPrivate string `json:"url_private"`
+	Permalink  string `json:"permalink"`
+}
+
+type slackMessage struct {
+	Type     string      `json:"type"`
+	Subtype  string      `json:"subtype"`
+	User     string      `json:"user"`
+	Username string      `json:"username"`
+	Text     string      `json:"text"`
+	Ts       string      `json:"ts"`
+	Files    []slackFile `json:"files"`
+}
+
+// systemSubtypes are Slack events that map onto SYSTEM messages.
+var systemSubtypes = map[string]bool{
+	"channel_join":    true,
+	"channel_leave":   true,
+	"channel_topic":   true,
+	"channel_purpose": true,
+	"channel_name":    true,
+	"group_join":      true,
+	"group_leave":     true,
+	"group_topic":     true,
+	"group_purpose":   true,
+	"group_name":      true,
+}
+
+var (
+	userRefPattern    = regexp.MustCompile(`<@([A-Z0-9]+)(?:\|[^>]*)?>`)
+	channelRefPattern = regexp.MustCompile(`<#[A-Z0-9]+\|([^>]*)>`)
+	linkRefPattern    = regexp.MustCompile(`<((?:https?|mailto):[^>|]+)(?:\|([^>]*))?>`)
+)
+
+// slackArchive reads a Slack-format export zip, as produced by Slack's
+// workspace export and by Mattermost's Slack-compatible export tooling.
+type slackArchive struct {
+	files map[string]*zip.File
+}
+
+func openSlackArchive(r *zip.Reader) *slackArchive {
+	files := make(map[string]*zip.File, len(r.File))
+	for _, f := range r.File {
+		files[f.Name] = f
+	}
+	return &slackArchive{files: files}
+}
+
+func (a *slackArchive) readJSON(name string, dest any) (bool, error) {
+	f, ok := a.files[name]
+	if !ok {
+		return false, nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return true, nil
+}
+
+func (a *slackArchive) Users() ([]*slackUser, error) {
+	var users []*slackUser
+	found, err := a.readJSON("users.json", &users)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("archive is missing users.json")
+	}
+	return users, nil
+}
+
+// Channels returns public channels followed by private ones.
+func (a *slackArchive) Channels() ([]*slackChannel, error) {
+	var public, private []*slackChannel
+	found, err := a.readJSON("channels.json", &public)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("archive is missing channels.json")
+	}
+	if _, err := a.readJSON("groups.json", &private); err != nil {
+		return nil, err
+	}
+	for _, c := range private {
+		c.Private = true
+	}
+	return append(public, private...), nil
+}
+
+// DayFiles lists a channel's per-day message files in chronological order.
+func (a *slackArchive) DayFiles(channelName string) []string {
+	var names []string
+	for name := range a.files {
+		if path.Dir(name) == channelName && strings.HasSuffix(name, ".json") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (a *slackArchive) Messages(dayFile string) ([]*slackMessage, error) {
+	var messages []*slackMessage
+	if _, err := a.readJSON(dayFile, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// parseSlackTimestamp converts a Slack "ts" value ("1503435956.000247") to a time.
+func parseSlackTimestamp(ts string) (time.Time, error) {
+	secs, frac, _ := strings.Cut(ts, ".")
+	s, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid slack timestamp %q: %w", ts, err)
+	}
+	var micros int64
+	if frac != "" {
+		frac = (frac + "000000")[:6]
+		if micros, err = strconv.ParseInt(frac, 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("invalid slack timestamp %q: %w", ts, err)
+		}
+	}
+	return time.Unix(s, micros*int64(time.Microsecond)).UTC(), nil
+}
+
+// convertSlackText rewrites Slack's markup into plain text with readable mentions and links.
+func convertSlackText(text string, names map[string]string) string {
+	text = userRefPattern.ReplaceAllStringFunc(text, func(ref string) string {
+		id := userRefPattern.FindStringSubmatch(ref)[1]
+		if name, ok := names[id]; ok {
+			return "@" + name
+		}
+		return "@unknown-user"
+	})
+	text = channelRefPattern.ReplaceAllString(text, "#$1")
+	text = linkRefPattern.ReplaceAllStringFunc(text, func(ref string) string {
+		parts := linkRefPattern.FindStringSubmatch(ref)
+		if parts[2] == "" || parts[2] == parts[1] {
+			return parts[1]
+		}
+		return fmt.Sprintf("%s (%s)", parts[2], parts[1])
+	})
+	return html.UnescapeString(text)
+}
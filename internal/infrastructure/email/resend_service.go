@@ -3,6 +3,8 @@ package email
 import (
 	"context"
 	"fmt"
+	"html"
+	"time"
 
 	"github.com/purushothdl/gochat-backend/internal/config"
 	"github.com/resend/resend-go/v2"
@@ -43,4 +45,29 @@ func (s *ResendService) SendPasswordResetEmail(ctx context.Context, recipientEma
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+func (s *ResendService) SendRoomExportReadyEmail(ctx context.Context, recipientEmail, recipientName, roomName, downloadLink string, expiresAt time.Time) error {
+	subject := fmt.Sprintf("Your export of %s is ready", roomName)
+	htmlBody := fmt.Sprintf(`
+		<p>Hi %s,</p>
+		<p>The message history export you requested for <strong>%s</strong> has finished processing.</p>
+		<p><a href="%s">Download Export</a></p>
+		<p>This link expires on %s.</p>
+		<p>Thanks,<br>The GoChat Team</p>
+	`, html.EscapeString(recipientName), html.EscapeString(roomName), downloadLink, expiresAt.UTC().Format("Jan 2, 2006 15:04 MST"))
+
+	params := &resend.SendEmailRequest{
+		From:    fmt.Sprintf("%s <%s>", s.config.FromName, s.config.FromEmail),
+		To:      []string{recipientEmail},
+		Subject: subject,
+		Html:    htmlBody,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		return fmt.Errorf("resend: failed to send export ready email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+)
+
+const HTTPHookName = "external"
+
+// HTTPHook delegates moderation to an external service. The service receives
+// the request as JSON and answers with {"action", "content", "reason"}.
+type HTTPHook struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPHook creates a hook that calls url with the given client, whose
+// timeout bounds how long a send can wait on the external service.
+func NewHTTPHook(url string, client *http.Client) *HTTPHook {
+	return &HTTPHook{url: url, client: client}
+}
+
+type httpHookRequest struct {
+	RoomID    string `json:"room_id"`
+	UserID    string `json:"user_id"`
+	MessageID string `json:"message_id,omitempty"`
+	Content   string `json:"content"`
+}
+
+type httpHookResponse struct {
+	Action  message.ModerationAction `json:"action"`
+	Content string                   `json:"content"`
+	Reason  string                   `json:"reason"`
+}
+
+func (h *HTTPHook) Name() string {
+	return HTTPHookName
+}
+
+func (h *HTTPHook) Moderate(ctx context.Context, req *message.ModerationRequest) (*message.ModerationResult, error) {
+	body, err := json.Marshal(httpHookRequest{
+		RoomID:    req.RoomID,
+		UserID:    req.UserID,
+		MessageID: req.MessageID,
+		Content:   req.Content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal moderation request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build moderation request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("moderation service unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moderation service returned status %d", resp.StatusCode)
+	}
+
+	var result httpHookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode moderation response: %w", err)
+	}
+
+	switch result.Action {
+	case message.ModerationAllow, message.ModerationReject, message.ModerationFlag:
+	case message.ModerationMask:
+		if result.Content == "" {
+			return nil, fmt.Errorf("moderation service masked content without returning a replacement")
+		}
+	default:
+		return nil, fmt.Errorf("moderation service returned unknown action %q", result.Action)
+	}
+
+	return &message.ModerationResult{
+		Action:  result.Action,
+		Content: result.Content,
+		Reason:  result.Reason,
+	}, nil
+}
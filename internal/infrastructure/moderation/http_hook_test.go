@@ -0,0 +1,218 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+const (
+	testRoomID  = "room-1"
+	testContent = "hello there"
+	hookTimeout = 100 * time.Millisecond
+)
+
+// stubModerationServer answers every moderation callout with the given status
+// and body, or never answers when hang is set.
+func stubModerationServer(t *testing.T, status int, body string, hang bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req httpHookRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("moderation request is not valid JSON: %v", err)
+		}
+		if req.RoomID != testRoomID || req.Content != testContent {
+			t.Errorf("moderation request = %+v, want room %q and content %q", req, testRoomID, testContent)
+		}
+
+		if hang {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// The stubs below implement only what PostWebhookMessage uses; any other
+// call panics on the nil embedded interface.
+
+type stubRooms struct {
+	message.RoomProvider
+}
+
+func (stubRooms) GetRoomInfo(ctx context.Context, roomID string) (*types.RoomInfo, error) {
+	return &types.RoomInfo{ID: roomID, Name: "general"}, nil
+}
+
+type stubMessages struct {
+	message.Repository
+	created []*message.Message
+	flags   []*message.MessageFlag
+}
+
+func (r *stubMessages) CreateMessage(ctx context.Context, msg *message.Message, buildEvents ...message.EventBuilder) error {
+	r.created = append(r.created, msg)
+	return nil
+}
+
+func (r *stubMessages) CreateMessageFlags(ctx context.Context, flags []*message.MessageFlag) error {
+	r.flags = append(r.flags, flags...)
+	return nil
+}
+
+type stubDispatcher struct{}
+
+func (stubDispatcher) Dispatch(ctx context.Context, event *types.WebhookEvent) {}
+
+func TestHTTPHookModerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		hang       bool
+		wantErr    bool
+		wantAction message.ModerationAction
+		wantText   string
+	}{
+		{name: "allow", status: http.StatusOK, body: `{"action":"ALLOW"}`, wantAction: message.ModerationAllow},
+		{name: "reject", status: http.StatusOK, body: `{"action":"REJECT","reason":"spam"}`, wantAction: message.ModerationReject},
+		{name: "mask", status: http.StatusOK, body: `{"action":"MASK","content":"hello *****"}`, wantAction: message.ModerationMask, wantText: "hello *****"},
+		{name: "flag", status: http.StatusOK, body: `{"action":"FLAG","reason":"suspicious"}`, wantAction: message.ModerationFlag},
+		{name: "mask without content", status: http.StatusOK, body: `{"action":"MASK"}`, wantErr: true},
+		{name: "unknown action", status: http.StatusOK, body: `{"action":"DELETE"}`, wantErr: true},
+		{name: "non-2xx status", status: http.StatusInternalServerError, body: `{"action":"ALLOW"}`, wantErr: true},
+		{name: "malformed JSON", status: http.StatusOK, body: `{"action":`, wantErr: true},
+		{name: "timeout", hang: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := stubModerationServer(t, tt.status, tt.body, tt.hang)
+			hook := NewHTTPHook(srv.URL, &http.Client{Timeout: hookTimeout})
+
+			result, err := hook.Moderate(context.Background(), &message.ModerationRequest{RoomID: testRoomID, Content: testContent})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Moderate() = %+v, want an error", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Moderate() error = %v", err)
+			}
+			if result.Action != tt.wantAction {
+				t.Errorf("Action = %q, want %q", result.Action, tt.wantAction)
+			}
+			if result.Content != tt.wantText {
+				t.Errorf("Content = %q, want %q", result.Content, tt.wantText)
+			}
+		})
+	}
+}
+
+// TestHTTPHookPipeline runs the hook inside the message service, where
+// FailOpen decides what happens to content when the external service fails.
+func TestHTTPHookPipeline(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		hang      bool
+		failure   bool   // The callout fails rather than returning a verdict
+		rejection string // Expected rejection reason, if the verdict rejects
+		wantText  string
+		wantFlag  string
+	}{
+		{name: "allow", status: http.StatusOK, body: `{"action":"ALLOW"}`, wantText: testContent},
+		{name: "reject", status: http.StatusOK, body: `{"action":"REJECT","reason":"spam"}`, rejection: "spam"},
+		{name: "mask", status: http.StatusOK, body: `{"action":"MASK","content":"hello *****"}`, wantText: "hello *****"},
+		{name: "flag", status: http.StatusOK, body: `{"action":"FLAG","reason":"suspicious"}`, wantText: testContent, wantFlag: "suspicious"},
+		{name: "non-2xx status", status: http.StatusBadGateway, failure: true},
+		{name: "malformed JSON", status: http.StatusOK, body: `not json`, failure: true},
+		{name: "timeout", hang: true, failure: true},
+	}
+
+	for _, failOpen := range []bool{true, false} {
+		for _, tt := range tests {
+			mode := "fail-closed"
+			if failOpen {
+				mode = "fail-open"
+			}
+			t.Run(mode+"/"+tt.name, func(t *testing.T) {
+				srv := stubModerationServer(t, tt.status, tt.body, tt.hang)
+				repo := &stubMessages{}
+				cfg := &config.Config{Moderation: config.ModerationConfig{FailOpen: failOpen}}
+				hooks := []message.ModerationHook{NewHTTPHook(srv.URL, &http.Client{Timeout: hookTimeout})}
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				svc := message.NewService(repo, stubRooms{}, nil, nil, nil, hooks, nil, stubDispatcher{}, cfg, logger)
+
+				msg, err := svc.PostWebhookMessage(context.Background(), message.WebhookPost{
+					RoomID:     testRoomID,
+					WebhookID:  "webhook-1",
+					SenderName: "CI",
+					Content:    testContent,
+				})
+
+				wantText := tt.wantText
+				if tt.failure && failOpen {
+					wantText = testContent
+				}
+				switch {
+				case tt.failure && !failOpen:
+					if err == nil {
+						t.Fatalf("PostWebhookMessage() = %+v, want an error", msg)
+					}
+					if len(repo.created) != 0 {
+						t.Errorf("stored %d messages, want none", len(repo.created))
+					}
+					return
+				case tt.rejection != "":
+					appErr, ok := err.(*errors.AppError)
+					if !ok || appErr.Code != "MESSAGE_REJECTED" || appErr.Message != tt.rejection {
+						t.Fatalf("PostWebhookMessage() error = %v, want MESSAGE_REJECTED %q", err, tt.rejection)
+					}
+					if len(repo.created) != 0 {
+						t.Errorf("stored %d messages, want none", len(repo.created))
+					}
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("PostWebhookMessage() error = %v", err)
+				}
+				if msg.Content != wantText {
+					t.Errorf("Content = %q, want %q", msg.Content, wantText)
+				}
+				if len(repo.created) != 1 {
+					t.Errorf("stored %d messages, want 1", len(repo.created))
+				}
+
+				if tt.wantFlag == "" {
+					if len(repo.flags) != 0 {
+						t.Errorf("recorded %d flags, want none", len(repo.flags))
+					}
+					return
+				}
+				if len(repo.flags) != 1 || repo.flags[0].Hook != HTTPHookName || repo.flags[0].Reason != tt.wantFlag {
+					t.Errorf("flags = %+v, want one %s flag with reason %q", repo.flags, HTTPHookName, tt.wantFlag)
+				}
+			})
+		}
+	}
+}
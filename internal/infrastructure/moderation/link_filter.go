@@ -0,0 +1,77 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+)
+
+const LinkFilterName = "link_filter"
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+
+// LinkFilter rejects messages that link to denied domains or, when an allow
+// list is configured, to any domain not on it. Subdomains match their parent.
+type LinkFilter struct {
+	allowed []string
+	blocked []string
+}
+
+func NewLinkFilter(allowed, blocked []string) *LinkFilter {
+	return &LinkFilter{
+		allowed: normalizeDomains(allowed),
+		blocked: normalizeDomains(blocked),
+	}
+}
+
+func (f *LinkFilter) Name() string {
+	return LinkFilterName
+}
+
+func (f *LinkFilter) Moderate(ctx context.Context, req *message.ModerationRequest) (*message.ModerationResult, error) {
+	for _, link := range linkPattern.FindAllString(req.Content, -1) {
+		host := linkHost(link)
+		if host == "" {
+			continue
+		}
+		if matchesDomain(host, f.blocked) || (len(f.allowed) > 0 && !matchesDomain(host, f.allowed)) {
+			return &message.ModerationResult{
+				Action: message.ModerationReject,
+				Reason: fmt.Sprintf("Links to %s are not allowed in this room.", host),
+			}, nil
+		}
+	}
+	return &message.ModerationResult{Action: message.ModerationAllow}, nil
+}
+
+func linkHost(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+func matchesDomain(host string, domains []string) bool {
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeDomains(domains []string) []string {
+	normalized := make([]string, 0, len(domains))
+	for _, d := range domains {
+		normalized = append(normalized, strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), "www."))
+	}
+	return normalized
+}
@@ -0,0 +1,34 @@
+package moderation
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+)
+
+// NewPipeline builds the configured hooks in the order they run: the cheap
+// local filters first, then the external service if one is configured.
+func NewPipeline(cfg *config.ModerationConfig) ([]message.ModerationHook, error) {
+	var hooks []message.ModerationHook
+
+	if len(cfg.BlockedWords) > 0 || len(cfg.BlockedPatterns) > 0 {
+		action := message.ModerationAction(strings.ToUpper(cfg.WordFilterAction))
+		wordFilter, err := NewWordFilter(cfg.BlockedWords, cfg.BlockedPatterns, action)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, wordFilter)
+	}
+
+	if len(cfg.AllowedLinkDomains) > 0 || len(cfg.BlockedLinkDomains) > 0 {
+		hooks = append(hooks, NewLinkFilter(cfg.AllowedLinkDomains, cfg.BlockedLinkDomains))
+	}
+
+	if cfg.WebhookURL != "" {
+		hooks = append(hooks, NewHTTPHook(cfg.WebhookURL, &http.Client{Timeout: cfg.WebhookTimeout}))
+	}
+
+	return hooks, nil
+}
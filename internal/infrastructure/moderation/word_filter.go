@@ -0,0 +1,72 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/purushothdl/gochat-backend/internal/domain/message"
+)
+
+const WordFilterName = "word_filter"
+
+// WordFilter matches content against a list of blocked words and regular
+// expressions, and masks, rejects or flags anything that matches.
+type WordFilter struct {
+	patterns []*regexp.Regexp
+	action   message.ModerationAction
+}
+
+// NewWordFilter compiles the blocked words (matched as whole words) and
+// patterns. All matching is case-insensitive.
+func NewWordFilter(words, patterns []string, action message.ModerationAction) (*WordFilter, error) {
+	switch action {
+	case message.ModerationMask, message.ModerationReject, message.ModerationFlag:
+	default:
+		return nil, fmt.Errorf("unsupported word filter action %q", action)
+	}
+
+	f := &WordFilter{action: action}
+	for _, w := range words {
+		f.patterns = append(f.patterns, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(w)+`\b`))
+	}
+	for _, p := range patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blocked pattern %q: %w", p, err)
+		}
+		f.patterns = append(f.patterns, re)
+	}
+	return f, nil
+}
+
+func (f *WordFilter) Name() string {
+	return WordFilterName
+}
+
+func (f *WordFilter) Moderate(ctx context.Context, req *message.ModerationRequest) (*message.ModerationResult, error) {
+	matched := false
+	content := req.Content
+	for _, re := range f.patterns {
+		if !re.MatchString(content) {
+			continue
+		}
+		matched = true
+		if f.action != message.ModerationMask {
+			break
+		}
+		content = re.ReplaceAllStringFunc(content, func(m string) string {
+			return strings.Repeat("*", len([]rune(m)))
+		})
+	}
+
+	if !matched {
+		return &message.ModerationResult{Action: message.ModerationAllow}, nil
+	}
+	return &message.ModerationResult{
+		Action:  f.action,
+		Content: content,
+		Reason:  "Message contains blocked language.",
+	}, nil
+}
@@ -0,0 +1,163 @@
+// internal/infrastructure/postgres/audit_repository.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type AuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{pool: pool}
+}
+
+func (r *AuditRepository) CreateEntry(ctx context.Context, e *audit.Entry) error {
+	metadata, err := json.Marshal(e.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit metadata: %w", err)
+	}
+	before, err := marshalAuditState(e.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalAuditState(e.After)
+	if err != nil {
+		return err
+	}
+	var ipAddress *string
+	if e.IPAddress != "" {
+		ipAddress = &e.IPAddress
+	}
+
+	query := `
+        INSERT INTO audit_logs (id, room_id, actor_id, action, target_type, target_id, metadata, before_state, after_state, ip_address)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING created_at
+    `
+	err = r.pool.QueryRow(ctx, query, e.ID, e.RoomID, e.ActorID, e.Action, e.TargetType, e.TargetID, metadata, before, after, ipAddress).Scan(&e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) ListEntries(ctx context.Context, filter audit.ListFilter) ([]*audit.Entry, error) {
+	args := []any{filter.RoomID}
+	conditions := []string{"a.room_id = $1"}
+
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions = append(conditions, fmt.Sprintf("a.action = $%d", len(args)))
+	}
+	if filter.ActorID != "" {
+		args = append(args, filter.ActorID)
+		conditions = append(conditions, fmt.Sprintf("a.actor_id = $%d", len(args)))
+	}
+	if filter.TargetID != "" {
+		args = append(args, filter.TargetID)
+		conditions = append(conditions, fmt.Sprintf("a.target_id = $%d", len(args)))
+	}
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		conditions = append(conditions, fmt.Sprintf("a.created_at >= $%d", len(args)))
+	}
+	if filter.Until != nil {
+		args = append(args, *filter.Until)
+		conditions = append(conditions, fmt.Sprintf("a.created_at < $%d", len(args)))
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(a.created_at, a.id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit)
+	query := `
+        SELECT a.id, a.room_id, a.actor_id, a.action, a.target_type, a.target_id, a.metadata,
+               a.before_state, a.after_state, a.ip_address, a.created_at,
+               u.name, u.image_url, u.is_bot
+        FROM audit_logs a
+        LEFT JOIN users u ON u.id = a.actor_id
+        WHERE ` + strings.Join(conditions, " AND ") + fmt.Sprintf(`
+        ORDER BY a.created_at DESC, a.id DESC
+        LIMIT $%d`, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*audit.Entry
+	for rows.Next() {
+		var e audit.Entry
+		var before, after []byte
+		var ipAddress, actorName, actorImageURL pgtype.Text
+		var actorIsBot pgtype.Bool
+		err := rows.Scan(
+			&e.ID, &e.RoomID, &e.ActorID, &e.Action, &e.TargetType, &e.TargetID, &e.Metadata,
+			&before, &after, &ipAddress, &e.CreatedAt,
+			&actorName, &actorImageURL, &actorIsBot,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if before != nil {
+			if err := json.Unmarshal(before, &e.Before); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal audit before state: %w", err)
+			}
+		}
+		if after != nil {
+			if err := json.Unmarshal(after, &e.After); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal audit after state: %w", err)
+			}
+		}
+		e.IPAddress = ipAddress.String
+		if e.ActorID != nil && actorName.Valid {
+			e.Actor = &types.BasicUser{ID: *e.ActorID, Name: actorName.String, ImageURL: actorImageURL.String, IsBot: actorIsBot.Bool}
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *AuditRepository) PurgeEntriesBatch(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	query := `
+        DELETE FROM audit_logs
+        WHERE id IN (
+            SELECT id FROM audit_logs
+            WHERE created_at < $1
+            ORDER BY created_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	cmdTag, err := r.pool.Exec(ctx, query, cutoff, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge audit entries: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// marshalAuditState encodes a before/after snapshot, storing NULL rather than
+// a JSON null when the action recorded no change set.
+func marshalAuditState(state map[string]any) ([]byte, error) {
+	if state == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit state: %w", err)
+	}
+	return data, nil
+}
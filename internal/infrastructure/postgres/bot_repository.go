@@ -0,0 +1,306 @@
+// internal/infrastructure/postgres/bot_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/domain/bot"
+	"github.com/purushothdl/gochat-backend/internal/domain/workspace"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type BotRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewBotRepository(pool *pgxpool.Pool) *BotRepository {
+	return &BotRepository{pool: pool}
+}
+
+const (
+	botColumns      = `id, bot_owner_id, name, COALESCE(image_url, ''), created_at, updated_at`
+	botTokenColumns = `t.id, t.bot_id, t.name, t.token_hash, t.scopes, t.created_by, t.expires_at, t.last_used_at, t.revoked_at, t.created_at`
+)
+
+// ============================================================================
+// Bot Operations
+// ============================================================================
+
+// CreateBot stores the bot's account and its workspace membership. Bots get a
+// placeholder address under the reserved .invalid domain and no password, so
+// they can never sign in or receive email.
+func (r *BotRepository) CreateBot(ctx context.Context, b *bot.Bot, workspaceID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        INSERT INTO users (id, email, name, image_url, password_hash, is_verified, is_bot, bot_owner_id)
+        VALUES ($1, $2, $3, NULLIF($4, ''), '', TRUE, TRUE, $5)
+        RETURNING created_at, updated_at
+    `
+	email := fmt.Sprintf("bot-%s@bots.invalid", b.ID)
+	if err := tx.QueryRow(ctx, query, b.ID, email, b.Name, b.ImageURL, b.OwnerID).Scan(&b.CreatedAt, &b.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create bot: %w", err)
+	}
+
+	memberQuery := `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(ctx, memberQuery, workspaceID, b.ID, workspace.RoleMember); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" {
+			return workspace.ErrWorkspaceNotFound
+		}
+		return fmt.Errorf("failed to add bot to workspace: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *BotRepository) FindBot(ctx context.Context, botID string) (*bot.Bot, error) {
+	query := `SELECT ` + botColumns + ` FROM users WHERE id = $1 AND is_bot AND deleted_at IS NULL`
+	b, err := scanBot(r.pool.QueryRow(ctx, query, botID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, bot.ErrBotNotFound
+		}
+		return nil, fmt.Errorf("failed to find bot: %w", err)
+	}
+	return b, nil
+}
+
+func (r *BotRepository) ListOwnedBots(ctx context.Context, ownerID string) ([]*bot.Bot, error) {
+	query := `
+        SELECT ` + botColumns + `
+        FROM users
+        WHERE bot_owner_id = $1 AND deleted_at IS NULL
+        ORDER BY created_at DESC, id DESC
+    `
+	rows, err := r.pool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bots: %w", err)
+	}
+	defer rows.Close()
+
+	var bots []*bot.Bot
+	for rows.Next() {
+		b, err := scanBot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bot: %w", err)
+		}
+		bots = append(bots, b)
+	}
+	return bots, rows.Err()
+}
+
+func (r *BotRepository) CountOwnedBots(ctx context.Context, ownerID string) (int, error) {
+	query := `SELECT COUNT(*) FROM users WHERE bot_owner_id = $1 AND deleted_at IS NULL`
+	var count int
+	if err := r.pool.QueryRow(ctx, query, ownerID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count bots: %w", err)
+	}
+	return count, nil
+}
+
+func (r *BotRepository) UpdateBot(ctx context.Context, b *bot.Bot) error {
+	query := `
+        UPDATE users SET name = $2, image_url = NULLIF($3, '')
+        WHERE id = $1 AND is_bot AND deleted_at IS NULL
+        RETURNING updated_at
+    `
+	err := r.pool.QueryRow(ctx, query, b.ID, b.Name, b.ImageURL).Scan(&b.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return bot.ErrBotNotFound
+		}
+		return fmt.Errorf("failed to update bot: %w", err)
+	}
+	return nil
+}
+
+// DeleteBot soft-deletes the bot's account like a closed user account, so its
+// messages keep their sender. Its tokens are revoked and its room and
+// workspace memberships removed. It refuses while the bot owns a room, since
+// the room would be left without an owner.
+func (r *BotRepository) DeleteBot(ctx context.Context, botID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	ownsQuery := `
+        SELECT EXISTS (
+            SELECT 1 FROM room_memberships rm
+            JOIN rooms r ON r.id = rm.room_id
+            WHERE rm.user_id = $1 AND rm.role = 'OWNER' AND r.deleted_at IS NULL
+        )
+    `
+	var ownsRooms bool
+	if err := tx.QueryRow(ctx, ownsQuery, botID).Scan(&ownsRooms); err != nil {
+		return fmt.Errorf("failed to check room ownership: %w", err)
+	}
+	if ownsRooms {
+		return bot.ErrBotOwnsRooms
+	}
+
+	cmdTag, err := tx.Exec(ctx, `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND is_bot AND deleted_at IS NULL`, botID)
+	if err != nil {
+		return fmt.Errorf("failed to delete bot: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return bot.ErrBotNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE bot_tokens SET revoked_at = NOW() WHERE bot_id = $1 AND revoked_at IS NULL`, botID); err != nil {
+		return fmt.Errorf("failed to revoke bot tokens: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM room_memberships WHERE user_id = $1`, botID); err != nil {
+		return fmt.Errorf("failed to remove bot from rooms: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM workspace_members WHERE user_id = $1`, botID); err != nil {
+		return fmt.Errorf("failed to remove bot from workspaces: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ============================================================================
+// Token Operations
+// ============================================================================
+
+func (r *BotRepository) CreateToken(ctx context.Context, token *bot.Token) error {
+	query := `
+        INSERT INTO bot_tokens (id, bot_id, name, token_hash, scopes, created_by, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING created_at
+    `
+	err := r.pool.QueryRow(ctx, query,
+		token.ID, token.BotID, token.Name, token.TokenHash, scopeNames(token.Scopes), token.CreatedBy, token.ExpiresAt,
+	).Scan(&token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create bot token: %w", err)
+	}
+	return nil
+}
+
+func (r *BotRepository) ListTokens(ctx context.Context, botID string) ([]*bot.Token, error) {
+	query := `SELECT ` + botTokenColumns + ` FROM bot_tokens t WHERE t.bot_id = $1 ORDER BY t.created_at DESC, t.id DESC`
+	rows, err := r.pool.Query(ctx, query, botID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bot tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*bot.Token
+	for rows.Next() {
+		token, err := scanBotToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bot token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *BotRepository) CountActiveTokens(ctx context.Context, botID string) (int, error) {
+	query := `
+        SELECT COUNT(*) FROM bot_tokens
+        WHERE bot_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+    `
+	var count int
+	if err := r.pool.QueryRow(ctx, query, botID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count bot tokens: %w", err)
+	}
+	return count, nil
+}
+
+// RevokeToken marks the token revoked. Revoking an already revoked token is a no-op.
+func (r *BotRepository) RevokeToken(ctx context.Context, botID, tokenID string) error {
+	query := `UPDATE bot_tokens SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1 AND bot_id = $2`
+	cmdTag, err := r.pool.Exec(ctx, query, tokenID, botID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke bot token: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return bot.ErrTokenNotFound
+	}
+	return nil
+}
+
+// FindTokenByHash returns the token with its bot's owner, as long as neither
+// the bot nor its owner has been deleted.
+func (r *BotRepository) FindTokenByHash(ctx context.Context, tokenHash string) (*bot.Token, error) {
+	query := `
+        SELECT ` + botTokenColumns + `, b.bot_owner_id
+        FROM bot_tokens t
+        JOIN users b ON b.id = t.bot_id AND b.deleted_at IS NULL
+        JOIN users o ON o.id = b.bot_owner_id AND o.deleted_at IS NULL
+        WHERE t.token_hash = $1
+    `
+	var token bot.Token
+	var scopes []string
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID, &token.BotID, &token.Name, &token.TokenHash, &scopes, &token.CreatedBy,
+		&token.ExpiresAt, &token.LastUsedAt, &token.RevokedAt, &token.CreatedAt,
+		&token.OwnerID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, bot.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to find bot token: %w", err)
+	}
+	token.Scopes = toScopes(scopes)
+	return &token, nil
+}
+
+func (r *BotRepository) TouchToken(ctx context.Context, tokenID string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE bot_tokens SET last_used_at = NOW() WHERE id = $1`, tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to touch bot token: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+func scanBot(row pgx.Row) (*bot.Bot, error) {
+	var b bot.Bot
+	err := row.Scan(&b.ID, &b.OwnerID, &b.Name, &b.ImageURL, &b.CreatedAt, &b.UpdatedAt)
+	return &b, err
+}
+
+func scanBotToken(row pgx.Row) (*bot.Token, error) {
+	var token bot.Token
+	var scopes []string
+	err := row.Scan(
+		&token.ID, &token.BotID, &token.Name, &token.TokenHash, &scopes, &token.CreatedBy,
+		&token.ExpiresAt, &token.LastUsedAt, &token.RevokedAt, &token.CreatedAt,
+	)
+	token.Scopes = toScopes(scopes)
+	return &token, err
+}
+
+// scopeNames converts token scopes to the TEXT[] they are stored as.
+func scopeNames(scopes []types.BotScope) []string {
+	names := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		names = append(names, string(scope))
+	}
+	return names
+}
+
+func toScopes(names []string) []types.BotScope {
+	scopes := make([]types.BotScope, 0, len(names))
+	for _, name := range names {
+		scopes = append(scopes, types.BotScope(name))
+	}
+	return scopes
+}
@@ -0,0 +1,266 @@
+// internal/infrastructure/postgres/command_repository.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/domain/command"
+)
+
+type CommandRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCommandRepository(pool *pgxpool.Pool) *CommandRepository {
+	return &CommandRepository{pool: pool}
+}
+
+const commandColumns = `c.id, c.name, c.description, c.args, c.room_id, c.workspace_id, c.bot_id, c.webhook_id, c.created_by, c.created_at, c.updated_at`
+
+// availableCommandsWhere limits commands to those that can be run in room $1:
+// its own and its workspace's, except those of paused webhooks and of bots
+// that are no longer members. Bot commands always belong to a single room.
+const availableCommandsWhere = `
+        (c.room_id = $1 OR c.workspace_id = (SELECT workspace_id FROM rooms WHERE id = $1))
+          AND (c.webhook_id IS NULL OR EXISTS (
+              SELECT 1 FROM outgoing_webhooks w WHERE w.id = c.webhook_id AND w.is_active
+          ))
+          AND (c.bot_id IS NULL OR EXISTS (
+              SELECT 1 FROM room_memberships rm WHERE rm.room_id = c.room_id AND rm.user_id = c.bot_id
+          ))
+    `
+
+// ============================================================================
+// Command Operations
+// ============================================================================
+
+func (r *CommandRepository) CreateCommand(ctx context.Context, cmd *command.Command) error {
+	args, err := json.Marshal(cmd.Args)
+	if err != nil {
+		return fmt.Errorf("failed to encode command args: %w", err)
+	}
+
+	query := `
+        INSERT INTO commands (id, name, description, args, room_id, workspace_id, bot_id, webhook_id, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING created_at, updated_at
+    `
+	err = r.pool.QueryRow(ctx, query,
+		cmd.ID, cmd.Name, cmd.Description, args, cmd.RoomID, cmd.WorkspaceID, cmd.BotID, cmd.WebhookID, cmd.CreatedBy,
+	).Scan(&cmd.CreatedAt, &cmd.UpdatedAt)
+	if err != nil {
+		// 23505 is a unique_violation on the per-room or per-workspace name index.
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return command.ErrCommandExists
+		}
+		return fmt.Errorf("failed to create command: %w", err)
+	}
+	return nil
+}
+
+func (r *CommandRepository) FindCommand(ctx context.Context, commandID string) (*command.Command, error) {
+	query := `SELECT ` + commandColumns + ` FROM commands c WHERE c.id = $1`
+	cmd, err := scanCommand(r.pool.QueryRow(ctx, query, commandID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, command.ErrCommandNotFound
+		}
+		return nil, fmt.Errorf("failed to find command: %w", err)
+	}
+	return cmd, nil
+}
+
+func (r *CommandRepository) UpdateCommand(ctx context.Context, cmd *command.Command) error {
+	args, err := json.Marshal(cmd.Args)
+	if err != nil {
+		return fmt.Errorf("failed to encode command args: %w", err)
+	}
+
+	query := `
+        UPDATE commands SET name = $2, description = $3, args = $4
+        WHERE id = $1
+        RETURNING updated_at
+    `
+	err = r.pool.QueryRow(ctx, query, cmd.ID, cmd.Name, cmd.Description, args).Scan(&cmd.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return command.ErrCommandNotFound
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return command.ErrCommandExists
+		}
+		return fmt.Errorf("failed to update command: %w", err)
+	}
+	return nil
+}
+
+func (r *CommandRepository) DeleteCommand(ctx context.Context, commandID string) error {
+	cmdTag, err := r.pool.Exec(ctx, `DELETE FROM commands WHERE id = $1`, commandID)
+	if err != nil {
+		return fmt.Errorf("failed to delete command: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return command.ErrCommandNotFound
+	}
+	return nil
+}
+
+func (r *CommandRepository) ListRoomCommands(ctx context.Context, roomID string) ([]*command.Command, error) {
+	query := `
+        SELECT DISTINCT ON (c.name) ` + commandColumns + `
+        FROM commands c
+        WHERE ` + availableCommandsWhere + `
+        ORDER BY c.name, c.room_id IS NULL
+    `
+	return r.queryCommands(ctx, query, roomID)
+}
+
+func (r *CommandRepository) FindRoomCommand(ctx context.Context, roomID, name string) (*command.Command, error) {
+	query := `
+        SELECT ` + commandColumns + `
+        FROM commands c
+        WHERE c.name = $2 AND ` + availableCommandsWhere + `
+        ORDER BY c.room_id IS NULL
+        LIMIT 1
+    `
+	cmd, err := scanCommand(r.pool.QueryRow(ctx, query, roomID, name))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, command.ErrCommandNotFound
+		}
+		return nil, fmt.Errorf("failed to find command: %w", err)
+	}
+	return cmd, nil
+}
+
+func (r *CommandRepository) ListWebhookCommands(ctx context.Context, webhookID string) ([]*command.Command, error) {
+	query := `SELECT ` + commandColumns + ` FROM commands c WHERE c.webhook_id = $1 ORDER BY c.name`
+	return r.queryCommands(ctx, query, webhookID)
+}
+
+func (r *CommandRepository) CountScopeCommands(ctx context.Context, roomID, workspaceID *string) (int, error) {
+	query := `SELECT COUNT(*) FROM commands WHERE room_id = $1`
+	arg := roomID
+	if roomID == nil {
+		query = `SELECT COUNT(*) FROM commands WHERE workspace_id = $1`
+		arg = workspaceID
+	}
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, arg).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count commands: %w", err)
+	}
+	return count, nil
+}
+
+// ============================================================================
+// Invocation Operations
+// ============================================================================
+
+// CreateInvocation stores the invocation and the events that notify its
+// handler together, so a bot is never told about an invocation that was not
+// stored. Expired invocations of the same command are cleared on the way.
+func (r *CommandRepository) CreateInvocation(ctx context.Context, inv *command.Invocation, events ...*contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM command_invocations WHERE command_id = $1 AND expires_at < NOW()`, inv.CommandID); err != nil {
+		return fmt.Errorf("failed to clear expired invocations: %w", err)
+	}
+
+	query := `
+        INSERT INTO command_invocations (id, command_id, room_id, user_id, token_hash, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at
+    `
+	err = tx.QueryRow(ctx, query, inv.ID, inv.CommandID, inv.RoomID, inv.UserID, inv.TokenHash, inv.ExpiresAt).Scan(&inv.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create invocation: %w", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *CommandRepository) ClaimResponse(ctx context.Context, tokenHash string, maxResponses int) (*command.Invocation, error) {
+	query := `
+        UPDATE command_invocations i SET responses = i.responses + 1
+        FROM commands c
+        WHERE c.id = i.command_id AND i.token_hash = $1 AND i.expires_at > NOW() AND i.responses < $2
+        RETURNING i.id, i.command_id, c.name, c.bot_id, c.webhook_id, i.room_id, i.user_id, i.token_hash, i.responses, i.expires_at, i.created_at
+    `
+	var inv command.Invocation
+	err := r.pool.QueryRow(ctx, query, tokenHash, maxResponses).Scan(
+		&inv.ID, &inv.CommandID, &inv.Command, &inv.BotID, &inv.WebhookID, &inv.RoomID, &inv.UserID,
+		&inv.TokenHash, &inv.Responses, &inv.ExpiresAt, &inv.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, command.ErrInvalidResponseToken
+		}
+		return nil, fmt.Errorf("failed to claim command response: %w", err)
+	}
+	return &inv, nil
+}
+
+func (r *CommandRepository) PublishEvents(ctx context.Context, events ...*contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertOutboxEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+func (r *CommandRepository) queryCommands(ctx context.Context, query string, args ...any) ([]*command.Command, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commands: %w", err)
+	}
+	defer rows.Close()
+
+	var cmds []*command.Command
+	for rows.Next() {
+		cmd, err := scanCommand(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan command: %w", err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds, rows.Err()
+}
+
+func scanCommand(row pgx.Row) (*command.Command, error) {
+	var cmd command.Command
+	var args []byte
+	err := row.Scan(
+		&cmd.ID, &cmd.Name, &cmd.Description, &args, &cmd.RoomID, &cmd.WorkspaceID,
+		&cmd.BotID, &cmd.WebhookID, &cmd.CreatedBy, &cmd.CreatedAt, &cmd.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(args, &cmd.Args); err != nil {
+		return nil, fmt.Errorf("failed to decode command args: %w", err)
+	}
+	return &cmd, nil
+}
@@ -0,0 +1,172 @@
+// internal/infrastructure/postgres/export_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/domain/export"
+)
+
+type ExportRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewExportRepository(pool *pgxpool.Pool) *ExportRepository {
+	return &ExportRepository{pool: pool}
+}
+
+// ============================================================================
+// Export Job Operations
+// ============================================================================
+
+func (r *ExportRepository) CreateExport(ctx context.Context, e *export.Export) error {
+	query := `
+        INSERT INTO room_exports (id, room_id, requested_by, format, status)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING created_at, updated_at
+    `
+	err := r.pool.QueryRow(ctx, query, e.ID, e.RoomID, e.RequestedBy, e.Format, e.Status).Scan(
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create export: %w", err)
+	}
+	return nil
+}
+
+func (r *ExportRepository) GetExport(ctx context.Context, exportID string) (*export.Export, error) {
+	query := `
+        SELECT id, room_id, requested_by, format, status, total_messages, processed_messages,
+               file_key, error, created_at, updated_at, completed_at, expires_at
+        FROM room_exports
+        WHERE id = $1
+    `
+	e, err := scanExport(r.pool.QueryRow(ctx, query, exportID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, export.ErrExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get export: %w", err)
+	}
+	return e, nil
+}
+
+func (r *ExportRepository) ListRoomExports(ctx context.Context, roomID string, limit int) ([]*export.Export, error) {
+	query := `
+        SELECT id, room_id, requested_by, format, status, total_messages, processed_messages,
+               file_key, error, created_at, updated_at, completed_at, expires_at
+        FROM room_exports
+        WHERE room_id = $1
+        ORDER BY created_at DESC
+        LIMIT $2
+    `
+	rows, err := r.pool.Query(ctx, query, roomID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list room exports: %w", err)
+	}
+	defer rows.Close()
+
+	var exports []*export.Export
+	for rows.Next() {
+		e, err := scanExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan export: %w", err)
+		}
+		exports = append(exports, e)
+	}
+	return exports, nil
+}
+
+func (r *ExportRepository) MarkProcessing(ctx context.Context, exportID string, totalMessages int) error {
+	query := `UPDATE room_exports SET status = 'PROCESSING', total_messages = $2, processed_messages = 0 WHERE id = $1`
+	_, err := r.pool.Exec(ctx, query, exportID, totalMessages)
+	return err
+}
+
+func (r *ExportRepository) UpdateProgress(ctx context.Context, exportID string, processedMessages int) error {
+	query := `UPDATE room_exports SET processed_messages = $2 WHERE id = $1`
+	_, err := r.pool.Exec(ctx, query, exportID, processedMessages)
+	return err
+}
+
+func (r *ExportRepository) MarkCompleted(ctx context.Context, exportID, fileKey string, expiresAt time.Time) error {
+	query := `
+        UPDATE room_exports
+        SET status = 'COMPLETED', file_key = $2, expires_at = $3, completed_at = NOW(), error = NULL
+        WHERE id = $1
+    `
+	_, err := r.pool.Exec(ctx, query, exportID, fileKey, expiresAt)
+	return err
+}
+
+func (r *ExportRepository) MarkFailed(ctx context.Context, exportID, reason string) error {
+	query := `UPDATE room_exports SET status = 'FAILED', error = $2, completed_at = NOW() WHERE id = $1`
+	_, err := r.pool.Exec(ctx, query, exportID, reason)
+	return err
+}
+
+// ============================================================================
+// Message Reads
+// ============================================================================
+
+func (r *ExportRepository) CountRoomMessages(ctx context.Context, roomID string) (int, error) {
+	query := `SELECT COUNT(*) FROM messages WHERE room_id = $1`
+	var count int
+	if err := r.pool.QueryRow(ctx, query, roomID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count room messages: %w", err)
+	}
+	return count, nil
+}
+
+// ListMessagesForExport pages through a room's messages oldest first using a (created_at, id) keyset.
+func (r *ExportRepository) ListMessagesForExport(ctx context.Context, roomID string, after *export.ExportCursor, limit int) ([]*export.ExportedMessage, error) {
+	query := `
+        SELECT m.id, m.user_id, COALESCE(u.name, m.sender_name), m.content, m.type, m.created_at, m.updated_at, m.deleted_at
+        FROM messages m
+        LEFT JOIN users u ON m.user_id = u.id
+        WHERE m.room_id = $1
+          AND ($2::timestamptz IS NULL OR (m.created_at, m.id) > ($2, $3::uuid))
+        ORDER BY m.created_at ASC, m.id ASC
+        LIMIT $4
+    `
+	var afterTime *time.Time
+	var afterID *string
+	if after != nil {
+		afterTime = &after.CreatedAt
+		afterID = &after.ID
+	}
+
+	rows, err := r.pool.Query(ctx, query, roomID, afterTime, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages for export: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*export.ExportedMessage
+	for rows.Next() {
+		var m export.ExportedMessage
+		if err := rows.Scan(&m.ID, &m.SenderID, &m.SenderName, &m.Content, &m.Type, &m.CreatedAt, &m.UpdatedAt, &m.DeletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan exported message: %w", err)
+		}
+		messages = append(messages, &m)
+	}
+	return messages, nil
+}
+
+// ============================================================================
+// Private Helpers
+// ============================================================================
+
+func scanExport(row pgx.Row) (*export.Export, error) {
+	var e export.Export
+	err := row.Scan(
+		&e.ID, &e.RoomID, &e.RequestedBy, &e.Format, &e.Status, &e.TotalMessages, &e.ProcessedMessages,
+		&e.FileKey, &e.Error, &e.CreatedAt, &e.UpdatedAt, &e.CompletedAt, &e.ExpiresAt,
+	)
+	return &e, err
+}
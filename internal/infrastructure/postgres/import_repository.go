@@ -0,0 +1,162 @@
+// internal/infrastructure/postgres/import_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/importer"
+)
+
+type ImportRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImportRepository(pool *pgxpool.Pool) *ImportRepository {
+	return &ImportRepository{pool: pool}
+}
+
+func (r *ImportRepository) FindUserIDsByEmail(ctx context.Context, emails []string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(emails) == 0 {
+		return result, nil
+	}
+
+	query := `
+        SELECT LOWER(email), id
+        FROM users
+        WHERE LOWER(email) = ANY($1) AND deleted_at IS NULL
+    `
+	rows, err := r.pool.Query(ctx, query, emails)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users by email: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var email, id string
+		if err := rows.Scan(&email, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		result[email] = id
+	}
+	return result, rows.Err()
+}
+
+// UpsertRoom creates the room and its memberships if they do not exist yet.
+// Existing rows are left untouched so reruns never clobber later edits.
+func (r *ImportRepository) UpsertRoom(ctx context.Context, room *importer.ImportedRoom, members []importer.ImportedMembership) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Imported rooms land in the default workspace, where sign-ups land too.
+	roomQuery := `
+        INSERT INTO rooms (id, workspace_id, name, type, created_at, updated_at)
+        VALUES ($1, (SELECT id FROM workspaces WHERE is_default), $2, $3, $4, $4)
+        ON CONFLICT (id) DO NOTHING
+    `
+	if _, err := tx.Exec(ctx, roomQuery, room.ID, room.Name, room.Type, room.CreatedAt); err != nil {
+		return fmt.Errorf("failed to insert room: %w", err)
+	}
+
+	memberQuery := `
+        INSERT INTO room_memberships (room_id, user_id, role)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (room_id, user_id) DO NOTHING
+    `
+	batch := &pgx.Batch{}
+	for _, m := range members {
+		batch.Queue(memberQuery, room.ID, m.UserID, m.Role)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to insert memberships: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ImportRepository) CompletedCheckpoints(ctx context.Context, source string) (map[string]bool, error) {
+	rows, err := r.pool.Query(ctx, `SELECT item FROM import_checkpoints WHERE source = $1`, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[string]bool)
+	for rows.Next() {
+		var item string
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("failed to scan checkpoint: %w", err)
+		}
+		done[item] = true
+	}
+	return done, rows.Err()
+}
+
+// ImportMessages bulk-loads a batch through a COPY into a staging table, then
+// inserts it into messages and records the checkpoint in the same transaction.
+// Messages whose IDs already exist are skipped, keeping partial reruns safe.
+func (r *ImportRepository) ImportMessages(ctx context.Context, source, checkpoint string, messages []*importer.ImportedMessage) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	stagingQuery := `
+        CREATE TEMP TABLE import_messages_staging (
+            id UUID,
+            room_id UUID,
+            user_id UUID,
+            content TEXT,
+            type TEXT,
+            created_at TIMESTAMPTZ
+        ) ON COMMIT DROP
+    `
+	if _, err := tx.Exec(ctx, stagingQuery); err != nil {
+		return 0, fmt.Errorf("failed to create staging table: %w", err)
+	}
+
+	rows := make([][]any, 0, len(messages))
+	for _, m := range messages {
+		rows = append(rows, []any{m.ID, m.RoomID, m.UserID, m.Content, m.Type, m.CreatedAt})
+	}
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"import_messages_staging"},
+		[]string{"id", "room_id", "user_id", "content", "type", "created_at"},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy messages: %w", err)
+	}
+
+	insertQuery := `
+        INSERT INTO messages (id, room_id, user_id, content, type, created_at, updated_at)
+        SELECT id, room_id, user_id, content, type::message_type, created_at, created_at
+        FROM import_messages_staging
+        ON CONFLICT (id) DO NOTHING
+    `
+	tag, err := tx.Exec(ctx, insertQuery)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert messages: %w", err)
+	}
+
+	checkpointQuery := `
+        INSERT INTO import_checkpoints (source, item)
+        VALUES ($1, $2)
+        ON CONFLICT (source, item) DO NOTHING
+    `
+	if _, err := tx.Exec(ctx, checkpointQuery, source, checkpoint); err != nil {
+		return 0, fmt.Errorf("failed to record checkpoint: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit import batch: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
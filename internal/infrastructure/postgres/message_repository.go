@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/purushothdl/gochat-backend/internal/domain/message"
@@ -26,20 +27,51 @@ func NewMessageRepository(pool *pgxpool.Pool) *MessageRepository {
 // Message Operations
 // ============================================================================
 
-func (r *MessageRepository) CreateMessage(ctx context.Context, msg *message.Message) error {
+// CreateMessage inserts a message and one outbox event per builder in buildEvents, all in one transaction.
+func (r *MessageRepository) CreateMessage(ctx context.Context, msg *message.Message, buildEvents ...message.EventBuilder) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-        INSERT INTO messages (id, room_id, user_id, content, type)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO messages (id, room_id, user_id, content, type, client_message_id, webhook_id, sender_name, sender_avatar_url)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
         RETURNING created_at, updated_at
     `
-	return r.pool.QueryRow(ctx, query, msg.ID, msg.RoomID, msg.UserID, msg.Content, msg.Type).Scan(
+	err = tx.QueryRow(ctx, query,
+		msg.ID, msg.RoomID, msg.UserID, msg.Content, msg.Type, msg.ClientMessageID, msg.WebhookID, msg.SenderName, msg.SenderAvatarURL,
+	).Scan(
 		&msg.CreatedAt,
 		&msg.UpdatedAt,
 	)
+	if err != nil {
+		// A unique violation on the client id index means a concurrent retry already stored this message.
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_messages_client_message_id" {
+			return message.ErrDuplicateClientMessage
+		}
+		return err
+	}
+
+	for _, buildEvent := range buildEvents {
+		if buildEvent == nil {
+			continue
+		}
+		event, err := buildEvent(msg)
+		if err != nil {
+			return fmt.Errorf("failed to build message event: %w", err)
+		}
+		if err := insertOutboxEvents(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *MessageRepository) GetMessageByID(ctx context.Context, messageID string) (*message.Message, error) {
-	query := `SELECT id, room_id, user_id, content, type, created_at, updated_at, deleted_at FROM messages WHERE id = $1`
+	query := `SELECT id, room_id, user_id, content, type, client_message_id, webhook_id, sender_name, sender_avatar_url, created_at, updated_at, deleted_at FROM messages WHERE id = $1`
 	row := r.pool.QueryRow(ctx, query, messageID)
 	msg, err := scanMessage(row)
 	if err != nil {
@@ -51,12 +83,31 @@ func (r *MessageRepository) GetMessageByID(ctx context.Context, messageID string
 	return msg, nil
 }
 
+// GetMessageByClientID finds a message a sender previously stored under their own client-generated id.
+func (r *MessageRepository) GetMessageByClientID(ctx context.Context, roomID, userID, clientMessageID string) (*message.Message, error) {
+	query := `
+        SELECT id, room_id, user_id, content, type, client_message_id, webhook_id, sender_name, sender_avatar_url, created_at, updated_at, deleted_at
+        FROM messages
+        WHERE room_id = $1 AND user_id = $2 AND client_message_id = $3
+    `
+	row := r.pool.QueryRow(ctx, query, roomID, userID, clientMessageID)
+	msg, err := scanMessage(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, message.ErrMessageNotFound
+		}
+		return nil, err
+	}
+	return msg, nil
+}
+
 func (r *MessageRepository) ListMessagesByRoom(ctx context.Context, roomID, userID string, cursor message.PaginationCursor) ([]*message.MessageWithSeenFlag, error) {
 	query := `
         SELECT
-            m.id, m.room_id, m.user_id, m.content, m.type, m.created_at, m.updated_at, m.deleted_at,
+            m.id, m.room_id, m.user_id, m.content, m.type, m.client_message_id,
+            m.webhook_id, m.sender_name, m.sender_avatar_url, m.created_at, m.updated_at, m.deleted_at,
             CASE WHEN mr.message_id IS NOT NULL THEN TRUE ELSE FALSE END as is_seen_by_user,
-            u.id as sender_id, u.name as sender_name, u.image_url as sender_image_url
+            u.id as sender_id, u.name as sender_name, u.image_url as sender_image_url, u.is_bot as sender_is_bot
         FROM messages m
         LEFT JOIN users u ON m.user_id = u.id
         LEFT JOIN message_read_receipts mr ON m.id = mr.message_id AND mr.user_id = $1
@@ -78,11 +129,13 @@ func (r *MessageRepository) ListMessagesByRoom(ctx context.Context, roomID, user
 		var msg message.MessageWithSeenFlag
 		var sender types.BasicUser
 		var senderID, senderName, senderImageURL pgtype.Text 
+		var senderIsBot pgtype.Bool
 
 		err := rows.Scan(
-			&msg.ID, &msg.RoomID, &msg.UserID, &msg.Content, &msg.Type, &msg.CreatedAt, &msg.UpdatedAt, &msg.DeletedAt,
+			&msg.ID, &msg.RoomID, &msg.UserID, &msg.Content, &msg.Type, &msg.ClientMessageID,
+			&msg.WebhookID, &msg.SenderName, &msg.SenderAvatarURL, &msg.CreatedAt, &msg.UpdatedAt, &msg.DeletedAt,
 			&msg.IsSeenByUser,
-			&senderID, &senderName, &senderImageURL,
+			&senderID, &senderName, &senderImageURL, &senderIsBot,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan message with seen flag: %w", err)
@@ -92,6 +145,7 @@ func (r *MessageRepository) ListMessagesByRoom(ctx context.Context, roomID, user
 			sender.ID = senderID.String
 			sender.Name = senderName.String
 			sender.ImageURL = senderImageURL.String
+			sender.IsBot = senderIsBot.Bool
 			msg.User = &sender
 		}
 		messages = append(messages, &msg)
@@ -115,6 +169,22 @@ func (r *MessageRepository) UpdateMessage(ctx context.Context, messageID, conten
 	return err
 }
 
+// CreateMessageFlags stores the moderation flags raised for a message.
+func (r *MessageRepository) CreateMessageFlags(ctx context.Context, flags []*message.MessageFlag) error {
+	query := `
+        INSERT INTO message_flags (id, message_id, room_id, hook, reason)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	batch := &pgx.Batch{}
+	for _, f := range flags {
+		batch.Queue(query, f.ID, f.MessageID, f.RoomID, f.Hook, f.Reason)
+	}
+	if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to create message flags: %w", err)
+	}
+	return nil
+}
+
 // ============================================================================
 // Deletion Operations
 // ============================================================================
@@ -195,12 +265,33 @@ func (r *MessageRepository) GetMessageReceipts(ctx context.Context, messageID st
 	return receipts, nil
 }
 
+// ============================================================================
+// Provider Methods (for other domains)
+// ============================================================================
+
+// GetMessageInfo provides minimal, shared message data for other services.
+func (r *MessageRepository) GetMessageInfo(ctx context.Context, messageID string) (*types.MessageInfo, error) {
+	query := `SELECT id, room_id, user_id, content, deleted_at FROM messages WHERE id = $1`
+	var info types.MessageInfo
+	err := r.pool.QueryRow(ctx, query, messageID).Scan(&info.ID, &info.RoomID, &info.UserID, &info.Content, &info.DeletedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, message.ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get message info: %w", err)
+	}
+	return &info, nil
+}
+
 // ============================================================================
 // Private Helpers
 // ============================================================================
 
 func scanMessage(row pgx.Row) (*message.Message, error) {
 	var m message.Message
-	err := row.Scan(&m.ID, &m.RoomID, &m.UserID, &m.Content, &m.Type, &m.CreatedAt, &m.UpdatedAt, &m.DeletedAt)
+	err := row.Scan(
+		&m.ID, &m.RoomID, &m.UserID, &m.Content, &m.Type, &m.ClientMessageID,
+		&m.WebhookID, &m.SenderName, &m.SenderAvatarURL, &m.CreatedAt, &m.UpdatedAt, &m.DeletedAt,
+	)
 	return &m, err
 }
\ No newline at end of file
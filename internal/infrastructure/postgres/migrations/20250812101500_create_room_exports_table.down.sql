@@ -0,0 +1,8 @@
+-- Rollback migration: create_room_exports_table
+-- Created at: 2025-08-12T10:15:00+05:30
+
+DROP TRIGGER IF EXISTS update_room_exports_updated_at ON room_exports;
+DROP INDEX IF EXISTS idx_room_exports_room_id_created_at;
+DROP TABLE IF EXISTS room_exports;
+DROP TYPE IF EXISTS export_status;
+DROP TYPE IF EXISTS export_format;
@@ -0,0 +1,29 @@
+-- Migration: create_room_exports_table
+-- Created at: 2025-08-12T10:15:00+05:30
+
+-- Tracks asynchronous room history export jobs and their progress.
+CREATE TYPE export_format AS ENUM ('JSONL', 'HTML');
+CREATE TYPE export_status AS ENUM ('PENDING', 'PROCESSING', 'COMPLETED', 'FAILED');
+
+CREATE TABLE room_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    format export_format NOT NULL,
+    status export_status NOT NULL DEFAULT 'PENDING',
+    total_messages INT NOT NULL DEFAULT 0,
+    processed_messages INT NOT NULL DEFAULT 0,
+    file_key TEXT,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+
+-- Index for listing a room's exports, newest first.
+CREATE INDEX idx_room_exports_room_id_created_at ON room_exports(room_id, created_at DESC);
+
+CREATE TRIGGER update_room_exports_updated_at
+BEFORE UPDATE ON room_exports
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback migration: create_import_checkpoints_table
+-- Created at: 2025-08-13T09:30:00+05:30
+
+DROP TABLE IF EXISTS import_checkpoints;
@@ -0,0 +1,11 @@
+-- Migration: create_import_checkpoints_table
+-- Created at: 2025-08-13T09:30:00+05:30
+
+-- Records which units of an external import (e.g. a channel's day file) have
+-- been committed, so an interrupted import can resume where it stopped.
+CREATE TABLE import_checkpoints (
+    source TEXT NOT NULL,
+    item TEXT NOT NULL,
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source, item)
+);
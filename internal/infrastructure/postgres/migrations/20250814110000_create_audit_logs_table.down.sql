@@ -0,0 +1,6 @@
+-- Rollback migration: create_audit_logs_table
+-- Created at: 2025-08-14T11:00:00+05:30
+
+DROP INDEX IF EXISTS idx_audit_logs_actor_id;
+DROP INDEX IF EXISTS idx_audit_logs_room_id_created_at;
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,17 @@
+-- Migration: create_audit_logs_table
+-- Created at: 2025-08-14T11:00:00+05:30
+
+-- Append-only record of administrative and automated actions.
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    room_id UUID REFERENCES rooms(id) ON DELETE SET NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL for system actions
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_room_id_created_at ON audit_logs(room_id, created_at DESC);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id);
@@ -0,0 +1,5 @@
+-- Rollback migration: add_retention_days_to_rooms
+-- Created at: 2025-08-14T11:05:00+05:30
+
+ALTER TABLE rooms
+DROP COLUMN IF EXISTS retention_days;
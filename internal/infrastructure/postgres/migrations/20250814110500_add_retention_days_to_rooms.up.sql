@@ -0,0 +1,6 @@
+-- Migration: add_retention_days_to_rooms
+-- Created at: 2025-08-14T11:05:00+05:30
+
+-- Per-room message retention window in days. NULL falls back to the global default.
+ALTER TABLE rooms
+ADD COLUMN retention_days INT CHECK (retention_days > 0);
@@ -0,0 +1,5 @@
+-- Rollback migration: add_slow_mode_to_rooms
+-- Created at: 2025-08-15T09:00:00+05:30
+
+ALTER TABLE rooms
+DROP COLUMN IF EXISTS slow_mode_seconds;
@@ -0,0 +1,6 @@
+-- Migration: add_slow_mode_to_rooms
+-- Created at: 2025-08-15T09:00:00+05:30
+
+-- Minimum number of seconds a non-admin member must wait between messages. 0 disables slow mode.
+ALTER TABLE rooms
+ADD COLUMN slow_mode_seconds INT NOT NULL DEFAULT 0 CHECK (slow_mode_seconds >= 0);
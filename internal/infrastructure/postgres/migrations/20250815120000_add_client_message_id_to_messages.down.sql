@@ -0,0 +1,7 @@
+-- Rollback migration: add_client_message_id_to_messages
+-- Created at: 2025-08-15T12:00:00+05:30
+
+DROP INDEX IF EXISTS idx_messages_client_message_id;
+
+ALTER TABLE messages
+DROP COLUMN IF EXISTS client_message_id;
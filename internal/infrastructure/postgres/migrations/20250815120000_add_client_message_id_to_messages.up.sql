@@ -0,0 +1,11 @@
+-- Migration: add_client_message_id_to_messages
+-- Created at: 2025-08-15T12:00:00+05:30
+
+-- Optional sender-generated id that lets clients retry a send without creating duplicates.
+ALTER TABLE messages
+ADD COLUMN client_message_id VARCHAR(64);
+
+-- A client id is unique per sender within a room; messages without one are unconstrained.
+CREATE UNIQUE INDEX idx_messages_client_message_id
+ON messages(room_id, user_id, client_message_id)
+WHERE client_message_id IS NOT NULL;
@@ -0,0 +1,9 @@
+-- Rollback migration: add_content_moderation
+-- Created at: 2025-08-16T10:00:00+05:30
+
+DROP INDEX IF EXISTS idx_message_flags_message_id;
+DROP INDEX IF EXISTS idx_message_flags_room_id_pending;
+DROP TABLE IF EXISTS message_flags;
+
+ALTER TABLE rooms
+DROP COLUMN IF EXISTS moderation_settings;
@@ -0,0 +1,21 @@
+-- Migration: add_content_moderation
+-- Created at: 2025-08-16T10:00:00+05:30
+
+-- Per-room moderation hook toggles, keyed by hook name. Hooks absent from the map run by default.
+ALTER TABLE rooms
+ADD COLUMN moderation_settings JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Messages a moderation hook allowed through but marked for review.
+CREATE TABLE message_flags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    hook TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMPTZ
+);
+
+-- Index for listing a room's unreviewed flags.
+CREATE INDEX idx_message_flags_room_id_pending ON message_flags(room_id, created_at DESC) WHERE reviewed_at IS NULL;
+CREATE INDEX idx_message_flags_message_id ON message_flags(message_id);
@@ -0,0 +1,6 @@
+-- Rollback migration: add_moderation_to_users
+-- Created at: 2025-08-17T10:00:00+05:30
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS suspended_until,
+DROP COLUMN IF EXISTS is_admin;
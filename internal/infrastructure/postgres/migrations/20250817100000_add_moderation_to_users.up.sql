@@ -0,0 +1,7 @@
+-- Migration: add_moderation_to_users
+-- Created at: 2025-08-17T10:00:00+05:30
+
+-- System admins can act on any report; suspended accounts cannot sign in until suspended_until passes.
+ALTER TABLE users
+ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN suspended_until TIMESTAMPTZ;
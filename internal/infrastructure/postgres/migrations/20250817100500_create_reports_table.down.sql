@@ -0,0 +1,12 @@
+-- Rollback migration: create_reports_table
+-- Created at: 2025-08-17T10:05:00+05:30
+
+DROP TRIGGER IF EXISTS update_reports_updated_at ON reports;
+DROP INDEX IF EXISTS idx_reports_room_id_created_at;
+DROP INDEX IF EXISTS idx_reports_status_created_at;
+DROP INDEX IF EXISTS idx_reports_open_user;
+DROP INDEX IF EXISTS idx_reports_open_message;
+DROP TABLE IF EXISTS reports;
+DROP TYPE IF EXISTS report_status;
+DROP TYPE IF EXISTS report_reason;
+DROP TYPE IF EXISTS report_target;
@@ -0,0 +1,40 @@
+-- Migration: create_reports_table
+-- Created at: 2025-08-17T10:05:00+05:30
+
+CREATE TYPE report_target AS ENUM ('MESSAGE', 'USER');
+CREATE TYPE report_reason AS ENUM ('SPAM', 'HARASSMENT', 'HATE_SPEECH', 'SEXUAL_CONTENT', 'VIOLENCE', 'IMPERSONATION', 'OTHER');
+CREATE TYPE report_status AS ENUM ('OPEN', 'IN_REVIEW', 'RESOLVED', 'DISMISSED');
+
+-- User-submitted reports against messages or accounts, worked through by moderators.
+CREATE TABLE reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reporter_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    target_type report_target NOT NULL,
+    message_id UUID REFERENCES messages(id) ON DELETE SET NULL,
+    reported_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    room_id UUID REFERENCES rooms(id) ON DELETE SET NULL, -- NULL for account reports made outside a room
+    reason report_reason NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    status report_status NOT NULL DEFAULT 'OPEN',
+    assignee_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolution_action TEXT,
+    resolution_note TEXT,
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A reporter may only have one unresolved report against the same message or account.
+CREATE UNIQUE INDEX idx_reports_open_message ON reports(reporter_id, message_id)
+WHERE target_type = 'MESSAGE' AND status IN ('OPEN', 'IN_REVIEW');
+CREATE UNIQUE INDEX idx_reports_open_user ON reports(reporter_id, reported_user_id)
+WHERE target_type = 'USER' AND status IN ('OPEN', 'IN_REVIEW');
+
+-- Indexes for the moderation queue, newest first.
+CREATE INDEX idx_reports_status_created_at ON reports(status, created_at DESC, id DESC);
+CREATE INDEX idx_reports_room_id_created_at ON reports(room_id, created_at DESC, id DESC);
+
+CREATE TRIGGER update_reports_updated_at
+BEFORE UPDATE ON reports
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Rollback migration: create_outbox_events_table
+-- Created at: 2025-08-18T09:00:00+05:30
+
+DROP TRIGGER IF EXISTS outbox_events_notify ON outbox_events;
+DROP FUNCTION IF EXISTS notify_outbox_event();
+DROP INDEX IF EXISTS idx_outbox_events_published_at;
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+DROP TABLE IF EXISTS outbox_events;
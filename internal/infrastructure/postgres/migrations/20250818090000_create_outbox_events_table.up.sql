@@ -0,0 +1,34 @@
+-- Migration: create_outbox_events_table
+-- Created at: 2025-08-18T09:00:00+05:30
+
+-- Real-time events written alongside the domain change they describe and
+-- relayed to Redis Pub/Sub by the outbox worker.
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY,
+    channel TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The relay only ever scans undelivered events that are due.
+CREATE INDEX idx_outbox_events_pending ON outbox_events(next_attempt_at, created_at)
+WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events(published_at)
+WHERE published_at IS NOT NULL;
+
+-- Wake the relay as soon as an event commits instead of waiting for its next poll.
+CREATE OR REPLACE FUNCTION notify_outbox_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('outbox_events', '');
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER outbox_events_notify
+AFTER INSERT ON outbox_events
+FOR EACH STATEMENT EXECUTE FUNCTION notify_outbox_event();
@@ -0,0 +1,8 @@
+-- Rollback migration: create_room_invitations_table
+-- Created at: 2025-08-18T12:00:00+05:30
+
+DROP TRIGGER IF EXISTS update_room_invitations_updated_at ON room_invitations;
+DROP INDEX IF EXISTS idx_room_invitations_invitee_status;
+DROP INDEX IF EXISTS idx_room_invitations_pending;
+DROP TABLE IF EXISTS room_invitations;
+DROP TYPE IF EXISTS invitation_status;
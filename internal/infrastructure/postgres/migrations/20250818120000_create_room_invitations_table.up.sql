@@ -0,0 +1,28 @@
+-- Migration: create_room_invitations_table
+-- Created at: 2025-08-18T12:00:00+05:30
+
+CREATE TYPE invitation_status AS ENUM ('PENDING', 'ACCEPTED', 'DECLINED', 'REVOKED', 'EXPIRED');
+
+-- Invitations to join a room, which the invitee must accept before becoming a member.
+-- A PENDING invitation past expires_at is treated as expired and is marked EXPIRED
+-- once a new invitation replaces it.
+CREATE TABLE room_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    inviter_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    invitee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status invitation_status NOT NULL DEFAULT 'PENDING',
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A user can have at most one pending invitation per room.
+CREATE UNIQUE INDEX idx_room_invitations_pending ON room_invitations(room_id, invitee_id)
+WHERE status = 'PENDING';
+CREATE INDEX idx_room_invitations_invitee_status ON room_invitations(invitee_id, status);
+
+CREATE TRIGGER update_room_invitations_updated_at
+BEFORE UPDATE ON room_invitations
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
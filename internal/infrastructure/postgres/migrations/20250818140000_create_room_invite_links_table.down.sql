@@ -0,0 +1,6 @@
+-- Rollback migration: create_room_invite_links_table
+-- Created at: 2025-08-18T14:00:00+05:30
+
+DROP TRIGGER IF EXISTS update_room_invite_links_updated_at ON room_invite_links;
+DROP INDEX IF EXISTS idx_room_invite_links_room_id;
+DROP TABLE IF EXISTS room_invite_links;
@@ -0,0 +1,25 @@
+-- Migration: create_room_invite_links_table
+-- Created at: 2025-08-18T14:00:00+05:30
+
+-- Shareable links that let anyone holding the token join a room. Only the
+-- SHA-256 hash of the token is stored. A link stops working once it is
+-- revoked, expires, or has been used max_uses times.
+CREATE TABLE room_invite_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    role member_role NOT NULL DEFAULT 'MEMBER',
+    max_uses INTEGER CHECK (max_uses IS NULL OR max_uses > 0),
+    use_count INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_room_invite_links_room_id ON room_invite_links(room_id, created_at DESC);
+
+CREATE TRIGGER update_room_invite_links_updated_at
+BEFORE UPDATE ON room_invite_links
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,23 @@
+-- Rollback migration: add_owner_moderator_roles
+-- Created at: 2025-08-19T09:00:00+05:30
+
+-- Postgres cannot drop enum values, so the type is rebuilt without them.
+-- Owners fall back to ADMIN and moderators to MEMBER.
+ALTER TYPE member_role RENAME TO member_role_old;
+CREATE TYPE member_role AS ENUM ('ADMIN', 'MEMBER');
+
+ALTER TABLE room_memberships
+    ALTER COLUMN role DROP DEFAULT,
+    ALTER COLUMN role TYPE member_role USING (
+        CASE role::text WHEN 'OWNER' THEN 'ADMIN' WHEN 'MODERATOR' THEN 'MEMBER' ELSE role::text END
+    )::member_role,
+    ALTER COLUMN role SET DEFAULT 'MEMBER';
+
+ALTER TABLE room_invite_links
+    ALTER COLUMN role DROP DEFAULT,
+    ALTER COLUMN role TYPE member_role USING (
+        CASE role::text WHEN 'OWNER' THEN 'ADMIN' WHEN 'MODERATOR' THEN 'MEMBER' ELSE role::text END
+    )::member_role,
+    ALTER COLUMN role SET DEFAULT 'MEMBER';
+
+DROP TYPE member_role_old;
@@ -0,0 +1,7 @@
+-- Migration: add_owner_moderator_roles
+-- Created at: 2025-08-19T09:00:00+05:30
+
+-- New values cannot be used in the transaction that adds them, so existing
+-- memberships are migrated to them in add_room_member_permissions.
+ALTER TYPE member_role ADD VALUE IF NOT EXISTS 'OWNER' BEFORE 'ADMIN';
+ALTER TYPE member_role ADD VALUE IF NOT EXISTS 'MODERATOR' BEFORE 'MEMBER';
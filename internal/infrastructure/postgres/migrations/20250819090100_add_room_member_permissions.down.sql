@@ -0,0 +1,9 @@
+-- Rollback migration: add_room_member_permissions
+-- Created at: 2025-08-19T09:01:00+05:30
+
+UPDATE room_memberships SET role = 'ADMIN' WHERE role = 'OWNER';
+UPDATE room_memberships SET role = 'MEMBER' WHERE role = 'MODERATOR';
+UPDATE room_invite_links SET role = 'MEMBER' WHERE role = 'MODERATOR';
+
+ALTER TABLE rooms
+DROP COLUMN IF EXISTS member_permissions;
@@ -0,0 +1,17 @@
+-- Migration: add_room_member_permissions
+-- Created at: 2025-08-19T09:01:00+05:30
+
+-- Per-room overrides of the MEMBER role's default permissions, keyed by permission name.
+ALTER TABLE rooms
+ADD COLUMN member_permissions JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Every room that has admins gets an owner: its longest-standing admin.
+UPDATE room_memberships m
+SET role = 'OWNER'
+FROM (
+    SELECT DISTINCT ON (room_id) room_id, user_id
+    FROM room_memberships
+    WHERE role = 'ADMIN'
+    ORDER BY room_id, created_at, user_id
+) first_admin
+WHERE m.room_id = first_admin.room_id AND m.user_id = first_admin.user_id;
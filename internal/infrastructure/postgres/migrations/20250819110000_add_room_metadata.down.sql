@@ -0,0 +1,7 @@
+-- Rollback migration: add_room_metadata
+-- Created at: 2025-08-19T11:00:00+05:30
+
+ALTER TABLE rooms
+DROP COLUMN IF EXISTS avatar_url,
+DROP COLUMN IF EXISTS topic,
+DROP COLUMN IF EXISTS description;
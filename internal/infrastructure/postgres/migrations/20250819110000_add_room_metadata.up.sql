@@ -0,0 +1,9 @@
+-- Migration: add_room_metadata
+-- Created at: 2025-08-19T11:00:00+05:30
+
+-- Descriptive room details. avatar_url is filled in by the upload worker once an
+-- uploaded avatar has been processed.
+ALTER TABLE rooms
+ADD COLUMN description VARCHAR(500) NOT NULL DEFAULT '',
+ADD COLUMN topic VARCHAR(250) NOT NULL DEFAULT '',
+ADD COLUMN avatar_url VARCHAR(500) NOT NULL DEFAULT '';
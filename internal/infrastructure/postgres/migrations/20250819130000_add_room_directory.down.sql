@@ -0,0 +1,23 @@
+-- Rollback migration: add_room_directory
+-- Created at: 2025-08-19T13:00:00+05:30
+
+DROP INDEX IF EXISTS idx_rooms_directory_created;
+DROP INDEX IF EXISTS idx_rooms_directory_activity;
+DROP INDEX IF EXISTS idx_rooms_directory_members;
+DROP INDEX IF EXISTS idx_rooms_directory_description_trgm;
+DROP INDEX IF EXISTS idx_rooms_directory_name_trgm;
+
+DROP TRIGGER IF EXISTS update_rooms_updated_at ON rooms;
+CREATE TRIGGER update_rooms_updated_at
+BEFORE UPDATE ON rooms
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+DROP FUNCTION IF EXISTS update_rooms_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_rooms_last_activity ON messages;
+DROP FUNCTION IF EXISTS update_room_last_activity();
+DROP TRIGGER IF EXISTS update_rooms_member_count ON room_memberships;
+DROP FUNCTION IF EXISTS update_room_member_count();
+
+ALTER TABLE rooms
+DROP COLUMN IF EXISTS last_activity_at,
+DROP COLUMN IF EXISTS member_count;
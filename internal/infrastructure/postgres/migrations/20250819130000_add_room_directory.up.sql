@@ -0,0 +1,81 @@
+-- Migration: add_room_directory
+-- Created at: 2025-08-19T13:00:00+05:30
+
+-- Denormalised stats for the public room directory, kept current by triggers so
+-- the directory can sort thousands of rooms without aggregating per request.
+ALTER TABLE rooms
+ADD COLUMN member_count INT NOT NULL DEFAULT 0,
+ADD COLUMN last_activity_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE rooms r SET
+    member_count = (SELECT COUNT(*) FROM room_memberships rm WHERE rm.room_id = r.id),
+    last_activity_at = COALESCE(
+        (SELECT MAX(m.created_at) FROM messages m WHERE m.room_id = r.id),
+        r.created_at,
+        NOW()
+    );
+
+CREATE OR REPLACE FUNCTION update_room_member_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE rooms SET member_count = member_count + 1 WHERE id = NEW.room_id;
+    ELSE
+        UPDATE rooms SET member_count = member_count - 1 WHERE id = OLD.room_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER update_rooms_member_count
+AFTER INSERT OR DELETE ON room_memberships
+FOR EACH ROW EXECUTE FUNCTION update_room_member_count();
+
+-- last_activity_at only orders the directory, so it is kept to the minute
+-- rather than rewriting the room row on every message.
+CREATE OR REPLACE FUNCTION update_room_last_activity()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE rooms SET last_activity_at = NEW.created_at
+    WHERE id = NEW.room_id AND last_activity_at < NEW.created_at - interval '1 minute';
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER update_rooms_last_activity
+AFTER INSERT ON messages
+FOR EACH ROW EXECUTE FUNCTION update_room_last_activity();
+
+-- Stat updates are not edits to the room, so they must not bump updated_at.
+CREATE OR REPLACE FUNCTION update_rooms_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (to_jsonb(NEW) - ARRAY['member_count', 'last_activity_at', 'updated_at'])
+        = (to_jsonb(OLD) - ARRAY['member_count', 'last_activity_at', 'updated_at']) THEN
+        RETURN NEW;
+    END IF;
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS update_rooms_updated_at ON rooms;
+CREATE TRIGGER update_rooms_updated_at
+BEFORE UPDATE ON rooms
+FOR EACH ROW EXECUTE FUNCTION update_rooms_updated_at_column();
+
+-- Directory search matches substrings of the name and description.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_rooms_directory_name_trgm ON rooms USING gin (name gin_trgm_ops)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_description_trgm ON rooms USING gin (description gin_trgm_ops)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
+
+-- One index per directory sort order, matching its keyset.
+CREATE INDEX idx_rooms_directory_members ON rooms(member_count DESC, id DESC)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_activity ON rooms(last_activity_at DESC, id DESC)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_created ON rooms(created_at DESC, id DESC)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
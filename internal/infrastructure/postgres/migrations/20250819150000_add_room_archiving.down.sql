@@ -0,0 +1,6 @@
+-- Rollback migration: add_room_archiving
+-- Created at: 2025-08-19T15:00:00+05:30
+
+DROP INDEX IF EXISTS idx_rooms_deleted_at;
+
+ALTER TABLE rooms DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,10 @@
+-- Migration: add_room_archiving
+-- Created at: 2025-08-19T15:00:00+05:30
+
+-- Archived rooms stay readable but accept no new messages or members.
+-- deleted_at (from create_rooms_table) marks a soft-deleted room that can be
+-- restored until the purge job removes it.
+ALTER TABLE rooms ADD COLUMN archived_at TIMESTAMPTZ;
+
+-- Index for the purge job's scan of rooms whose restore window has passed.
+CREATE INDEX idx_rooms_deleted_at ON rooms(deleted_at) WHERE deleted_at IS NOT NULL;
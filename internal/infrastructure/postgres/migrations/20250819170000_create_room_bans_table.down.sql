@@ -0,0 +1,9 @@
+-- Rollback migration: create_room_bans_table
+-- Created at: 2025-08-19T17:00:00+05:30
+
+DROP INDEX IF EXISTS idx_room_memberships_muted_until;
+ALTER TABLE room_memberships DROP COLUMN IF EXISTS muted_until;
+
+DROP TRIGGER IF EXISTS update_room_bans_updated_at ON room_bans;
+DROP INDEX IF EXISTS idx_room_bans_expires_at;
+DROP TABLE IF EXISTS room_bans;
@@ -0,0 +1,28 @@
+-- Migration: create_room_bans_table
+-- Created at: 2025-08-19T17:00:00+05:30
+
+-- A ban keeps a user out of a room until it expires or is lifted. Expired rows
+-- are ignored by every check and removed by the room worker.
+CREATE TABLE room_bans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    banned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ, -- NULL for a permanent ban
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (room_id, user_id)
+);
+
+-- Index for the worker's sweep of lapsed bans.
+CREATE INDEX idx_room_bans_expires_at ON room_bans(expires_at) WHERE expires_at IS NOT NULL;
+
+CREATE TRIGGER update_room_bans_updated_at
+BEFORE UPDATE ON room_bans
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- A muted member keeps read access but cannot send messages until muted_until.
+ALTER TABLE room_memberships ADD COLUMN muted_until TIMESTAMPTZ;
+
+CREATE INDEX idx_room_memberships_muted_until ON room_memberships(muted_until) WHERE muted_until IS NOT NULL;
@@ -0,0 +1,26 @@
+-- Rollback migration: add_room_join_requests
+-- Created at: 2025-08-19T19:00:00+05:30
+
+DROP TRIGGER IF EXISTS update_room_join_requests_updated_at ON room_join_requests;
+DROP TABLE IF EXISTS room_join_requests;
+DROP TYPE IF EXISTS join_request_status;
+
+DROP INDEX IF EXISTS idx_rooms_directory_name_trgm;
+DROP INDEX IF EXISTS idx_rooms_directory_description_trgm;
+DROP INDEX IF EXISTS idx_rooms_directory_members;
+DROP INDEX IF EXISTS idx_rooms_directory_activity;
+DROP INDEX IF EXISTS idx_rooms_directory_created;
+
+ALTER TABLE rooms DROP COLUMN IF EXISTS is_discoverable;
+
+CREATE INDEX idx_rooms_directory_name_trgm ON rooms USING gin (name gin_trgm_ops)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_description_trgm ON rooms USING gin (description gin_trgm_ops)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
+
+CREATE INDEX idx_rooms_directory_members ON rooms(member_count DESC, id DESC)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_activity ON rooms(last_activity_at DESC, id DESC)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_created ON rooms(created_at DESC, id DESC)
+WHERE type = 'PUBLIC' AND deleted_at IS NULL;
@@ -0,0 +1,49 @@
+-- Migration: add_room_join_requests
+-- Created at: 2025-08-19T19:00:00+05:30
+
+-- Discoverable private rooms are listed in the directory, but joining them
+-- takes an approved join request.
+ALTER TABLE rooms ADD COLUMN is_discoverable BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The directory now lists discoverable private rooms alongside public ones.
+DROP INDEX IF EXISTS idx_rooms_directory_name_trgm;
+DROP INDEX IF EXISTS idx_rooms_directory_description_trgm;
+DROP INDEX IF EXISTS idx_rooms_directory_members;
+DROP INDEX IF EXISTS idx_rooms_directory_activity;
+DROP INDEX IF EXISTS idx_rooms_directory_created;
+
+CREATE INDEX idx_rooms_directory_name_trgm ON rooms USING gin (name gin_trgm_ops)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_description_trgm ON rooms USING gin (description gin_trgm_ops)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+
+CREATE INDEX idx_rooms_directory_members ON rooms(member_count DESC, id DESC)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_activity ON rooms(last_activity_at DESC, id DESC)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_created ON rooms(created_at DESC, id DESC)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+
+CREATE TYPE join_request_status AS ENUM ('PENDING', 'APPROVED', 'DENIED', 'CANCELED');
+
+-- Requests from users asking to be let into a discoverable private room.
+CREATE TABLE room_join_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message VARCHAR(500) NOT NULL DEFAULT '',
+    status join_request_status NOT NULL DEFAULT 'PENDING',
+    responded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A user can have at most one pending request per room.
+CREATE UNIQUE INDEX idx_room_join_requests_pending ON room_join_requests(room_id, user_id)
+WHERE status = 'PENDING';
+CREATE INDEX idx_room_join_requests_user_status ON room_join_requests(user_id, status);
+
+CREATE TRIGGER update_room_join_requests_updated_at
+BEFORE UPDATE ON room_join_requests
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
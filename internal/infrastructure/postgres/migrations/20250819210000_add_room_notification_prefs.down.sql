@@ -0,0 +1,8 @@
+-- Rollback migration: add_room_notification_prefs
+-- Created at: 2025-08-19T21:00:00+05:30
+
+ALTER TABLE room_memberships
+    DROP COLUMN IF EXISTS notifications_muted_until,
+    DROP COLUMN IF EXISTS notification_level;
+
+DROP TYPE IF EXISTS notification_level;
@@ -0,0 +1,10 @@
+-- Migration: add_room_notification_prefs
+-- Created at: 2025-08-19T21:00:00+05:30
+
+CREATE TYPE notification_level AS ENUM ('ALL', 'MENTIONS', 'NONE');
+
+-- Per-room notification preferences. A member with notifications_muted_until in
+-- the future gets no notifications from the room, whatever their level.
+ALTER TABLE room_memberships
+    ADD COLUMN notification_level notification_level NOT NULL DEFAULT 'ALL',
+    ADD COLUMN notifications_muted_until TIMESTAMPTZ;
@@ -0,0 +1,14 @@
+-- Rollback migration: add_room_prefs
+-- Created at: 2025-08-19T23:00:00+05:30
+
+DROP INDEX IF EXISTS idx_room_memberships_folder_id;
+ALTER TABLE room_memberships
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS folder_id,
+    DROP COLUMN IF EXISTS hidden_at,
+    DROP COLUMN IF EXISTS is_favorite,
+    DROP COLUMN IF EXISTS is_pinned;
+
+DROP TRIGGER IF EXISTS update_room_folders_updated_at ON room_folders;
+DROP INDEX IF EXISTS idx_room_folders_user_position;
+DROP TABLE IF EXISTS room_folders;
@@ -0,0 +1,30 @@
+-- Migration: add_room_prefs
+-- Created at: 2025-08-19T23:00:00+05:30
+
+-- User-defined folders for organizing a user's own room list.
+CREATE TABLE room_folders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX idx_room_folders_user_position ON room_folders(user_id, position);
+
+CREATE TRIGGER update_room_folders_updated_at
+BEFORE UPDATE ON room_folders
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Per-user room list state. These only change how the room appears to that
+-- member. A hidden room comes back when the member is mentioned in it.
+ALTER TABLE room_memberships
+    ADD COLUMN is_pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN is_favorite BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN hidden_at TIMESTAMPTZ,
+    ADD COLUMN folder_id UUID REFERENCES room_folders(id) ON DELETE SET NULL,
+    ADD COLUMN position INT;
+
+CREATE INDEX idx_room_memberships_folder_id ON room_memberships(folder_id) WHERE folder_id IS NOT NULL;
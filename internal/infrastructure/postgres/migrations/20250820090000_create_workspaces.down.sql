@@ -0,0 +1,26 @@
+-- Rollback migration: create_workspaces
+-- Created at: 2025-08-20T09:00:00+05:30
+
+DROP INDEX IF EXISTS idx_rooms_directory_members;
+DROP INDEX IF EXISTS idx_rooms_directory_activity;
+DROP INDEX IF EXISTS idx_rooms_directory_created;
+
+CREATE INDEX idx_rooms_directory_members ON rooms(member_count DESC, id DESC)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_activity ON rooms(last_activity_at DESC, id DESC)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_created ON rooms(created_at DESC, id DESC)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_rooms_workspace_default;
+DROP INDEX IF EXISTS idx_rooms_workspace_id;
+
+ALTER TABLE rooms
+DROP COLUMN IF EXISTS is_default,
+DROP COLUMN IF EXISTS workspace_id;
+
+DROP TRIGGER IF EXISTS update_workspace_members_updated_at ON workspace_members;
+DROP TABLE IF EXISTS workspace_members;
+DROP TRIGGER IF EXISTS update_workspaces_updated_at ON workspaces;
+DROP TABLE IF EXISTS workspaces;
+DROP TYPE IF EXISTS workspace_role;
@@ -0,0 +1,74 @@
+-- Migration: create_workspaces
+-- Created at: 2025-08-20T09:00:00+05:30
+
+CREATE TYPE workspace_role AS ENUM ('ADMIN', 'MEMBER');
+
+-- Workspaces group users and rooms. Rooms, invitations and the directory are
+-- scoped to a workspace; new accounts join the default one.
+CREATE TABLE workspaces (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- At most one workspace takes in new sign-ups.
+CREATE UNIQUE INDEX idx_workspaces_default ON workspaces(is_default) WHERE is_default;
+
+CREATE TRIGGER update_workspaces_updated_at
+BEFORE UPDATE ON workspaces
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE workspace_members (
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role workspace_role NOT NULL DEFAULT 'MEMBER',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX idx_workspace_members_user_id ON workspace_members(user_id);
+CREATE INDEX idx_workspace_members_joined ON workspace_members(workspace_id, created_at DESC, user_id DESC);
+
+CREATE TRIGGER update_workspace_members_updated_at
+BEFORE UPDATE ON workspace_members
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Everything that exists today moves into the default workspace. System admins
+-- become its admins.
+INSERT INTO workspaces (id, name, is_default)
+VALUES ('00000000-0000-0000-0000-000000000001', 'Default', TRUE);
+
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT '00000000-0000-0000-0000-000000000001', id,
+       CASE WHEN is_admin THEN 'ADMIN' ELSE 'MEMBER' END::workspace_role
+FROM users;
+
+ALTER TABLE rooms
+ADD COLUMN workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE,
+ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE rooms SET workspace_id = '00000000-0000-0000-0000-000000000001';
+
+ALTER TABLE rooms ALTER COLUMN workspace_id SET NOT NULL;
+
+CREATE INDEX idx_rooms_workspace_id ON rooms(workspace_id);
+
+-- Public rooms that new workspace members join automatically.
+CREATE INDEX idx_rooms_workspace_default ON rooms(workspace_id)
+WHERE is_default AND deleted_at IS NULL;
+
+-- The directory is now listed per workspace.
+DROP INDEX IF EXISTS idx_rooms_directory_members;
+DROP INDEX IF EXISTS idx_rooms_directory_activity;
+DROP INDEX IF EXISTS idx_rooms_directory_created;
+
+CREATE INDEX idx_rooms_directory_members ON rooms(workspace_id, member_count DESC, id DESC)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_activity ON rooms(workspace_id, last_activity_at DESC, id DESC)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
+CREATE INDEX idx_rooms_directory_created ON rooms(workspace_id, created_at DESC, id DESC)
+WHERE (type = 'PUBLIC' OR is_discoverable) AND deleted_at IS NULL;
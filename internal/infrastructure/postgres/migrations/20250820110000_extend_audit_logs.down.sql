@@ -0,0 +1,13 @@
+-- Rollback migration: extend_audit_logs
+-- Created at: 2025-08-20T11:00:00+05:30
+
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+DROP FUNCTION IF EXISTS prevent_audit_log_update();
+
+DROP INDEX IF EXISTS idx_audit_logs_created_at;
+DROP INDEX IF EXISTS idx_audit_logs_room_id_action_created_at;
+
+ALTER TABLE audit_logs
+    DROP COLUMN IF EXISTS ip_address,
+    DROP COLUMN IF EXISTS after_state,
+    DROP COLUMN IF EXISTS before_state;
@@ -0,0 +1,38 @@
+-- Migration: extend_audit_logs
+-- Created at: 2025-08-20T11:00:00+05:30
+
+ALTER TABLE audit_logs
+    ADD COLUMN before_state JSONB,
+    ADD COLUMN after_state JSONB,
+    ADD COLUMN ip_address TEXT; -- As reported by the client, so not necessarily a valid INET
+
+CREATE INDEX idx_audit_logs_room_id_action_created_at ON audit_logs(room_id, action, created_at DESC);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+
+-- Entries are append-only. The only updates allowed are the ones made by the
+-- ON DELETE SET NULL foreign keys when a room or user is removed; deletes stay
+-- possible so the retention purge can run.
+CREATE OR REPLACE FUNCTION prevent_audit_log_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.id IS DISTINCT FROM OLD.id
+        OR NEW.action IS DISTINCT FROM OLD.action
+        OR NEW.target_type IS DISTINCT FROM OLD.target_type
+        OR NEW.target_id IS DISTINCT FROM OLD.target_id
+        OR NEW.metadata IS DISTINCT FROM OLD.metadata
+        OR NEW.before_state IS DISTINCT FROM OLD.before_state
+        OR NEW.after_state IS DISTINCT FROM OLD.after_state
+        OR NEW.ip_address IS DISTINCT FROM OLD.ip_address
+        OR NEW.created_at IS DISTINCT FROM OLD.created_at
+        OR (NEW.room_id IS DISTINCT FROM OLD.room_id AND NEW.room_id IS NOT NULL)
+        OR (NEW.actor_id IS DISTINCT FROM OLD.actor_id AND NEW.actor_id IS NOT NULL) THEN
+        RAISE EXCEPTION 'audit_logs entries are append-only';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_logs_append_only
+    BEFORE UPDATE ON audit_logs
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_audit_log_update();
@@ -0,0 +1,23 @@
+-- Rollback migration: create_incoming_webhooks
+-- Created at: 2025-08-20T13:00:00+05:30
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS sender_avatar_url,
+    DROP COLUMN IF EXISTS sender_name,
+    DROP COLUMN IF EXISTS webhook_id;
+
+-- Postgres cannot drop enum values, so the type is rebuilt without WEBHOOK.
+-- Webhook messages are kept as system messages.
+ALTER TYPE message_type RENAME TO message_type_old;
+CREATE TYPE message_type AS ENUM ('TEXT', 'SYSTEM');
+
+ALTER TABLE messages
+    ALTER COLUMN type DROP DEFAULT,
+    ALTER COLUMN type TYPE message_type USING (
+        CASE type::text WHEN 'WEBHOOK' THEN 'SYSTEM' ELSE type::text END
+    )::message_type,
+    ALTER COLUMN type SET DEFAULT 'TEXT';
+
+DROP TYPE message_type_old;
+
+DROP TABLE IF EXISTS incoming_webhooks;
@@ -0,0 +1,31 @@
+-- Migration: create_incoming_webhooks
+-- Created at: 2025-08-20T13:00:00+05:30
+
+-- An incoming webhook lets an external service post into a room through a
+-- secret URL. Only the token's hash is stored.
+CREATE TABLE incoming_webhooks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    name VARCHAR(80) NOT NULL,
+    avatar_url TEXT NOT NULL DEFAULT '',
+    token_hash TEXT NOT NULL UNIQUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_incoming_webhooks_room_id ON incoming_webhooks(room_id);
+
+CREATE TRIGGER update_incoming_webhooks_updated_at
+BEFORE UPDATE ON incoming_webhooks
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Webhook messages have no user; they carry the webhook and the name and
+-- avatar shown for them instead.
+ALTER TYPE message_type ADD VALUE IF NOT EXISTS 'WEBHOOK';
+
+ALTER TABLE messages
+    ADD COLUMN webhook_id UUID REFERENCES incoming_webhooks(id) ON DELETE SET NULL,
+    ADD COLUMN sender_name VARCHAR(80),
+    ADD COLUMN sender_avatar_url TEXT;
@@ -0,0 +1,7 @@
+-- Rollback migration: create_outgoing_webhooks
+-- Created at: 2025-08-20T15:00:00+05:30
+
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TYPE IF EXISTS webhook_delivery_status;
+DROP TABLE IF EXISTS outgoing_webhooks;
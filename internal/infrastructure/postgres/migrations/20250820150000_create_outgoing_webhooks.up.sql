@@ -0,0 +1,69 @@
+-- Migration: create_outgoing_webhooks
+-- Created at: 2025-08-20T15:00:00+05:30
+
+-- An outgoing webhook receives signed deliveries of the events it subscribes
+-- to, for a single room or for every room in a workspace.
+CREATE TABLE outgoing_webhooks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    room_id UUID REFERENCES rooms(id) ON DELETE CASCADE,
+    workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL, -- Kept in plaintext because every delivery is signed with it
+    events TEXT[] NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((room_id IS NULL) <> (workspace_id IS NULL))
+);
+
+CREATE INDEX idx_outgoing_webhooks_room_id ON outgoing_webhooks(room_id) WHERE room_id IS NOT NULL;
+CREATE INDEX idx_outgoing_webhooks_workspace_id ON outgoing_webhooks(workspace_id) WHERE workspace_id IS NOT NULL;
+
+CREATE TRIGGER update_outgoing_webhooks_updated_at
+BEFORE UPDATE ON outgoing_webhooks
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- PENDING deliveries are waiting for their next attempt; FAILED ones ran out
+-- of attempts and form the dead-letter list until they are redelivered.
+CREATE TYPE webhook_delivery_status AS ENUM ('PENDING', 'SUCCEEDED', 'FAILED');
+
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL REFERENCES outgoing_webhooks(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL, -- Shared by the deliveries of one event to several webhooks
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    queued_at TIMESTAMPTZ,     -- When a job for the delivery was last put on the queue
+    claimed_until TIMESTAMPTZ, -- Lease held by the worker attempting the delivery
+    last_status_code INT,
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook_id_created_at ON webhook_deliveries(webhook_id, created_at DESC, id DESC);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX idx_webhook_deliveries_updated_at ON webhook_deliveries(updated_at) WHERE status <> 'PENDING';
+
+CREATE TRIGGER update_webhook_deliveries_updated_at
+BEFORE UPDATE ON webhook_deliveries
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- One row per HTTP request made for a delivery.
+CREATE TABLE webhook_delivery_attempts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    delivery_id UUID NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt INT NOT NULL,
+    status_code INT, -- NULL when no response was received
+    error TEXT,
+    response_body TEXT, -- Truncated
+    duration_ms INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts(delivery_id, created_at);
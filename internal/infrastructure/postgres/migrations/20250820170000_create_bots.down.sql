@@ -0,0 +1,15 @@
+-- Rollback migration: create_bots
+-- Created at: 2025-08-20T17:00:00+05:30
+
+DROP TABLE IF EXISTS bot_tokens;
+
+-- Bot accounts cannot exist without the columns that mark them, so they are
+-- removed with their messages and memberships.
+DELETE FROM users WHERE is_bot;
+
+DROP INDEX IF EXISTS idx_users_bot_owner_id;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_bot_owner_check,
+    DROP COLUMN IF EXISTS bot_owner_id,
+    DROP COLUMN IF EXISTS is_bot;
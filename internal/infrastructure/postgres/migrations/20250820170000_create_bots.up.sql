@@ -0,0 +1,28 @@
+-- Migration: create_bots
+-- Created at: 2025-08-20T17:00:00+05:30
+
+-- Bots are users owned by another user. They have no usable email or
+-- password and authenticate with bot tokens instead.
+ALTER TABLE users
+    ADD COLUMN is_bot BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN bot_owner_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    ADD CONSTRAINT users_bot_owner_check CHECK (is_bot = (bot_owner_id IS NOT NULL));
+
+CREATE INDEX idx_users_bot_owner_id ON users(bot_owner_id) WHERE bot_owner_id IS NOT NULL;
+
+-- A bot token is a long-lived, scoped credential for one bot. Only the
+-- token's hash is stored.
+CREATE TABLE bot_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bot_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(80) NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_bot_tokens_bot_id ON bot_tokens(bot_id, created_at DESC);
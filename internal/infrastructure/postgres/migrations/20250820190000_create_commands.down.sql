@@ -0,0 +1,19 @@
+-- Rollback migration: create_commands
+-- Created at: 2025-08-20T19:00:00+05:30
+
+DROP TABLE IF EXISTS command_invocations;
+DROP TABLE IF EXISTS commands;
+
+-- Postgres cannot drop enum values, so the type is rebuilt without ACTION.
+-- Action messages are kept as text messages.
+ALTER TYPE message_type RENAME TO message_type_old;
+CREATE TYPE message_type AS ENUM ('TEXT', 'SYSTEM', 'WEBHOOK');
+
+ALTER TABLE messages
+    ALTER COLUMN type DROP DEFAULT,
+    ALTER COLUMN type TYPE message_type USING (
+        CASE type::text WHEN 'ACTION' THEN 'TEXT' ELSE type::text END
+    )::message_type,
+    ALTER COLUMN type SET DEFAULT 'TEXT';
+
+DROP TYPE message_type_old;
@@ -0,0 +1,47 @@
+-- Migration: create_commands
+-- Created at: 2025-08-20T19:00:00+05:30
+
+-- /me posts are stored as ACTION messages, shown as something the sender did.
+ALTER TYPE message_type ADD VALUE IF NOT EXISTS 'ACTION';
+
+-- A custom slash command, answered by the bot or outgoing webhook that
+-- registered it. Bot commands belong to a room the bot is in; webhook commands
+-- share their webhook's room or workspace.
+CREATE TABLE commands (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(32) NOT NULL,
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    args JSONB NOT NULL DEFAULT '[]', -- Argument schema, in order
+    room_id UUID REFERENCES rooms(id) ON DELETE CASCADE,
+    workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE,
+    bot_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    webhook_id UUID REFERENCES outgoing_webhooks(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((room_id IS NULL) <> (workspace_id IS NULL)),
+    CHECK ((bot_id IS NULL) <> (webhook_id IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_commands_room_id_name ON commands(room_id, name) WHERE room_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_commands_workspace_id_name ON commands(workspace_id, name) WHERE workspace_id IS NOT NULL;
+CREATE INDEX idx_commands_webhook_id ON commands(webhook_id) WHERE webhook_id IS NOT NULL;
+
+CREATE TRIGGER update_commands_updated_at
+BEFORE UPDATE ON commands
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- One run of a custom command. Its handler replies through the response
+-- token, of which only the hash is stored, until the invocation expires.
+CREATE TABLE command_invocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    command_id UUID NOT NULL REFERENCES commands(id) ON DELETE CASCADE,
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    responses INT NOT NULL DEFAULT 0, -- Replies sent so far
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_command_invocations_command_id_expires_at ON command_invocations(command_id, expires_at);
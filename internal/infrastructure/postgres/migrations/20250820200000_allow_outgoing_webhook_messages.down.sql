@@ -0,0 +1,12 @@
+-- Rollback migration: allow_outgoing_webhook_messages
+-- Created at: 2025-08-20T20:00:00+05:30
+
+-- Messages from outgoing or deleted webhooks keep their name and avatar but
+-- lose the webhook they came from.
+UPDATE messages SET webhook_id = NULL
+WHERE webhook_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM incoming_webhooks w WHERE w.id = messages.webhook_id);
+
+ALTER TABLE messages
+    ADD CONSTRAINT messages_webhook_id_fkey
+    FOREIGN KEY (webhook_id) REFERENCES incoming_webhooks(id) ON DELETE SET NULL;
@@ -0,0 +1,8 @@
+-- Migration: allow_outgoing_webhook_messages
+-- Created at: 2025-08-20T20:00:00+05:30
+
+-- Outgoing webhooks post into rooms when they answer a slash command, so a
+-- webhook message may now name either kind of webhook. The sender name and
+-- avatar are stored on the message, so it still renders once its webhook is
+-- deleted.
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_webhook_id_fkey;
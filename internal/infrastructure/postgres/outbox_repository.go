@@ -0,0 +1,164 @@
+// internal/infrastructure/postgres/outbox_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+)
+
+// outboxChannel is the LISTEN/NOTIFY channel signalled by the outbox_events insert trigger.
+const outboxChannel = "outbox_events"
+
+type OutboxRepository struct {
+	pool     *pgxpool.Pool
+	listener *pgxpool.Conn
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{pool: pool}
+}
+
+// ============================================================================
+// Relay Operations
+// ============================================================================
+
+// ClaimPending leases up to limit due events to the caller by pushing their
+// next attempt past the lease. Concurrent relays skip rows already locked, and
+// an event whose relay dies mid-batch becomes due again once the lease expires.
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*contracts.OutboxEvent, error) {
+	query := `
+        WITH due AS (
+            SELECT id FROM outbox_events
+            WHERE published_at IS NULL AND next_attempt_at <= NOW()
+            ORDER BY created_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE outbox_events e
+        SET attempts = e.attempts + 1, next_attempt_at = NOW() + $2::interval
+        FROM due
+        WHERE e.id = due.id
+        RETURNING e.id, e.channel, e.payload, e.attempts, e.created_at
+    `
+	rows, err := r.pool.Query(ctx, query, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*contracts.OutboxEvent
+	for rows.Next() {
+		var e contracts.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.Channel, &e.Payload, &e.Attempts, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+
+	// The UPDATE does not preserve the CTE's ordering, so restore it for the relay.
+	sortOutboxEvents(events)
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, eventID string) error {
+	query := `UPDATE outbox_events SET published_at = NOW(), last_error = NULL WHERE id = $1`
+	if _, err := r.pool.Exec(ctx, query, eventID); err != nil {
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, eventID, lastError string, nextAttemptAt time.Time) error {
+	query := `UPDATE outbox_events SET last_error = $2, next_attempt_at = $3 WHERE id = $1`
+	if _, err := r.pool.Exec(ctx, query, eventID, lastError, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// DeletePublishedBefore removes delivered events older than cutoff and returns how many were removed.
+func (r *OutboxRepository) DeletePublishedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `DELETE FROM outbox_events WHERE published_at IS NOT NULL AND published_at < $1`
+	cmdTag, err := r.pool.Exec(ctx, query, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published outbox events: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// WaitForEvents blocks until a new event is committed or timeout elapses. It
+// keeps one pooled connection LISTENing between calls; on any connection error
+// the listener is dropped and re-established on the next call.
+func (r *OutboxRepository) WaitForEvents(ctx context.Context, timeout time.Duration) error {
+	if r.listener == nil {
+		conn, err := r.pool.Acquire(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to acquire listener connection: %w", err)
+		}
+		if _, err := conn.Exec(ctx, "LISTEN "+outboxChannel); err != nil {
+			conn.Release()
+			return fmt.Errorf("failed to listen for outbox events: %w", err)
+		}
+		r.listener = conn
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err := r.listener.Conn().WaitForNotification(waitCtx)
+	if err == nil || waitCtx.Err() != nil {
+		// A timeout is the normal idle case, not a failure.
+		return nil
+	}
+
+	// The connection failed mid-wait, so it is closed rather than returned to the pool.
+	r.listener.Hijack().Close(context.Background())
+	r.listener = nil
+	return fmt.Errorf("failed waiting for outbox events: %w", err)
+}
+
+// Close releases the listener connection, if any.
+func (r *OutboxRepository) Close() {
+	if r.listener != nil {
+		r.listener.Hijack().Close(context.Background())
+		r.listener = nil
+	}
+}
+
+// ============================================================================
+// Private Helpers
+// ============================================================================
+
+// insertOutboxEvents stores events inside the caller's transaction so that they
+// commit, or roll back, together with the change they describe.
+func insertOutboxEvents(ctx context.Context, tx pgx.Tx, events ...*contracts.OutboxEvent) error {
+	query := `
+        INSERT INTO outbox_events (id, channel, payload)
+        VALUES ($1, $2, $3)
+        RETURNING created_at
+    `
+	for _, e := range events {
+		if e == nil {
+			continue
+		}
+		if err := tx.QueryRow(ctx, query, e.ID, e.Channel, e.Payload).Scan(&e.CreatedAt); err != nil {
+			return fmt.Errorf("failed to insert outbox event: %w", err)
+		}
+	}
+	return nil
+}
+
+func sortOutboxEvents(events []*contracts.OutboxEvent) {
+	slices.SortStableFunc(events, func(a, b *contracts.OutboxEvent) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+}
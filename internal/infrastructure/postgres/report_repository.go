@@ -0,0 +1,149 @@
+// internal/infrastructure/postgres/report_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/domain/report"
+)
+
+type ReportRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReportRepository(pool *pgxpool.Pool) *ReportRepository {
+	return &ReportRepository{pool: pool}
+}
+
+const reportColumns = `
+    id, reporter_id, target_type, message_id, reported_user_id, room_id, reason, details, status,
+    assignee_id, resolution_action, resolution_note, resolved_by, resolved_at, created_at, updated_at
+`
+
+// ============================================================================
+// Report Operations
+// ============================================================================
+
+func (r *ReportRepository) CreateReport(ctx context.Context, rep *report.Report) error {
+	query := `
+        INSERT INTO reports (id, reporter_id, target_type, message_id, reported_user_id, room_id, reason, details, status)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING created_at, updated_at
+    `
+	err := r.pool.QueryRow(ctx, query,
+		rep.ID, rep.ReporterID, rep.TargetType, rep.MessageID, rep.ReportedUserID, rep.RoomID, rep.Reason, rep.Details, rep.Status,
+	).Scan(&rep.CreatedAt, &rep.UpdatedAt)
+	if err != nil {
+		// 23505 is a unique_violation on one of the "one open report per target" indexes.
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return report.ErrAlreadyReported
+		}
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportRepository) GetReportByID(ctx context.Context, reportID string) (*report.Report, error) {
+	query := `SELECT ` + reportColumns + ` FROM reports WHERE id = $1`
+	rep, err := scanReport(r.pool.QueryRow(ctx, query, reportID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, report.ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	return rep, nil
+}
+
+func (r *ReportRepository) ListReports(ctx context.Context, filter report.ListFilter) ([]*report.Report, error) {
+	var conditions []string
+	var args []any
+
+	if filter.RoomID != nil {
+		args = append(args, *filter.RoomID)
+		conditions = append(conditions, fmt.Sprintf("room_id = $%d", len(args)))
+	}
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + reportColumns + ` FROM reports`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*report.Report
+	for rows.Next() {
+		rep, err := scanReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+		reports = append(reports, rep)
+	}
+	return reports, rows.Err()
+}
+
+func (r *ReportRepository) AssignReport(ctx context.Context, reportID, assigneeID string) error {
+	query := `
+        UPDATE reports SET assignee_id = $2, status = 'IN_REVIEW'
+        WHERE id = $1 AND status IN ('OPEN', 'IN_REVIEW')
+    `
+	cmdTag, err := r.pool.Exec(ctx, query, reportID, assigneeID)
+	if err != nil {
+		return fmt.Errorf("failed to assign report: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return report.ErrReportClosed
+	}
+	return nil
+}
+
+func (r *ReportRepository) CloseReport(ctx context.Context, rep *report.Report) error {
+	query := `
+        UPDATE reports
+        SET status = $2, resolution_action = $3, resolution_note = $4, resolved_by = $5, resolved_at = $6
+        WHERE id = $1 AND status IN ('OPEN', 'IN_REVIEW')
+        RETURNING updated_at
+    `
+	err := r.pool.QueryRow(ctx, query,
+		rep.ID, rep.Status, rep.ResolutionAction, rep.ResolutionNote, rep.ResolvedBy, rep.ResolvedAt,
+	).Scan(&rep.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return report.ErrReportClosed
+		}
+		return fmt.Errorf("failed to close report: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// Private Helpers
+// ============================================================================
+
+func scanReport(row pgx.Row) (*report.Report, error) {
+	var rep report.Report
+	err := row.Scan(
+		&rep.ID, &rep.ReporterID, &rep.TargetType, &rep.MessageID, &rep.ReportedUserID, &rep.RoomID, &rep.Reason, &rep.Details, &rep.Status,
+		&rep.AssigneeID, &rep.ResolutionAction, &rep.ResolutionNote, &rep.ResolvedBy, &rep.ResolvedAt, &rep.CreatedAt, &rep.UpdatedAt,
+	)
+	return &rep, err
+}
@@ -0,0 +1,136 @@
+// internal/infrastructure/postgres/retention_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/domain/retention"
+	"github.com/purushothdl/gochat-backend/internal/domain/room"
+)
+
+type RetentionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRetentionRepository(pool *pgxpool.Pool) *RetentionRepository {
+	return &RetentionRepository{pool: pool}
+}
+
+// ============================================================================
+// Policy Operations
+// ============================================================================
+
+func (r *RetentionRepository) GetRoomRetention(ctx context.Context, roomID string) (*int, error) {
+	query := `SELECT retention_days FROM rooms WHERE id = $1 AND deleted_at IS NULL`
+	var days *int
+	if err := r.pool.QueryRow(ctx, query, roomID).Scan(&days); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, room.ErrRoomNotFound
+		}
+		return nil, fmt.Errorf("failed to get room retention: %w", err)
+	}
+	return days, nil
+}
+
+func (r *RetentionRepository) SetRoomRetention(ctx context.Context, roomID string, days *int) error {
+	query := `UPDATE rooms SET retention_days = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	cmdTag, err := r.pool.Exec(ctx, query, roomID, days)
+	if err != nil {
+		return fmt.Errorf("failed to set room retention: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrRoomNotFound
+	}
+	return nil
+}
+
+func (r *RetentionRepository) ListRoomPolicies(ctx context.Context, defaultDays int) ([]*retention.RoomPolicy, error) {
+	query := `
+        SELECT id, COALESCE(retention_days, $1)
+        FROM rooms
+        WHERE deleted_at IS NULL AND COALESCE(retention_days, $1) > 0
+    `
+	rows, err := r.pool.Query(ctx, query, defaultDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retention policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*retention.RoomPolicy
+	for rows.Next() {
+		var p retention.RoomPolicy
+		if err := rows.Scan(&p.RoomID, &p.RetentionDays); err != nil {
+			return nil, fmt.Errorf("failed to scan retention policy: %w", err)
+		}
+		policies = append(policies, &p)
+	}
+	return policies, rows.Err()
+}
+
+// ============================================================================
+// Purge Operations
+// ============================================================================
+
+func (r *RetentionRepository) PreviewPurge(ctx context.Context, roomID string, cutoff time.Time) (*retention.PurgePreview, error) {
+	query := `
+        SELECT COUNT(*), MIN(created_at), MAX(created_at)
+        FROM messages
+        WHERE room_id = $1 AND created_at < $2
+    `
+	var p retention.PurgePreview
+	err := r.pool.QueryRow(ctx, query, roomID, cutoff).Scan(&p.MessageCount, &p.OldestMessageAt, &p.NewestMessageAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview purge: %w", err)
+	}
+	return &p, nil
+}
+
+// PurgeBatch locks a bounded set of expired messages, skipping rows other
+// transactions hold, and removes them with their dependent rows. Messages
+// carry no file attachments in this schema, so there is no storage to clean up.
+func (r *RetentionRepository) PurgeBatch(ctx context.Context, roomID string, cutoff time.Time, limit int) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	selectQuery := `
+        SELECT id FROM messages
+        WHERE room_id = $1 AND created_at < $2
+        ORDER BY created_at
+        LIMIT $3
+        FOR UPDATE SKIP LOCKED
+    `
+	rows, err := tx.Query(ctx, selectQuery, roomID, cutoff, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select expired messages: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan expired messages: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM message_read_receipts WHERE message_id = ANY($1)`, ids); err != nil {
+		return 0, fmt.Errorf("failed to delete read receipts: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_message_deletions WHERE message_id = ANY($1)`, ids); err != nil {
+		return 0, fmt.Errorf("failed to delete user message deletions: %w", err)
+	}
+	cmdTag, err := tx.Exec(ctx, `DELETE FROM messages WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete messages: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit purge batch: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
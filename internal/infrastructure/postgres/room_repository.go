@@ -4,9 +4,13 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
 	"github.com/purushothdl/gochat-backend/internal/domain/room"
 	"github.com/purushothdl/gochat-backend/internal/shared/types"
 )
@@ -23,11 +27,11 @@ func NewRoomRepository(pool *pgxpool.Pool) *RoomRepository {
 // the created_at and updated_at fields of the Room object.
 func (r *RoomRepository) CreateRoom(ctx context.Context, newRoom *room.Room) error {
 	query := `
-        INSERT INTO rooms (id, name, type, created_at, updated_at)
-        VALUES ($1, $2, $3, NOW(), NOW())
+        INSERT INTO rooms (id, workspace_id, name, type, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, NOW(), NOW())
         RETURNING created_at, updated_at
     `
-	err := r.pool.QueryRow(ctx, query, newRoom.ID, newRoom.Name, newRoom.Type).Scan(
+	err := r.pool.QueryRow(ctx, query, newRoom.ID, newRoom.WorkspaceID, newRoom.Name, newRoom.Type).Scan(
 		&newRoom.CreatedAt,
 		&newRoom.UpdatedAt,
 	)
@@ -37,21 +41,57 @@ func (r *RoomRepository) CreateRoom(ctx context.Context, newRoom *room.Room) err
 	return nil
 }
 
-// UpdateRoom updates a room's mutable properties.
-func (r *RoomRepository) UpdateRoom(ctx context.Context, rm *room.Room) error {
+// UpdateRoom updates a room's mutable properties and stores event, if any, in the same transaction.
+func (r *RoomRepository) UpdateRoom(ctx context.Context, rm *room.Room, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
         UPDATE rooms
-        SET name = $2, is_broadcast_only = $3, updated_at = NOW()
+        SET name = $2, description = $3, topic = $4, is_broadcast_only = $5, slow_mode_seconds = $6,
+            moderation_settings = $7, member_permissions = $8, is_discoverable = $9, updated_at = NOW()
         WHERE id = $1 AND deleted_at IS NULL
     `
-	cmdTag, err := r.pool.Exec(ctx, query, rm.ID, rm.Name, rm.IsBroadcastOnly)
+	cmdTag, err := tx.Exec(ctx, query,
+		rm.ID, rm.Name, rm.Description, rm.Topic, rm.IsBroadcastOnly, rm.SlowModeSeconds, rm.ModerationSettings, rm.MemberPermissions, rm.IsDiscoverable,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to update room: %w", err)
 	}
 	if cmdTag.RowsAffected() == 0 {
 		return room.ErrRoomNotFound
 	}
-	return nil
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UpdateRoomAvatarURL saves a processed avatar's URL and stores event in the same transaction.
+func (r *RoomRepository) UpdateRoomAvatarURL(ctx context.Context, roomID, avatarURL string, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE rooms SET avatar_url = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	cmdTag, err := tx.Exec(ctx, query, roomID, avatarURL)
+	if err != nil {
+		return fmt.Errorf("failed to update room avatar_url: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrRoomNotFound
+	}
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 // CreateMembership inserts a new room membership record.
@@ -69,12 +109,18 @@ func (r *RoomRepository) CreateMembership(ctx context.Context, membership *room.
 
 // FindMembership retrieves a specific membership from the database.
 func (r *RoomRepository) FindMembership(ctx context.Context, roomID, userID string) (*room.RoomMembership, error) {
-	query := `SELECT room_id, user_id, role, created_at, updated_at FROM room_memberships WHERE room_id = $1 AND user_id = $2`
+	query := `
+        SELECT rm.room_id, rm.user_id, rm.role, ` + activeMute + `, rm.created_at, rm.updated_at
+        FROM room_memberships rm
+        JOIN rooms r ON r.id = rm.room_id
+        WHERE rm.room_id = $1 AND rm.user_id = $2 AND r.deleted_at IS NULL
+    `
 	var m room.RoomMembership
 	err := r.pool.QueryRow(ctx, query, roomID, userID).Scan(
 		&m.RoomID,
 		&m.UserID,
 		&m.Role,
+		&m.MutedUntil,
 		&m.CreatedAt,
 		&m.UpdatedAt,
 	)
@@ -87,36 +133,72 @@ func (r *RoomRepository) FindMembership(ctx context.Context, roomID, userID stri
 	return &m, nil
 }
 
-// ListUserRooms retrieves all rooms a user is a member of.
-func (r *RoomRepository) ListUserRooms(ctx context.Context, userID string) ([]*room.Room, error) {
+// ListUserRooms retrieves the rooms a user is a member of, with the user's own
+// settings for each, narrowed by filter. Pinned rooms come first, then rooms the
+// user has placed, then the rest by most recently updated.
+func (r *RoomRepository) ListUserRooms(ctx context.Context, userID string, filter room.UserRoomFilter) ([]*room.UserRoom, error) {
+	args := []any{userID}
+	conditions := []string{"rm.user_id = $1", "r.deleted_at IS NULL"}
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "r.archived_at IS NULL")
+	}
+	if filter.Hidden {
+		conditions = append(conditions, "rm.hidden_at IS NOT NULL")
+	} else {
+		conditions = append(conditions, "rm.hidden_at IS NULL")
+	}
+	if filter.Pinned != nil {
+		args = append(args, *filter.Pinned)
+		conditions = append(conditions, fmt.Sprintf("rm.is_pinned = $%d", len(args)))
+	}
+	if filter.Favorite != nil {
+		args = append(args, *filter.Favorite)
+		conditions = append(conditions, fmt.Sprintf("rm.is_favorite = $%d", len(args)))
+	}
+	if filter.FolderID != nil {
+		if *filter.FolderID == "" {
+			conditions = append(conditions, "rm.folder_id IS NULL")
+		} else {
+			args = append(args, *filter.FolderID)
+			conditions = append(conditions, fmt.Sprintf("rm.folder_id = $%d", len(args)))
+		}
+	}
+
 	query := `
-        SELECT r.id, r.name, r.type, r.created_at, r.updated_at
+        SELECT r.id, r.workspace_id, r.name, r.description, r.topic, r.avatar_url, r.type, r.is_broadcast_only, r.is_discoverable, r.slow_mode_seconds, r.moderation_settings, r.member_permissions, r.created_at, r.updated_at, r.archived_at,
+               rm.notification_level, ` + activeNotificationMute + `,
+               rm.is_pinned, rm.is_favorite, rm.hidden_at, rm.folder_id, rm.position
         FROM rooms r
         JOIN room_memberships rm ON r.id = rm.room_id
-        WHERE rm.user_id = $1 AND r.deleted_at IS NULL
-        ORDER BY r.updated_at DESC
+        WHERE ` + strings.Join(conditions, " AND ") + `
+        ORDER BY rm.is_pinned DESC, rm.position ASC NULLS LAST, r.updated_at DESC
     `
-	rows, err := r.pool.Query(ctx, query, userID)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list user rooms: %w", err)
 	}
 	defer rows.Close()
 
-	var rooms []*room.Room
+	var rooms []*room.UserRoom
 	for rows.Next() {
-		room, err := scanRoom(rows)
+		var userRoom room.UserRoom
+		n, p := &userRoom.Notifications, &userRoom.Prefs
+		userRoom.Room, err = scanRoom(rows,
+			&n.Level, &n.MutedUntil,
+			&p.IsPinned, &p.IsFavorite, &p.HiddenAt, &p.FolderID, &p.Position,
+		)
 		if err != nil {
 			return nil, err
 		}
-		rooms = append(rooms, room)
+		rooms = append(rooms, &userRoom)
 	}
 
-	return rooms, nil
+	return rooms, rows.Err()
 }
 
 // FindRoomByID retrieves a single room by its ID.
 func (r *RoomRepository) FindRoomByID(ctx context.Context, roomID string) (*room.Room, error) {
-	query := `SELECT id, name, type, created_at, updated_at FROM rooms WHERE id = $1 AND deleted_at IS NULL`
+	query := `SELECT id, workspace_id, name, description, topic, avatar_url, type, is_broadcast_only, is_discoverable, slow_mode_seconds, moderation_settings, member_permissions, created_at, updated_at, archived_at FROM rooms WHERE id = $1 AND deleted_at IS NULL`
 	row := r.pool.QueryRow(ctx, query, roomID)
 	foundRoom, err := scanRoom(row)
 	if err != nil {
@@ -128,30 +210,133 @@ func (r *RoomRepository) FindRoomByID(ctx context.Context, roomID string) (*room
 	return foundRoom, nil
 }
 
-// ListPublicRooms retrieves all rooms with type 'PUBLIC'.
-func (r *RoomRepository) ListPublicRooms(ctx context.Context) ([]*room.Room, error) {
-	query := `SELECT id, name, type, created_at, updated_at FROM rooms WHERE type = 'PUBLIC' AND deleted_at IS NULL ORDER BY updated_at DESC`
-	rows, err := r.pool.Query(ctx, query)
+// directoryOrders maps each directory sort to its keyset column, matching the
+// idx_rooms_directory_* indexes.
+var directoryOrders = map[room.DirectorySort]string{
+	room.SortByMembers:  "r.member_count",
+	room.SortByActivity: "r.last_activity_at",
+	room.SortByCreated:  "r.created_at",
+}
+
+// ListPublicRooms retrieves a page of 'PUBLIC' and discoverable private rooms for
+// the directory, with member counts, last activity and whether the viewer has
+// joined each one or is waiting on a join request.
+func (r *RoomRepository) ListPublicRooms(ctx context.Context, filter room.DirectoryFilter) ([]*room.DirectoryEntry, error) {
+	orderColumn, ok := directoryOrders[filter.Sort]
+	if !ok {
+		return nil, room.ErrInvalidDirectorySort
+	}
+
+	args := []any{filter.ViewerID}
+	conditions := []string{
+		"r.workspace_id IN (SELECT wm.workspace_id FROM workspace_members wm WHERE wm.user_id = $1)",
+		"(r.type = 'PUBLIC' OR r.is_discoverable)", "r.deleted_at IS NULL", "r.archived_at IS NULL",
+	}
+	if filter.WorkspaceID != "" {
+		args = append(args, filter.WorkspaceID)
+		conditions = append(conditions, fmt.Sprintf("r.workspace_id = $%d", len(args)))
+	}
+	if filter.Query != "" {
+		args = append(args, "%"+likeEscaper.Replace(filter.Query)+"%")
+		conditions = append(conditions, fmt.Sprintf("(r.name ILIKE $%d OR r.description ILIKE $%d)", len(args), len(args)))
+	}
+	if filter.Cursor != nil {
+		var key any = filter.Cursor.CreatedAt
+		if filter.Cursor.Count != nil {
+			key = *filter.Cursor.Count
+		}
+		args = append(args, key, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(%s, r.id) < ($%d, $%d)", orderColumn, len(args)-1, len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+        SELECT r.id, r.workspace_id, r.name, r.description, r.topic, r.avatar_url, r.type, r.is_broadcast_only, r.is_discoverable, r.slow_mode_seconds,
+               r.moderation_settings, r.member_permissions, r.created_at, r.updated_at, r.archived_at,
+               r.member_count, r.last_activity_at,
+               EXISTS (SELECT 1 FROM room_memberships rm WHERE rm.room_id = r.id AND rm.user_id = $1),
+               EXISTS (SELECT 1 FROM room_join_requests jr WHERE jr.room_id = r.id AND jr.user_id = $1 AND jr.status = 'PENDING')
+        FROM rooms r
+        WHERE %s
+        ORDER BY %s DESC, r.id DESC
+        LIMIT $%d
+    `, strings.Join(conditions, " AND "), orderColumn, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list public rooms: %w", err)
 	}
 	defer rows.Close()
 
-	var rooms []*room.Room
+	var entries []*room.DirectoryEntry
 	for rows.Next() {
-		room, err := scanRoom(rows)
+		var rm room.Room
+		var e room.DirectoryEntry
+		err := rows.Scan(
+			&rm.ID, &rm.WorkspaceID, &rm.Name, &rm.Description, &rm.Topic, &rm.AvatarURL, &rm.Type, &rm.IsBroadcastOnly, &rm.IsDiscoverable, &rm.SlowModeSeconds,
+			&rm.ModerationSettings, &rm.MemberPermissions, &rm.CreatedAt, &rm.UpdatedAt, &rm.ArchivedAt,
+			&e.MemberCount, &e.LastActivityAt, &e.IsMember, &e.HasPendingRequest,
+		)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to scan public room: %w", err)
+		}
+		e.Room = &rm
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// activeNotificationMute selects a membership's notifications_muted_until only while it is in the future.
+const activeNotificationMute = `CASE WHEN rm.notifications_muted_until > NOW() THEN rm.notifications_muted_until END`
+
+// UpdateNotificationPrefs replaces a member's notification settings for a room.
+func (r *RoomRepository) UpdateNotificationPrefs(ctx context.Context, roomID, userID string, prefs types.NotificationPrefs) error {
+	query := `
+        UPDATE room_memberships SET notification_level = $3, notifications_muted_until = $4, updated_at = NOW()
+        WHERE room_id = $1 AND user_id = $2
+    `
+	cmdTag, err := r.pool.Exec(ctx, query, roomID, userID, prefs.Level, prefs.MutedUntil)
+	if err != nil {
+		return fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrNotMember
+	}
+	return nil
+}
+
+// ListNotificationTargets returns the notification settings of those userIDs
+// who are members of the room. Users without a stored global setting default to
+// notifications on, as new accounts do.
+func (r *RoomRepository) ListNotificationTargets(ctx context.Context, roomID string, userIDs []string) ([]*types.NotificationTarget, error) {
+	query := `
+        SELECT rm.user_id, rm.notification_level, ` + activeNotificationMute + `,
+               COALESCE((u.settings->>'notifications_enabled')::boolean, TRUE), rm.hidden_at IS NOT NULL
+        FROM room_memberships rm
+        JOIN users u ON u.id = rm.user_id
+        WHERE rm.room_id = $1 AND rm.user_id = ANY($2) AND u.deleted_at IS NULL
+    `
+	rows, err := r.pool.Query(ctx, query, roomID, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification targets: %w", err)
+	}
+	defer rows.Close()
+
+	var targets []*types.NotificationTarget
+	for rows.Next() {
+		var t types.NotificationTarget
+		if err := rows.Scan(&t.UserID, &t.Prefs.Level, &t.Prefs.MutedUntil, &t.NotificationsEnabled, &t.Hidden); err != nil {
+			return nil, fmt.Errorf("failed to scan notification target: %w", err)
 		}
-		rooms = append(rooms, room)
+		targets = append(targets, &t)
 	}
-	return rooms, nil
+	return targets, rows.Err()
 }
 
 // ListMembers retrieves all members of a specific room, along with their public user details.
 func (r *RoomRepository) ListMembers(ctx context.Context, roomID string) ([]*types.MemberDetail, error) {
 	query := `
-        SELECT rm.room_id, rm.user_id, rm.role, u.name, u.image_url
+        SELECT rm.room_id, rm.user_id, rm.role, u.name, u.image_url, u.is_bot, ` + activeMute + `
         FROM room_memberships rm
         JOIN users u ON rm.user_id = u.id
         WHERE rm.room_id = $1 AND u.deleted_at IS NULL
@@ -167,7 +352,7 @@ func (r *RoomRepository) ListMembers(ctx context.Context, roomID string) ([]*typ
 		var m types.MemberDetail
 		var imageURL *string 
 
-		if err := rows.Scan(&m.RoomID, &m.UserID, &m.Role, &m.Name, &imageURL); err != nil {
+		if err := rows.Scan(&m.RoomID, &m.UserID, &m.Role, &m.Name, &imageURL, &m.IsBot, &m.MutedUntil); err != nil {
 			return nil, fmt.Errorf("failed to scan member detail: %w", err)
 		}
 
@@ -206,69 +391,1183 @@ func (r *RoomRepository) DeleteMembership(ctx context.Context, roomID, userID st
 	return nil
 }
 
-// CountAdmins counts the number of administrators in a given room.
-func (r *RoomRepository) CountAdmins(ctx context.Context, roomID string) (int, error) {
-	query := `SELECT COUNT(*) FROM room_memberships WHERE room_id = $1 AND role = 'ADMIN'`
-	var count int
-	err := r.pool.QueryRow(ctx, query, roomID).Scan(&count)
+// TransferOwnership makes newOwnerID the room's owner and demotes the current owner to admin.
+func (r *RoomRepository) TransferOwnership(ctx context.Context, roomID, ownerID, newOwnerID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE room_memberships SET role = $3, updated_at = NOW() WHERE room_id = $1 AND user_id = $2`
+	cmdTag, err := tx.Exec(ctx, query, roomID, newOwnerID, room.OwnerRole)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count admins: %w", err)
+		return fmt.Errorf("failed to promote new owner: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrNotMember
 	}
-	return count, nil
+	if _, err := tx.Exec(ctx, query, roomID, ownerID, room.AdminRole); err != nil {
+		return fmt.Errorf("failed to demote previous owner: %w", err)
+	}
+
+	return tx.Commit(ctx)
 }
 
 
 // ============================================================================
-// Provider Methods (for other domains)
+// Invitation Operations
 // ============================================================================
 
-// GetRoomInfo provides minimal, shared room data for other services.
-func (r *RoomRepository) GetRoomInfo(ctx context.Context, roomID string) (*types.RoomInfo, error) {
-	query := `SELECT id, type, is_broadcast_only FROM rooms WHERE id = $1 AND deleted_at IS NULL`
-	var info types.RoomInfo
-	err := r.pool.QueryRow(ctx, query, roomID).Scan(
-		&info.ID,
-		&info.Type,
-		&info.IsBroadcastOnly,
+const invitationDetailQuery = `
+    SELECT ri.id, ri.room_id, ri.inviter_id, ri.invitee_id, ri.status, ri.expires_at, ri.responded_at, ri.created_at, ri.updated_at,
+           COALESCE(r.name, ''),
+           inviter.id, inviter.name, inviter.image_url,
+           invitee.id, invitee.name, invitee.image_url
+    FROM room_invitations ri
+    JOIN rooms r ON r.id = ri.room_id
+    LEFT JOIN users inviter ON inviter.id = ri.inviter_id
+    JOIN users invitee ON invitee.id = ri.invitee_id
+`
+
+// CreateInvitation inserts a pending invitation and its outbox event in one transaction.
+// A lapsed pending invitation for the same user is marked expired first so it does not block the new one.
+func (r *RoomRepository) CreateInvitation(ctx context.Context, inv *room.Invitation, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	expireQuery := `
+        UPDATE room_invitations SET status = 'EXPIRED'
+        WHERE room_id = $1 AND invitee_id = $2 AND status = 'PENDING' AND expires_at <= NOW()
+    `
+	if _, err := tx.Exec(ctx, expireQuery, inv.RoomID, inv.InviteeID); err != nil {
+		return fmt.Errorf("failed to expire lapsed invitations: %w", err)
+	}
+
+	insertQuery := `
+        INSERT INTO room_invitations (id, room_id, inviter_id, invitee_id, status, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at, updated_at
+    `
+	err = tx.QueryRow(ctx, insertQuery, inv.ID, inv.RoomID, inv.InviterID, inv.InviteeID, inv.Status, inv.ExpiresAt).Scan(
+		&inv.CreatedAt,
+		&inv.UpdatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, room.ErrRoomNotFound 
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_room_invitations_pending" {
+			return room.ErrAlreadyInvited
 		}
-		return nil, fmt.Errorf("failed to get room info: %w", err)
+		return fmt.Errorf("failed to create invitation: %w", err)
 	}
-	return &info, nil
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
-// GetMembershipInfo provides minimal, shared membership data for other services.
-func (r *RoomRepository) GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error) {
-	query := `SELECT room_id, user_id, role FROM room_memberships WHERE room_id = $1 AND user_id = $2`
-	var info types.MembershipInfo
-	err := r.pool.QueryRow(ctx, query, roomID, userID).Scan(
-		&info.RoomID,
-		&info.UserID,
-		&info.Role,
+func (r *RoomRepository) FindInvitationByID(ctx context.Context, invitationID string) (*room.Invitation, error) {
+	query := `
+        SELECT id, room_id, inviter_id, invitee_id, status, expires_at, responded_at, created_at, updated_at
+        FROM room_invitations WHERE id = $1
+    `
+	var inv room.Invitation
+	err := r.pool.QueryRow(ctx, query, invitationID).Scan(
+		&inv.ID, &inv.RoomID, &inv.InviterID, &inv.InviteeID, &inv.Status,
+		&inv.ExpiresAt, &inv.RespondedAt, &inv.CreatedAt, &inv.UpdatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, room.ErrNotMember 
+			return nil, room.ErrInvitationNotFound
 		}
-		return nil, fmt.Errorf("failed to get membership info: %w", err)
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
 	}
-	return &info, nil
+	return &inv, nil
 }
 
+// RenewInvitation extends a pending invitation to inv.ExpiresAt and stores its new notification event.
+func (r *RoomRepository) RenewInvitation(ctx context.Context, inv *room.Invitation, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-// scanRoom is a helper to scan a room record from a pgx.Row scanner.
-func scanRoom(row pgx.Row) (*room.Room, error) {
-	var r room.Room
-	err := row.Scan(
-		&r.ID,
-		&r.Name,
-		&r.Type,
-		&r.CreatedAt,
-		&r.UpdatedAt,
+	query := `
+        UPDATE room_invitations SET expires_at = $2
+        WHERE id = $1 AND status = 'PENDING'
+        RETURNING updated_at
+    `
+	if err := tx.QueryRow(ctx, query, inv.ID, inv.ExpiresAt).Scan(&inv.UpdatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return room.ErrInvitationNotPending
+		}
+		return fmt.Errorf("failed to renew invitation: %w", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// SetInvitationStatus closes a pending invitation. Accepting also adds the
+// invitee as a member, and only succeeds while the invitation is unexpired.
+func (r *RoomRepository) SetInvitationStatus(ctx context.Context, inv *room.Invitation, status room.InvitationStatus) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE room_invitations SET status = $2, responded_at = NOW()
+        WHERE id = $1 AND status = 'PENDING' AND ($2 <> 'ACCEPTED' OR expires_at > NOW())
+        RETURNING responded_at, updated_at
+    `
+	if err := tx.QueryRow(ctx, query, inv.ID, status).Scan(&inv.RespondedAt, &inv.UpdatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return room.ErrInvitationNotPending
+		}
+		return fmt.Errorf("failed to update invitation status: %w", err)
+	}
+	inv.Status = status
+
+	if status == room.InvitationAccepted {
+		membershipQuery := `
+            INSERT INTO room_memberships (room_id, user_id, role, created_at, updated_at)
+            VALUES ($1, $2, 'MEMBER', NOW(), NOW())
+            ON CONFLICT (room_id, user_id) DO NOTHING
+        `
+		if _, err := tx.Exec(ctx, membershipQuery, inv.RoomID, inv.InviteeID); err != nil {
+			return fmt.Errorf("failed to create membership for invitee: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListRoomInvitations returns a room's pending invitations, including lapsed ones an admin may resend.
+func (r *RoomRepository) ListRoomInvitations(ctx context.Context, roomID string) ([]*room.InvitationDetail, error) {
+	query := invitationDetailQuery + `
+        WHERE ri.room_id = $1 AND ri.status = 'PENDING'
+        ORDER BY ri.created_at DESC
+    `
+	return r.queryInvitationDetails(ctx, query, roomID)
+}
+
+// ListUserInvitations returns the invitations a user can still accept.
+func (r *RoomRepository) ListUserInvitations(ctx context.Context, userID string) ([]*room.InvitationDetail, error) {
+	query := invitationDetailQuery + `
+        WHERE ri.invitee_id = $1 AND ri.status = 'PENDING' AND ri.expires_at > NOW() AND r.deleted_at IS NULL
+        ORDER BY ri.created_at DESC
+    `
+	return r.queryInvitationDetails(ctx, query, userID)
+}
+
+func (r *RoomRepository) queryInvitationDetails(ctx context.Context, query string, args ...any) ([]*room.InvitationDetail, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []*room.InvitationDetail
+	for rows.Next() {
+		var d room.InvitationDetail
+		var inviterID, inviterName, inviterImage, inviteeImage *string
+		var invitee types.BasicUser
+		err := rows.Scan(
+			&d.ID, &d.RoomID, &d.InviterID, &d.InviteeID, &d.Status, &d.ExpiresAt, &d.RespondedAt, &d.CreatedAt, &d.UpdatedAt,
+			&d.RoomName,
+			&inviterID, &inviterName, &inviterImage,
+			&invitee.ID, &invitee.Name, &inviteeImage,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+
+		if inviterID != nil {
+			d.Inviter = &types.BasicUser{ID: *inviterID}
+			if inviterName != nil {
+				d.Inviter.Name = *inviterName
+			}
+			if inviterImage != nil {
+				d.Inviter.ImageURL = *inviterImage
+			}
+		}
+		if inviteeImage != nil {
+			invitee.ImageURL = *inviteeImage
+		}
+		d.Invitee = &invitee
+		invitations = append(invitations, &d)
+	}
+	return invitations, rows.Err()
+}
+
+// ============================================================================
+// Invite Link Operations
+// ============================================================================
+
+// inviteLinkUsable restricts a query on room_invite_links l to links that can still be redeemed.
+const inviteLinkUsable = `
+    l.revoked_at IS NULL
+    AND (l.expires_at IS NULL OR l.expires_at > NOW())
+    AND (l.max_uses IS NULL OR l.use_count < l.max_uses)
+`
+
+func (r *RoomRepository) CreateInviteLink(ctx context.Context, link *room.InviteLink) error {
+	query := `
+        INSERT INTO room_invite_links (id, room_id, created_by, token_hash, role, max_uses, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING created_at, updated_at
+    `
+	err := r.pool.QueryRow(ctx, query,
+		link.ID, link.RoomID, link.CreatedBy, link.TokenHash, link.Role, link.MaxUses, link.ExpiresAt,
+	).Scan(&link.CreatedAt, &link.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create invite link: %w", err)
+	}
+	return nil
+}
+
+func (r *RoomRepository) ListInviteLinks(ctx context.Context, roomID string) ([]*room.InviteLink, error) {
+	query := `
+        SELECT id, room_id, created_by, token_hash, role, max_uses, use_count, expires_at, revoked_at, created_at, updated_at
+        FROM room_invite_links
+        WHERE room_id = $1
+        ORDER BY created_at DESC
+    `
+	rows, err := r.pool.Query(ctx, query, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invite links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*room.InviteLink
+	for rows.Next() {
+		var l room.InviteLink
+		err := rows.Scan(
+			&l.ID, &l.RoomID, &l.CreatedBy, &l.TokenHash, &l.Role, &l.MaxUses, &l.UseCount,
+			&l.ExpiresAt, &l.RevokedAt, &l.CreatedAt, &l.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invite link: %w", err)
+		}
+		links = append(links, &l)
+	}
+	return links, rows.Err()
+}
+
+func (r *RoomRepository) RevokeInviteLink(ctx context.Context, roomID, linkID string) error {
+	query := `
+        UPDATE room_invite_links SET revoked_at = COALESCE(revoked_at, NOW())
+        WHERE id = $1 AND room_id = $2
+    `
+	cmdTag, err := r.pool.Exec(ctx, query, linkID, roomID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke invite link: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrInviteLinkNotFound
+	}
+	return nil
+}
+
+func (r *RoomRepository) GetInviteLinkPreview(ctx context.Context, tokenHash string) (*room.InviteLinkPreview, error) {
+	query := `
+        SELECT r.id, COALESCE(r.name, ''),
+               (SELECT COUNT(*) FROM room_memberships m WHERE m.room_id = r.id),
+               l.expires_at
+        FROM room_invite_links l
+        JOIN rooms r ON r.id = l.room_id
+        WHERE l.token_hash = $1 AND r.deleted_at IS NULL AND r.archived_at IS NULL AND ` + inviteLinkUsable
+
+	var p room.InviteLinkPreview
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(&p.RoomID, &p.RoomName, &p.MemberCount, &p.ExpiresAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, room.ErrInviteLinkInvalid
+		}
+		return nil, fmt.Errorf("failed to get invite link preview: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *RoomRepository) RedeemInviteLink(ctx context.Context, tokenHash, userID string) (*room.RoomMembership, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Resolve the link to its room and granted role.
+	var linkID string
+	membership := &room.RoomMembership{UserID: userID}
+	lookupQuery := `
+        SELECT l.id, l.room_id, l.role
+        FROM room_invite_links l
+        JOIN rooms r ON r.id = l.room_id
+        WHERE l.token_hash = $1 AND r.deleted_at IS NULL AND r.archived_at IS NULL
+    `
+	if err := tx.QueryRow(ctx, lookupQuery, tokenHash).Scan(&linkID, &membership.RoomID, &membership.Role); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, room.ErrInviteLinkInvalid
+		}
+		return nil, fmt.Errorf("failed to find invite link: %w", err)
+	}
+
+	// 2. Banned users cannot join through a link.
+	banned, err := isBanned(ctx, tx, membership.RoomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if banned {
+		return nil, room.ErrBanned
+	}
+
+	// 3. Add the membership; existing members do not use up the link.
+	membershipQuery := `
+        INSERT INTO room_memberships (room_id, user_id, role, created_at, updated_at)
+        VALUES ($1, $2, $3, NOW(), NOW())
+        ON CONFLICT (room_id, user_id) DO NOTHING
+        RETURNING created_at, updated_at
+    `
+	err = tx.QueryRow(ctx, membershipQuery, membership.RoomID, userID, membership.Role).Scan(&membership.CreatedAt, &membership.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, room.ErrAlreadyInRoom
+		}
+		return nil, fmt.Errorf("failed to create membership from invite link: %w", err)
+	}
+
+	// 4. Count the use. The row lock taken here serialises concurrent redemptions,
+	// and the usability check is re-evaluated after waiting for it.
+	useQuery := `
+        UPDATE room_invite_links l SET use_count = l.use_count + 1
+        WHERE l.id = $1 AND ` + inviteLinkUsable
+	cmdTag, err := tx.Exec(ctx, useQuery, linkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record invite link use: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return nil, room.ErrInviteLinkInvalid
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit invite link redemption: %w", err)
+	}
+	return membership, nil
+}
+
+// ============================================================================
+// Join Request Operations
+// ============================================================================
+
+const joinRequestColumns = `jr.id, jr.room_id, jr.user_id, jr.message, jr.status, jr.responded_by, jr.responded_at, jr.created_at, jr.updated_at`
+
+// CreateJoinRequest inserts a pending join request and its outbox events in one transaction.
+func (r *RoomRepository) CreateJoinRequest(ctx context.Context, req *room.JoinRequest, events ...*contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        INSERT INTO room_join_requests (id, room_id, user_id, message, status)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING created_at, updated_at
+    `
+	err = tx.QueryRow(ctx, query, req.ID, req.RoomID, req.UserID, req.Message, req.Status).Scan(&req.CreatedAt, &req.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_room_join_requests_pending" {
+			return room.ErrJoinRequestExists
+		}
+		return fmt.Errorf("failed to create join request: %w", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *RoomRepository) FindJoinRequestByID(ctx context.Context, requestID string) (*room.JoinRequest, error) {
+	query := `SELECT ` + joinRequestColumns + ` FROM room_join_requests jr WHERE jr.id = $1`
+	var jr room.JoinRequest
+	err := r.pool.QueryRow(ctx, query, requestID).Scan(
+		&jr.ID, &jr.RoomID, &jr.UserID, &jr.Message, &jr.Status, &jr.RespondedBy, &jr.RespondedAt, &jr.CreatedAt, &jr.UpdatedAt,
 	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, room.ErrJoinRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to find join request: %w", err)
+	}
+	return &jr, nil
+}
+
+func (r *RoomRepository) HasPendingJoinRequest(ctx context.Context, roomID, userID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM room_join_requests WHERE room_id = $1 AND user_id = $2 AND status = $3)`
+	var pending bool
+	if err := r.pool.QueryRow(ctx, query, roomID, userID, room.JoinRequestPending).Scan(&pending); err != nil {
+		return false, fmt.Errorf("failed to check pending join request: %w", err)
+	}
+	return pending, nil
+}
+
+// ResolveJoinRequest closes a pending join request, creating the membership
+// when it is approved, and stores events in the same transaction.
+func (r *RoomRepository) ResolveJoinRequest(ctx context.Context, req *room.JoinRequest, status room.JoinRequestStatus, respondedBy *string, events ...*contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE room_join_requests SET status = $2, responded_by = $3, responded_at = NOW()
+        WHERE id = $1 AND status = 'PENDING'
+        RETURNING responded_at, updated_at
+    `
+	if err := tx.QueryRow(ctx, query, req.ID, status, respondedBy).Scan(&req.RespondedAt, &req.UpdatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return room.ErrJoinRequestNotPending
+		}
+		return fmt.Errorf("failed to update join request status: %w", err)
+	}
+	req.Status = status
+	req.RespondedBy = respondedBy
+
+	if status == room.JoinRequestApproved {
+		membershipQuery := `
+            INSERT INTO room_memberships (room_id, user_id, role, created_at, updated_at)
+            VALUES ($1, $2, 'MEMBER', NOW(), NOW())
+            ON CONFLICT (room_id, user_id) DO NOTHING
+        `
+		if _, err := tx.Exec(ctx, membershipQuery, req.RoomID, req.UserID); err != nil {
+			return fmt.Errorf("failed to create membership for join request: %w", err)
+		}
+	}
+
+	if err := insertOutboxEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListRoomJoinRequests returns a room's pending join requests, oldest first, with the requesting users.
+func (r *RoomRepository) ListRoomJoinRequests(ctx context.Context, roomID string) ([]*room.JoinRequest, error) {
+	query := `
+        SELECT ` + joinRequestColumns + `, u.name, u.image_url, u.is_bot
+        FROM room_join_requests jr
+        JOIN users u ON u.id = jr.user_id
+        WHERE jr.room_id = $1 AND jr.status = 'PENDING' AND u.deleted_at IS NULL
+        ORDER BY jr.created_at ASC
+    `
+	rows, err := r.pool.Query(ctx, query, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list join requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*room.JoinRequest
+	for rows.Next() {
+		var jr room.JoinRequest
+		var name string
+		var imageURL *string
+		var isBot bool
+		err := rows.Scan(
+			&jr.ID, &jr.RoomID, &jr.UserID, &jr.Message, &jr.Status, &jr.RespondedBy, &jr.RespondedAt, &jr.CreatedAt, &jr.UpdatedAt,
+			&name, &imageURL, &isBot,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan join request: %w", err)
+		}
+		jr.User = &types.BasicUser{ID: jr.UserID, Name: name, IsBot: isBot}
+		if imageURL != nil {
+			jr.User.ImageURL = *imageURL
+		}
+		requests = append(requests, &jr)
+	}
+	return requests, rows.Err()
+}
+
+// ListUserJoinRequests returns the join requests a user is still waiting on.
+func (r *RoomRepository) ListUserJoinRequests(ctx context.Context, userID string) ([]*room.JoinRequest, error) {
+	query := `
+        SELECT ` + joinRequestColumns + `, COALESCE(r.name, '')
+        FROM room_join_requests jr
+        JOIN rooms r ON r.id = jr.room_id
+        WHERE jr.user_id = $1 AND jr.status = 'PENDING' AND r.deleted_at IS NULL
+        ORDER BY jr.created_at DESC
+    `
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list join requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*room.JoinRequest
+	for rows.Next() {
+		var jr room.JoinRequest
+		err := rows.Scan(
+			&jr.ID, &jr.RoomID, &jr.UserID, &jr.Message, &jr.Status, &jr.RespondedBy, &jr.RespondedAt, &jr.CreatedAt, &jr.UpdatedAt,
+			&jr.RoomName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan join request: %w", err)
+		}
+		requests = append(requests, &jr)
+	}
+	return requests, rows.Err()
+}
+
+// ============================================================================
+// Room Preference & Folder Operations
+// ============================================================================
+
+const roomPrefsColumns = `is_pinned, is_favorite, hidden_at, folder_id, position`
+
+func (r *RoomRepository) GetRoomPrefs(ctx context.Context, roomID, userID string) (*types.RoomPrefs, error) {
+	query := `SELECT ` + roomPrefsColumns + ` FROM room_memberships WHERE room_id = $1 AND user_id = $2`
+	var p types.RoomPrefs
+	err := r.pool.QueryRow(ctx, query, roomID, userID).Scan(&p.IsPinned, &p.IsFavorite, &p.HiddenAt, &p.FolderID, &p.Position)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, room.ErrNotMember
+		}
+		return nil, fmt.Errorf("failed to get room prefs: %w", err)
+	}
+	return &p, nil
+}
+
+// UpdateRoomPrefs saves a member's room list state and stores event in the same transaction.
+func (r *RoomRepository) UpdateRoomPrefs(ctx context.Context, roomID, userID string, prefs *types.RoomPrefs, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE room_memberships
+        SET is_pinned = $3, is_favorite = $4, hidden_at = $5, folder_id = $6, position = $7, updated_at = NOW()
+        WHERE room_id = $1 AND user_id = $2
+    `
+	cmdTag, err := tx.Exec(ctx, query, roomID, userID, prefs.IsPinned, prefs.IsFavorite, prefs.HiddenAt, prefs.FolderID, prefs.Position)
+	if err != nil {
+		return fmt.Errorf("failed to update room prefs: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrNotMember
+	}
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UnhideRoom brings a room back into the room lists of those userIDs who had
+// hidden it, storing an event for each from buildEvent in the same transaction.
+func (r *RoomRepository) UnhideRoom(ctx context.Context, roomID string, userIDs []string, buildEvent func(userID string, prefs types.RoomPrefs) (*contracts.OutboxEvent, error)) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE room_memberships SET hidden_at = NULL, updated_at = NOW()
+        WHERE room_id = $1 AND user_id = ANY($2) AND hidden_at IS NOT NULL
+        RETURNING user_id, ` + roomPrefsColumns
+	rows, err := tx.Query(ctx, query, roomID, userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to unhide room: %w", err)
+	}
+	type unhidden struct {
+		userID string
+		prefs  types.RoomPrefs
+	}
+	members, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (unhidden, error) {
+		var u unhidden
+		p := &u.prefs
+		err := row.Scan(&u.userID, &p.IsPinned, &p.IsFavorite, &p.HiddenAt, &p.FolderID, &p.Position)
+		return u, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan unhidden memberships: %w", err)
+	}
+
+	for _, m := range members {
+		event, err := buildEvent(m.userID, m.prefs)
+		if err != nil {
+			return err
+		}
+		if err := insertOutboxEvents(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// CreateFolder inserts a folder after the user's existing ones and stores its event in the same transaction.
+func (r *RoomRepository) CreateFolder(ctx context.Context, folder *room.Folder, buildEvent room.FolderEventBuilder) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        INSERT INTO room_folders (id, user_id, name, position)
+        VALUES ($1, $2, $3, (SELECT COALESCE(MAX(position) + 1, 0) FROM room_folders WHERE user_id = $2))
+        RETURNING position, created_at, updated_at
+    `
+	err = tx.QueryRow(ctx, query, folder.ID, folder.UserID, folder.Name).Scan(&folder.Position, &folder.CreatedAt, &folder.UpdatedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return room.ErrFolderExists
+		}
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+
+	event, err := buildEvent(folder)
+	if err != nil {
+		return err
+	}
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *RoomRepository) FindFolder(ctx context.Context, userID, folderID string) (*room.Folder, error) {
+	query := `SELECT id, user_id, name, position, created_at, updated_at FROM room_folders WHERE id = $1 AND user_id = $2`
+	var f room.Folder
+	err := r.pool.QueryRow(ctx, query, folderID, userID).Scan(&f.ID, &f.UserID, &f.Name, &f.Position, &f.CreatedAt, &f.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, room.ErrFolderNotFound
+		}
+		return nil, fmt.Errorf("failed to find folder: %w", err)
+	}
+	return &f, nil
+}
+
+func (r *RoomRepository) ListFolders(ctx context.Context, userID string) ([]*room.Folder, error) {
+	query := `
+        SELECT id, user_id, name, position, created_at, updated_at
+        FROM room_folders
+        WHERE user_id = $1
+        ORDER BY position ASC, created_at ASC
+    `
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list folders: %w", err)
+	}
+	defer rows.Close()
+
+	var folders []*room.Folder
+	for rows.Next() {
+		var f room.Folder
+		if err := rows.Scan(&f.ID, &f.UserID, &f.Name, &f.Position, &f.CreatedAt, &f.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan folder: %w", err)
+		}
+		folders = append(folders, &f)
+	}
+	return folders, rows.Err()
+}
+
+// UpdateFolder renames or moves a folder and stores event in the same transaction.
+func (r *RoomRepository) UpdateFolder(ctx context.Context, folder *room.Folder, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE room_folders SET name = $3, position = $4
+        WHERE id = $1 AND user_id = $2
+        RETURNING updated_at
+    `
+	err = tx.QueryRow(ctx, query, folder.ID, folder.UserID, folder.Name, folder.Position).Scan(&folder.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return room.ErrFolderNotFound
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return room.ErrFolderExists
+		}
+		return fmt.Errorf("failed to update folder: %w", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// DeleteFolder removes a folder; the foreign key moves its rooms out of it.
+func (r *RoomRepository) DeleteFolder(ctx context.Context, userID, folderID string, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	cmdTag, err := tx.Exec(ctx, `DELETE FROM room_folders WHERE id = $1 AND user_id = $2`, folderID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete folder: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrFolderNotFound
+	}
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ============================================================================
+// Ban & Mute Operations
+// ============================================================================
+
+// activeBan matches room_bans rows that are still in force.
+const activeBan = `(expires_at IS NULL OR expires_at > NOW())`
+
+// activeMute selects a membership's muted_until only while the mute is in force.
+const activeMute = `CASE WHEN rm.muted_until > NOW() THEN rm.muted_until END`
+
+// BanMember stores or replaces a ban and, in the same transaction, removes the
+// user's membership, closes their pending invitations and join requests and
+// stores events.
+func (r *RoomRepository) BanMember(ctx context.Context, ban *room.Ban, events ...*contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	banQuery := `
+        INSERT INTO room_bans (id, room_id, user_id, banned_by, reason, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (room_id, user_id) DO UPDATE
+        SET banned_by = EXCLUDED.banned_by, reason = EXCLUDED.reason, expires_at = EXCLUDED.expires_at, created_at = NOW()
+        RETURNING id, created_at
+    `
+	err = tx.QueryRow(ctx, banQuery, ban.ID, ban.RoomID, ban.UserID, ban.BannedBy, ban.Reason, ban.ExpiresAt).Scan(&ban.ID, &ban.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create ban: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM room_memberships WHERE room_id = $1 AND user_id = $2`, ban.RoomID, ban.UserID); err != nil {
+		return fmt.Errorf("failed to remove banned member: %w", err)
+	}
+	revokeQuery := `
+        UPDATE room_invitations SET status = 'REVOKED', responded_at = NOW()
+        WHERE room_id = $1 AND invitee_id = $2 AND status = 'PENDING'
+    `
+	if _, err := tx.Exec(ctx, revokeQuery, ban.RoomID, ban.UserID); err != nil {
+		return fmt.Errorf("failed to revoke banned user's invitations: %w", err)
+	}
+	denyQuery := `
+        UPDATE room_join_requests SET status = 'DENIED', responded_by = $3, responded_at = NOW()
+        WHERE room_id = $1 AND user_id = $2 AND status = 'PENDING'
+    `
+	if _, err := tx.Exec(ctx, denyQuery, ban.RoomID, ban.UserID, ban.BannedBy); err != nil {
+		return fmt.Errorf("failed to deny banned user's join requests: %w", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, events...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UnbanMember lifts an active ban and stores event in the same transaction.
+func (r *RoomRepository) UnbanMember(ctx context.Context, roomID, userID string, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `DELETE FROM room_bans WHERE room_id = $1 AND user_id = $2 AND ` + activeBan
+	cmdTag, err := tx.Exec(ctx, query, roomID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to lift ban: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrBanNotFound
+	}
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *RoomRepository) IsBanned(ctx context.Context, roomID, userID string) (bool, error) {
+	return isBanned(ctx, r.pool, roomID, userID)
+}
+
+// ListBans returns a room's bans that are still in force, newest first.
+func (r *RoomRepository) ListBans(ctx context.Context, roomID string) ([]*room.Ban, error) {
+	query := `
+        SELECT b.id, b.room_id, b.user_id, b.banned_by, b.reason, b.expires_at, b.created_at,
+               u.name, u.image_url, u.is_bot
+        FROM room_bans b
+        JOIN users u ON u.id = b.user_id
+        WHERE b.room_id = $1 AND (b.expires_at IS NULL OR b.expires_at > NOW())
+        ORDER BY b.created_at DESC
+    `
+	rows, err := r.pool.Query(ctx, query, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bans: %w", err)
+	}
+	defer rows.Close()
+
+	var bans []*room.Ban
+	for rows.Next() {
+		var b room.Ban
+		var name string
+		var imageURL *string
+		var isBot bool
+		if err := rows.Scan(&b.ID, &b.RoomID, &b.UserID, &b.BannedBy, &b.Reason, &b.ExpiresAt, &b.CreatedAt, &name, &imageURL, &isBot); err != nil {
+			return nil, fmt.Errorf("failed to scan ban: %w", err)
+		}
+		b.User = &types.BasicUser{ID: b.UserID, Name: name, IsBot: isBot}
+		if imageURL != nil {
+			b.User.ImageURL = *imageURL
+		}
+		bans = append(bans, &b)
+	}
+	return bans, rows.Err()
+}
+
+// SetMemberMute sets or, with a nil mutedUntil, clears a member's mute and
+// stores event in the same transaction. Clearing fails with ErrNotMuted unless
+// a mute is in force.
+func (r *RoomRepository) SetMemberMute(ctx context.Context, roomID, userID string, mutedUntil *time.Time, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE room_memberships SET muted_until = $3, updated_at = NOW()
+        WHERE room_id = $1 AND user_id = $2 AND ($3::timestamptz IS NOT NULL OR muted_until > NOW())
+    `
+	cmdTag, err := tx.Exec(ctx, query, roomID, userID, mutedUntil)
+	if err != nil {
+		return fmt.Errorf("failed to set member mute: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		if mutedUntil == nil {
+			return room.ErrNotMuted
+		}
+		return room.ErrNotMember
+	}
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ExpireBans deletes lapsed bans, storing the event buildEvent produces for
+// each in the same transaction, and returns how many were removed.
+func (r *RoomRepository) ExpireBans(ctx context.Context, buildEvent room.MemberEventBuilder) (int, error) {
+	query := `DELETE FROM room_bans WHERE expires_at <= NOW() RETURNING room_id, user_id`
+	return r.expireSanctions(ctx, query, buildEvent)
+}
+
+// ExpireMutes clears lapsed mutes, storing the event buildEvent produces for
+// each in the same transaction, and returns how many were cleared.
+func (r *RoomRepository) ExpireMutes(ctx context.Context, buildEvent room.MemberEventBuilder) (int, error) {
+	query := `UPDATE room_memberships SET muted_until = NULL WHERE muted_until <= NOW() RETURNING room_id, user_id`
+	return r.expireSanctions(ctx, query, buildEvent)
+}
+
+// ============================================================================
+// Archive & Deletion Operations
+// ============================================================================
+
+// SetRoomArchived archives or unarchives a room and stores event in the same transaction.
+func (r *RoomRepository) SetRoomArchived(ctx context.Context, roomID string, archived bool, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The state check makes a concurrent archive (or unarchive) lose cleanly.
+	query := `
+        UPDATE rooms SET archived_at = CASE WHEN $2 THEN NOW() END, updated_at = NOW()
+        WHERE id = $1 AND deleted_at IS NULL AND (archived_at IS NULL) = $2
+    `
+	cmdTag, err := tx.Exec(ctx, query, roomID, archived)
+	if err != nil {
+		return fmt.Errorf("failed to set room archived: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		if archived {
+			return room.ErrAlreadyArchived
+		}
+		return room.ErrRoomNotArchived
+	}
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// SoftDeleteRoom marks a room deleted at deletedAt and stores event in the same transaction.
+func (r *RoomRepository) SoftDeleteRoom(ctx context.Context, roomID string, deletedAt time.Time, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	cmdTag, err := tx.Exec(ctx, `UPDATE rooms SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`, roomID, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete room: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrRoomNotFound
+	}
+
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RestoreRoom undoes a soft delete made after deletedAfter, provided ownerID
+// still owns the room.
+func (r *RoomRepository) RestoreRoom(ctx context.Context, roomID, ownerID string, deletedAfter time.Time) error {
+	query := `
+        UPDATE rooms SET deleted_at = NULL
+        WHERE id = $1 AND deleted_at > $3
+          AND EXISTS (
+              SELECT 1 FROM room_memberships
+              WHERE room_id = $1 AND user_id = $2 AND role = 'OWNER'
+          )
+    `
+	cmdTag, err := r.pool.Exec(ctx, query, roomID, ownerID, deletedAfter)
+	if err != nil {
+		return fmt.Errorf("failed to restore room: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrRoomNotFound
+	}
+	return nil
+}
+
+// ============================================================================
+// Purge Operations
+// ============================================================================
+
+func (r *RoomRepository) ListRoomsToPurge(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id FROM rooms WHERE deleted_at < $1 ORDER BY deleted_at`, deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rooms to purge: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan rooms to purge: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *RoomRepository) ListExportFileKeys(ctx context.Context, roomID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT file_key FROM room_exports WHERE room_id = $1 AND file_key IS NOT NULL`, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list export files: %w", err)
+	}
+	keys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan export files: %w", err)
+	}
+	return keys, nil
+}
+
+// PurgeMessagesBatch deletes up to limit of a deleted room's messages. The
+// deleted_at check stops the purge if the room is restored part-way through.
+// Read receipts and per-user deletions go with them through ON DELETE CASCADE.
+func (r *RoomRepository) PurgeMessagesBatch(ctx context.Context, roomID string, deletedBefore time.Time, limit int) (int64, error) {
+	query := `
+        DELETE FROM messages
+        WHERE id IN (
+            SELECT m.id FROM messages m
+            JOIN rooms r ON r.id = m.room_id
+            WHERE m.room_id = $1 AND r.deleted_at < $2
+            LIMIT $3
+        )
+    `
+	cmdTag, err := r.pool.Exec(ctx, query, roomID, deletedBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge room messages: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// PurgeRoom removes a deleted room's row and, by cascade, everything still attached to it.
+func (r *RoomRepository) PurgeRoom(ctx context.Context, roomID string, deletedBefore time.Time) error {
+	cmdTag, err := r.pool.Exec(ctx, `DELETE FROM rooms WHERE id = $1 AND deleted_at < $2`, roomID, deletedBefore)
+	if err != nil {
+		return fmt.Errorf("failed to purge room: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return room.ErrRoomNotFound
+	}
+	return nil
+}
+
+// ============================================================================
+// Provider Methods (for other domains)
+// ============================================================================
+
+// GetRoomInfo provides minimal, shared room data for other services.
+func (r *RoomRepository) GetRoomInfo(ctx context.Context, roomID string) (*types.RoomInfo, error) {
+	query := `SELECT id, COALESCE(name, ''), type, is_broadcast_only, slow_mode_seconds, moderation_settings, member_permissions, archived_at IS NOT NULL FROM rooms WHERE id = $1 AND deleted_at IS NULL`
+	var info types.RoomInfo
+	err := r.pool.QueryRow(ctx, query, roomID).Scan(
+		&info.ID,
+		&info.Name,
+		&info.Type,
+		&info.IsBroadcastOnly,
+		&info.SlowModeSeconds,
+		&info.ModerationSettings,
+		&info.MemberPermissions,
+		&info.IsArchived,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, room.ErrRoomNotFound 
+		}
+		return nil, fmt.Errorf("failed to get room info: %w", err)
+	}
+	return &info, nil
+}
+
+// GetMembershipInfo provides minimal, shared membership data for other services.
+func (r *RoomRepository) GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error) {
+	query := `
+        SELECT rm.room_id, rm.user_id, rm.role, r.member_permissions, ` + activeMute + `
+        FROM room_memberships rm
+        JOIN rooms r ON r.id = rm.room_id
+        WHERE rm.room_id = $1 AND rm.user_id = $2 AND r.deleted_at IS NULL
+    `
+	var info types.MembershipInfo
+	err := r.pool.QueryRow(ctx, query, roomID, userID).Scan(
+		&info.RoomID,
+		&info.UserID,
+		&info.Role,
+		&info.MemberPermissions,
+		&info.MutedUntil,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, room.ErrNotMember 
+		}
+		return nil, fmt.Errorf("failed to get membership info: %w", err)
+	}
+	return &info, nil
+}
+
+
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// isBanned reports whether userID is under a ban in force in roomID.
+func isBanned(ctx context.Context, q querier, roomID, userID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM room_bans WHERE room_id = $1 AND user_id = $2 AND ` + activeBan + `)`
+	var banned bool
+	if err := q.QueryRow(ctx, query, roomID, userID).Scan(&banned); err != nil {
+		return false, fmt.Errorf("failed to check ban: %w", err)
+	}
+	return banned, nil
+}
+
+// expireSanctions runs a statement that lifts lapsed bans or mutes and
+// returns (room_id, user_id) rows, storing one event per row with it.
+func (r *RoomRepository) expireSanctions(ctx context.Context, query string, buildEvent room.MemberEventBuilder) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire sanctions: %w", err)
+	}
+	type member struct{ roomID, userID string }
+	members, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (member, error) {
+		var m member
+		err := row.Scan(&m.roomID, &m.userID)
+		return m, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan expired sanctions: %w", err)
+	}
+
+	events := make([]*contracts.OutboxEvent, 0, len(members))
+	for _, m := range members {
+		event, err := buildEvent(m.roomID, m.userID)
+		if err != nil {
+			return 0, err
+		}
+		events = append(events, event)
+	}
+	if err := insertOutboxEvents(ctx, tx, events...); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit expired sanctions: %w", err)
+	}
+	return len(members), nil
+}
+
+// likeEscaper escapes LIKE wildcards so user search text matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// scanRoom is a helper to scan a room record from a pgx.Row scanner. Any
+// columns selected after the room's own are scanned into extra.
+func scanRoom(row pgx.Row, extra ...any) (*room.Room, error) {
+	var r room.Room
+	dest := []any{
+		&r.ID,
+		&r.WorkspaceID,
+		&r.Name,
+		&r.Description,
+		&r.Topic,
+		&r.AvatarURL,
+		&r.Type,
+		&r.IsBroadcastOnly,
+		&r.IsDiscoverable,
+		&r.SlowModeSeconds,
+		&r.ModerationSettings,
+		&r.MemberPermissions,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&r.ArchivedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan room: %w", err)
 	}
@@ -7,10 +7,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
 	"github.com/purushothdl/gochat-backend/internal/domain/user"
 	"github.com/purushothdl/gochat-backend/internal/shared/types"
 )
@@ -85,7 +87,8 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 
 func (r *UserRepository) GetByEmailShared(ctx context.Context, email string) (*types.User, error) {
     query := `
-        SELECT id, email, name, image_url, created_at, updated_at, is_verified, last_login
+        SELECT id, email, name, image_url, created_at, updated_at, is_verified, last_login,
+               is_admin, suspended_until, is_bot, bot_owner_id
         FROM users 
         WHERE email = $1 AND deleted_at IS NULL
     `
@@ -95,7 +98,8 @@ func (r *UserRepository) GetByEmailShared(ctx context.Context, email string) (*t
 
 func (r *UserRepository) GetByIDShared(ctx context.Context, id string) (*types.User, error) {
     query := `
-        SELECT id, email, name, image_url, created_at, updated_at, is_verified, last_login
+        SELECT id, email, name, image_url, created_at, updated_at, is_verified, last_login,
+               is_admin, suspended_until, is_bot, bot_owner_id
         FROM users 
         WHERE id = $1 AND deleted_at IS NULL
     `
@@ -215,7 +219,7 @@ func (r *UserRepository) UnblockUser(ctx context.Context, blockerID, blockedID s
 // ListBlockedUsers retrieves all users that a specific user has blocked.
 func (r *UserRepository) ListBlockedUsers(ctx context.Context, blockerID string) ([]*types.BasicUser, error) {
 	query := `
-        SELECT u.id, u.name, u.image_url
+        SELECT u.id, u.name, u.image_url, u.is_bot
         FROM users u
         JOIN user_blocks ub ON u.id = ub.blocked_id
         WHERE ub.blocker_id = $1 AND u.deleted_at IS NULL
@@ -240,10 +244,16 @@ func (r *UserRepository) ListBlockedUsers(ctx context.Context, blockerID string)
 }
 
 
-func (r *UserRepository) UpdateUserImageURL(ctx context.Context, userID string, imageURL string) error {
+func (r *UserRepository) UpdateUserImageURL(ctx context.Context, userID string, imageURL string, event *contracts.OutboxEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `UPDATE users SET image_url = $1, updated_at = NOW() WHERE id = $2`
 	
-	cmdTag, err := r.pool.Exec(ctx, query, imageURL, userID)
+	cmdTag, err := tx.Exec(ctx, query, imageURL, userID)
 	if err != nil {
 		return fmt.Errorf("failed to update user image_url: %w", err)
 	}
@@ -251,9 +261,36 @@ func (r *UserRepository) UpdateUserImageURL(ctx context.Context, userID string,
 		return fmt.Errorf("user not found for image_url update")
 	}
 
+	if err := insertOutboxEvents(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SuspendUser bars a user from signing in until the given time.
+func (r *UserRepository) SuspendUser(ctx context.Context, userID string, until time.Time) error {
+	query := `UPDATE users SET suspended_until = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	cmdTag, err := r.pool.Exec(ctx, query, userID, until)
+	if err != nil {
+		return fmt.Errorf("failed to suspend user: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return user.ErrUserNotFound
+	}
 	return nil
 }
 
+// HasBlocked checks whether blockerID has blocked blockedID.
+func (r *UserRepository) HasBlocked(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2)`
+	var blocked bool
+	if err := r.pool.QueryRow(ctx, query, blockerID, blockedID).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+	return blocked, nil
+}
+
 // IsBlocked checks if a communication block exists between two users, in either direction.
 func (r *UserRepository) IsBlocked(ctx context.Context, userID1, userID2 string) (bool, error) {
 	query := `
@@ -328,6 +365,7 @@ func (r *UserRepository) scanSharedUser(ctx context.Context, query string, args
     err := r.pool.QueryRow(ctx, query, args...).Scan(
         &u.ID, &u.Email, &u.Name, &imageURL,  
         &u.CreatedAt, &u.UpdatedAt, &u.IsVerified, &lastLogin,
+        &u.IsAdmin, &u.SuspendedUntil, &u.IsBot, &u.BotOwnerID,
     )
 
     if err != nil {
@@ -354,7 +392,7 @@ func scanBasicUserFromRow(row rowScanner) (*types.BasicUser, error) {
 	var u types.BasicUser
 	var imageURL sql.NullString
 
-	err := row.Scan(&u.ID, &u.Name, &imageURL)
+	err := row.Scan(&u.ID, &u.Name, &imageURL, &u.IsBot)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,485 @@
+// internal/infrastructure/postgres/webhook_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/domain/webhook"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool: pool}
+}
+
+const (
+	incomingWebhookColumns = `id, room_id, name, avatar_url, token_hash, created_by, last_used_at, created_at, updated_at`
+	outgoingWebhookColumns = `id, room_id, workspace_id, url, secret, events, is_active, created_by, created_at, updated_at`
+	deliveryColumns        = `id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+        last_status_code, last_error, delivered_at, created_at, updated_at`
+)
+
+// ============================================================================
+// Incoming Webhook Operations
+// ============================================================================
+
+func (r *WebhookRepository) CreateIncomingWebhook(ctx context.Context, hook *webhook.IncomingWebhook) error {
+	query := `
+        INSERT INTO incoming_webhooks (id, room_id, name, avatar_url, token_hash, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at, updated_at
+    `
+	err := r.pool.QueryRow(ctx, query, hook.ID, hook.RoomID, hook.Name, hook.AvatarURL, hook.TokenHash, hook.CreatedBy).
+		Scan(&hook.CreatedAt, &hook.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create incoming webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) ListIncomingWebhooks(ctx context.Context, roomID string) ([]*webhook.IncomingWebhook, error) {
+	query := `SELECT ` + incomingWebhookColumns + ` FROM incoming_webhooks WHERE room_id = $1 ORDER BY created_at DESC`
+	rows, err := r.pool.Query(ctx, query, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incoming webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []*webhook.IncomingWebhook
+	for rows.Next() {
+		hook, err := scanIncomingWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incoming webhook: %w", err)
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, rows.Err()
+}
+
+func (r *WebhookRepository) FindIncomingWebhook(ctx context.Context, roomID, webhookID string) (*webhook.IncomingWebhook, error) {
+	query := `SELECT ` + incomingWebhookColumns + ` FROM incoming_webhooks WHERE id = $1 AND room_id = $2`
+	hook, err := scanIncomingWebhook(r.pool.QueryRow(ctx, query, webhookID, roomID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, webhook.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to find incoming webhook: %w", err)
+	}
+	return hook, nil
+}
+
+func (r *WebhookRepository) FindIncomingWebhookByTokenHash(ctx context.Context, tokenHash string) (*webhook.IncomingWebhook, error) {
+	query := `SELECT ` + incomingWebhookColumns + ` FROM incoming_webhooks WHERE token_hash = $1`
+	hook, err := scanIncomingWebhook(r.pool.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, webhook.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to find incoming webhook: %w", err)
+	}
+	return hook, nil
+}
+
+func (r *WebhookRepository) UpdateIncomingWebhook(ctx context.Context, hook *webhook.IncomingWebhook) error {
+	query := `
+        UPDATE incoming_webhooks SET name = $3, avatar_url = $4
+        WHERE id = $1 AND room_id = $2
+        RETURNING updated_at
+    `
+	err := r.pool.QueryRow(ctx, query, hook.ID, hook.RoomID, hook.Name, hook.AvatarURL).Scan(&hook.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return webhook.ErrWebhookNotFound
+		}
+		return fmt.Errorf("failed to update incoming webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) DeleteIncomingWebhook(ctx context.Context, roomID, webhookID string) error {
+	cmdTag, err := r.pool.Exec(ctx, `DELETE FROM incoming_webhooks WHERE id = $1 AND room_id = $2`, webhookID, roomID)
+	if err != nil {
+		return fmt.Errorf("failed to delete incoming webhook: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return webhook.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// TouchIncomingWebhook records that a webhook was just used.
+func (r *WebhookRepository) TouchIncomingWebhook(ctx context.Context, webhookID string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE incoming_webhooks SET last_used_at = NOW() WHERE id = $1`, webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to touch incoming webhook: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// Outgoing Webhook Operations
+// ============================================================================
+
+func (r *WebhookRepository) CreateOutgoingWebhook(ctx context.Context, hook *webhook.OutgoingWebhook) error {
+	query := `
+        INSERT INTO outgoing_webhooks (id, room_id, workspace_id, url, secret, events, is_active, created_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING created_at, updated_at
+    `
+	err := r.pool.QueryRow(ctx, query,
+		hook.ID, hook.RoomID, hook.WorkspaceID, hook.URL, hook.Secret, eventNames(hook.Events), hook.IsActive, hook.CreatedBy,
+	).Scan(&hook.CreatedAt, &hook.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create outgoing webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) ListRoomOutgoingWebhooks(ctx context.Context, roomID string) ([]*webhook.OutgoingWebhook, error) {
+	query := `SELECT ` + outgoingWebhookColumns + ` FROM outgoing_webhooks WHERE room_id = $1 ORDER BY created_at DESC`
+	return r.queryOutgoingWebhooks(ctx, query, roomID)
+}
+
+func (r *WebhookRepository) ListWorkspaceOutgoingWebhooks(ctx context.Context, workspaceID string) ([]*webhook.OutgoingWebhook, error) {
+	query := `SELECT ` + outgoingWebhookColumns + ` FROM outgoing_webhooks WHERE workspace_id = $1 ORDER BY created_at DESC`
+	return r.queryOutgoingWebhooks(ctx, query, workspaceID)
+}
+
+func (r *WebhookRepository) FindOutgoingWebhook(ctx context.Context, webhookID string) (*webhook.OutgoingWebhook, error) {
+	query := `SELECT ` + outgoingWebhookColumns + ` FROM outgoing_webhooks WHERE id = $1`
+	hook, err := scanOutgoingWebhook(r.pool.QueryRow(ctx, query, webhookID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, webhook.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to find outgoing webhook: %w", err)
+	}
+	return hook, nil
+}
+
+func (r *WebhookRepository) UpdateOutgoingWebhook(ctx context.Context, hook *webhook.OutgoingWebhook) error {
+	query := `
+        UPDATE outgoing_webhooks SET url = $2, secret = $3, events = $4, is_active = $5
+        WHERE id = $1
+        RETURNING updated_at
+    `
+	err := r.pool.QueryRow(ctx, query, hook.ID, hook.URL, hook.Secret, eventNames(hook.Events), hook.IsActive).Scan(&hook.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return webhook.ErrWebhookNotFound
+		}
+		return fmt.Errorf("failed to update outgoing webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) DeleteOutgoingWebhook(ctx context.Context, webhookID string) error {
+	cmdTag, err := r.pool.Exec(ctx, `DELETE FROM outgoing_webhooks WHERE id = $1`, webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to delete outgoing webhook: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return webhook.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) ListSubscribedWebhooks(ctx context.Context, roomID string, eventType types.WebhookEventType) ([]*webhook.OutgoingWebhook, error) {
+	query := `
+        SELECT ` + outgoingWebhookColumns + `
+        FROM outgoing_webhooks
+        WHERE is_active AND $2 = ANY(events)
+          AND (room_id = $1 OR workspace_id = (SELECT workspace_id FROM rooms WHERE id = $1))
+    `
+	return r.queryOutgoingWebhooks(ctx, query, roomID, string(eventType))
+}
+
+// ============================================================================
+// Delivery Operations
+// ============================================================================
+
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*webhook.Delivery) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, queued_at)
+        VALUES ($1, $2, $3, $4, $5, $6, NOW())
+        RETURNING attempts, next_attempt_at, created_at, updated_at
+    `
+	batch := &pgx.Batch{}
+	for _, d := range deliveries {
+		batch.Queue(query, d.ID, d.WebhookID, d.EventID, d.EventType, d.Payload, d.Status).
+			QueryRow(func(row pgx.Row) error {
+				return row.Scan(&d.Attempts, &d.NextAttemptAt, &d.CreatedAt, &d.UpdatedAt)
+			})
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter webhook.DeliveryFilter) ([]*webhook.Delivery, error) {
+	args := []any{filter.WebhookID}
+	conditions := []string{"webhook_id = $1"}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit)
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+        WHERE ` + strings.Join(conditions, " AND ") + fmt.Sprintf(`
+        ORDER BY created_at DESC, id DESC
+        LIMIT $%d`, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*webhook.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) FindDelivery(ctx context.Context, webhookID, deliveryID string) (*webhook.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1 AND webhook_id = $2`
+	d, err := scanDelivery(r.pool.QueryRow(ctx, query, deliveryID, webhookID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, webhook.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+	return d, nil
+}
+
+func (r *WebhookRepository) ListDeliveryAttempts(ctx context.Context, deliveryID string) ([]*webhook.DeliveryAttempt, error) {
+	query := `
+        SELECT id, delivery_id, attempt, status_code, error, response_body, duration_ms, created_at
+        FROM webhook_delivery_attempts
+        WHERE delivery_id = $1
+        ORDER BY created_at
+    `
+	rows, err := r.pool.Query(ctx, query, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook delivery attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*webhook.DeliveryAttempt
+	for rows.Next() {
+		var a webhook.DeliveryAttempt
+		var responseBody pgtype.Text
+		var durationMS int64
+		err := rows.Scan(&a.ID, &a.DeliveryID, &a.Attempt, &a.StatusCode, &a.Error, &responseBody, &durationMS, &a.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery attempt: %w", err)
+		}
+		a.ResponseBody = responseBody.String
+		a.Duration = time.Duration(durationMS) * time.Millisecond
+		attempts = append(attempts, &a)
+	}
+	return attempts, rows.Err()
+}
+
+func (r *WebhookRepository) ClaimDelivery(ctx context.Context, deliveryID string, lease time.Duration) (*webhook.Delivery, error) {
+	query := `
+        UPDATE webhook_deliveries
+        SET attempts = attempts + 1, claimed_until = NOW() + $2::interval
+        WHERE id = $1 AND status = 'PENDING' AND next_attempt_at <= NOW()
+          AND (claimed_until IS NULL OR claimed_until < NOW())
+        RETURNING ` + deliveryColumns
+	d, err := scanDelivery(r.pool.QueryRow(ctx, query, deliveryID, lease))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+	return d, nil
+}
+
+func (r *WebhookRepository) CompleteAttempt(ctx context.Context, d *webhook.Delivery, attempt *webhook.DeliveryAttempt) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	attemptQuery := `
+        INSERT INTO webhook_delivery_attempts (id, delivery_id, attempt, status_code, error, response_body, duration_ms)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING created_at
+    `
+	err = tx.QueryRow(ctx, attemptQuery,
+		attempt.ID, attempt.DeliveryID, attempt.Attempt, attempt.StatusCode, attempt.Error, attempt.ResponseBody, attempt.Duration.Milliseconds(),
+	).Scan(&attempt.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+
+	deliveryQuery := `
+        UPDATE webhook_deliveries
+        SET status = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5, delivered_at = $6,
+            claimed_until = NULL, queued_at = NULL
+        WHERE id = $1
+        RETURNING updated_at
+    `
+	err = tx.QueryRow(ctx, deliveryQuery, d.ID, d.Status, d.NextAttemptAt, d.LastStatusCode, d.LastError, d.DeliveredAt).
+		Scan(&d.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *WebhookRepository) ResetDelivery(ctx context.Context, deliveryID string) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET status = 'PENDING', attempts = 0, next_attempt_at = NOW(), queued_at = NOW(), claimed_until = NULL,
+            last_status_code = NULL, last_error = NULL, delivered_at = NULL
+        WHERE id = $1 AND status <> 'PENDING'
+    `
+	cmdTag, err := r.pool.Exec(ctx, query, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to reset webhook delivery: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return webhook.ErrDeliveryInProgress
+	}
+	return nil
+}
+
+func (r *WebhookRepository) RequeueDueDeliveries(ctx context.Context, staleBefore time.Time, limit int) ([]string, error) {
+	query := `
+        UPDATE webhook_deliveries SET queued_at = NOW()
+        WHERE id IN (
+            SELECT id FROM webhook_deliveries
+            WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+              AND (queued_at IS NULL OR queued_at < $1)
+              AND (claimed_until IS NULL OR claimed_until < NOW())
+            ORDER BY next_attempt_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING id
+    `
+	rows, err := r.pool.Query(ctx, query, staleBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to requeue webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// DeleteFinishedDeliveriesBefore removes succeeded and dead-lettered
+// deliveries last updated before cutoff, with their attempts.
+func (r *WebhookRepository) DeleteFinishedDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `DELETE FROM webhook_deliveries WHERE status <> 'PENDING' AND updated_at < $1`
+	cmdTag, err := r.pool.Exec(ctx, query, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// ============================================================================
+// Private Helpers
+// ============================================================================
+
+func scanIncomingWebhook(row pgx.Row) (*webhook.IncomingWebhook, error) {
+	var hook webhook.IncomingWebhook
+	err := row.Scan(
+		&hook.ID, &hook.RoomID, &hook.Name, &hook.AvatarURL, &hook.TokenHash,
+		&hook.CreatedBy, &hook.LastUsedAt, &hook.CreatedAt, &hook.UpdatedAt,
+	)
+	return &hook, err
+}
+
+func (r *WebhookRepository) queryOutgoingWebhooks(ctx context.Context, query string, args ...any) ([]*webhook.OutgoingWebhook, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outgoing webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []*webhook.OutgoingWebhook
+	for rows.Next() {
+		hook, err := scanOutgoingWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outgoing webhook: %w", err)
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, rows.Err()
+}
+
+func scanOutgoingWebhook(row pgx.Row) (*webhook.OutgoingWebhook, error) {
+	var hook webhook.OutgoingWebhook
+	var events []string
+	err := row.Scan(
+		&hook.ID, &hook.RoomID, &hook.WorkspaceID, &hook.URL, &hook.Secret, &events,
+		&hook.IsActive, &hook.CreatedBy, &hook.CreatedAt, &hook.UpdatedAt,
+	)
+	for _, event := range events {
+		hook.Events = append(hook.Events, types.WebhookEventType(event))
+	}
+	return &hook, err
+}
+
+func scanDelivery(row pgx.Row) (*webhook.Delivery, error) {
+	var d webhook.Delivery
+	err := row.Scan(
+		&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.LastStatusCode, &d.LastError, &d.DeliveredAt, &d.CreatedAt, &d.UpdatedAt,
+	)
+	return &d, err
+}
+
+// eventNames converts subscribed events to the TEXT[] they are stored as.
+func eventNames(events []types.WebhookEventType) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, string(event))
+	}
+	return names
+}
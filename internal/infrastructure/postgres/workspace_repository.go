@@ -0,0 +1,359 @@
+// internal/infrastructure/postgres/workspace_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/purushothdl/gochat-backend/internal/domain/workspace"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
+
+type WorkspaceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWorkspaceRepository(pool *pgxpool.Pool) *WorkspaceRepository {
+	return &WorkspaceRepository{pool: pool}
+}
+
+const workspaceColumns = `w.id, w.name, w.description, w.is_default, w.created_at, w.updated_at`
+
+// ============================================================================
+// Workspace Operations
+// ============================================================================
+
+// CreateWorkspace stores a new workspace with creatorID as its first admin.
+func (r *WorkspaceRepository) CreateWorkspace(ctx context.Context, ws *workspace.Workspace, creatorID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        INSERT INTO workspaces (id, name, description)
+        VALUES ($1, $2, $3)
+        RETURNING created_at, updated_at
+    `
+	if err := tx.QueryRow(ctx, query, ws.ID, ws.Name, ws.Description).Scan(&ws.CreatedAt, &ws.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	memberQuery := `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(ctx, memberQuery, ws.ID, creatorID, workspace.RoleAdmin); err != nil {
+		return fmt.Errorf("failed to add workspace creator: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *WorkspaceRepository) FindWorkspace(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	query := `SELECT ` + workspaceColumns + ` FROM workspaces w WHERE w.id = $1`
+	var ws workspace.Workspace
+	err := r.pool.QueryRow(ctx, query, workspaceID).Scan(&ws.ID, &ws.Name, &ws.Description, &ws.IsDefault, &ws.CreatedAt, &ws.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, workspace.ErrWorkspaceNotFound
+		}
+		return nil, fmt.Errorf("failed to find workspace: %w", err)
+	}
+	return &ws, nil
+}
+
+func (r *WorkspaceRepository) UpdateWorkspace(ctx context.Context, ws *workspace.Workspace) error {
+	query := `
+        UPDATE workspaces SET name = $2, description = $3, updated_at = NOW()
+        WHERE id = $1
+        RETURNING updated_at
+    `
+	err := r.pool.QueryRow(ctx, query, ws.ID, ws.Name, ws.Description).Scan(&ws.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return workspace.ErrWorkspaceNotFound
+		}
+		return fmt.Errorf("failed to update workspace: %w", err)
+	}
+	return nil
+}
+
+// ListUserWorkspaces returns the user's workspaces with their role in each, oldest first.
+func (r *WorkspaceRepository) ListUserWorkspaces(ctx context.Context, userID string) ([]*workspace.UserWorkspace, error) {
+	query := `
+        SELECT ` + workspaceColumns + `, wm.role
+        FROM workspace_members wm
+        JOIN workspaces w ON w.id = wm.workspace_id
+        WHERE wm.user_id = $1
+        ORDER BY wm.created_at, w.id
+    `
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	var workspaces []*workspace.UserWorkspace
+	for rows.Next() {
+		var ws workspace.Workspace
+		uw := &workspace.UserWorkspace{Workspace: &ws}
+		if err := rows.Scan(&ws.ID, &ws.Name, &ws.Description, &ws.IsDefault, &ws.CreatedAt, &ws.UpdatedAt, &uw.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan workspace: %w", err)
+		}
+		workspaces = append(workspaces, uw)
+	}
+	return workspaces, rows.Err()
+}
+
+// DefaultWorkspaceID returns the workspace that takes in new accounts.
+func (r *WorkspaceRepository) DefaultWorkspaceID(ctx context.Context) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `SELECT id FROM workspaces WHERE is_default`).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", workspace.ErrNoDefaultWorkspace
+		}
+		return "", fmt.Errorf("failed to find default workspace: %w", err)
+	}
+	return id, nil
+}
+
+// ============================================================================
+// Member Operations
+// ============================================================================
+
+func (r *WorkspaceRepository) FindMember(ctx context.Context, workspaceID, userID string) (*workspace.Member, error) {
+	query := `
+        SELECT workspace_id, user_id, role, created_at
+        FROM workspace_members
+        WHERE workspace_id = $1 AND user_id = $2
+    `
+	var m workspace.Member
+	err := r.pool.QueryRow(ctx, query, workspaceID, userID).Scan(&m.WorkspaceID, &m.UserID, &m.Role, &m.JoinedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, workspace.ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to find workspace member: %w", err)
+	}
+	return &m, nil
+}
+
+// IsWorkspaceMember reports whether userID belongs to the workspace.
+func (r *WorkspaceRepository) IsWorkspaceMember(ctx context.Context, workspaceID, userID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM workspace_members WHERE workspace_id = $1 AND user_id = $2)`
+	var isMember bool
+	if err := r.pool.QueryRow(ctx, query, workspaceID, userID).Scan(&isMember); err != nil {
+		return false, fmt.Errorf("failed to check workspace membership: %w", err)
+	}
+	return isMember, nil
+}
+
+// ListMembers returns a page of the workspace's members, most recently joined first.
+func (r *WorkspaceRepository) ListMembers(ctx context.Context, workspaceID string, filter workspace.MemberFilter) ([]*workspace.Member, error) {
+	args := []any{workspaceID}
+	conditions := []string{"wm.workspace_id = $1", "u.deleted_at IS NULL"}
+	if filter.Query != "" {
+		args = append(args, "%"+likeEscaper.Replace(filter.Query)+"%")
+		conditions = append(conditions, fmt.Sprintf("(u.name ILIKE $%d OR u.email ILIKE $%d)", len(args), len(args)))
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(wm.created_at, wm.user_id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+        SELECT wm.workspace_id, wm.user_id, wm.role, wm.created_at, u.name, u.image_url, u.is_bot
+        FROM workspace_members wm
+        JOIN users u ON u.id = wm.user_id
+        WHERE %s
+        ORDER BY wm.created_at DESC, wm.user_id DESC
+        LIMIT $%d
+    `, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*workspace.Member
+	for rows.Next() {
+		var m workspace.Member
+		var name string
+		var imageURL *string
+		var isBot bool
+		if err := rows.Scan(&m.WorkspaceID, &m.UserID, &m.Role, &m.JoinedAt, &name, &imageURL, &isBot); err != nil {
+			return nil, fmt.Errorf("failed to scan workspace member: %w", err)
+		}
+		m.User = &types.BasicUser{ID: m.UserID, Name: name, IsBot: isBot}
+		if imageURL != nil {
+			m.User.ImageURL = *imageURL
+		}
+		members = append(members, &m)
+	}
+	return members, rows.Err()
+}
+
+// AddMember stores the membership and joins the user to the workspace's active
+// default rooms, skipping rooms they are banned from or already in.
+func (r *WorkspaceRepository) AddMember(ctx context.Context, member *workspace.Member) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        INSERT INTO workspace_members (workspace_id, user_id, role)
+        VALUES ($1, $2, $3)
+        RETURNING created_at
+    `
+	err = tx.QueryRow(ctx, query, member.WorkspaceID, member.UserID, member.Role).Scan(&member.JoinedAt)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23505": // unique_violation on the (workspace_id, user_id) primary key
+				return workspace.ErrAlreadyMember
+			case "23503": // foreign_key_violation: the workspace does not exist
+				return workspace.ErrWorkspaceNotFound
+			}
+		}
+		return fmt.Errorf("failed to add workspace member: %w", err)
+	}
+
+	roomsQuery := `
+        INSERT INTO room_memberships (room_id, user_id, role, created_at, updated_at)
+        SELECT r.id, $2, 'MEMBER', NOW(), NOW()
+        FROM rooms r
+        WHERE r.workspace_id = $1 AND r.is_default AND r.type = 'PUBLIC'
+          AND r.deleted_at IS NULL AND r.archived_at IS NULL
+          AND NOT EXISTS (
+              SELECT 1 FROM room_bans b
+              WHERE b.room_id = r.id AND b.user_id = $2 AND (b.expires_at IS NULL OR b.expires_at > NOW())
+          )
+        ON CONFLICT (room_id, user_id) DO NOTHING
+    `
+	if _, err := tx.Exec(ctx, roomsQuery, member.WorkspaceID, member.UserID); err != nil {
+		return fmt.Errorf("failed to join default rooms: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *WorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID, userID string, role workspace.Role) error {
+	query := `UPDATE workspace_members SET role = $3, updated_at = NOW() WHERE workspace_id = $1 AND user_id = $2`
+	cmdTag, err := r.pool.Exec(ctx, query, workspaceID, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to update workspace member role: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return workspace.ErrMemberNotFound
+	}
+	return nil
+}
+
+// RemoveMember takes the user out of the workspace and its active rooms. It
+// refuses while they own one of those rooms, since the room would be left
+// without an owner.
+func (r *WorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	ownsQuery := `
+        SELECT EXISTS (
+            SELECT 1 FROM room_memberships rm
+            JOIN rooms r ON r.id = rm.room_id
+            WHERE r.workspace_id = $1 AND rm.user_id = $2 AND rm.role = 'OWNER' AND r.deleted_at IS NULL
+        )
+    `
+	var ownsRooms bool
+	if err := tx.QueryRow(ctx, ownsQuery, workspaceID, userID).Scan(&ownsRooms); err != nil {
+		return fmt.Errorf("failed to check room ownership: %w", err)
+	}
+	if ownsRooms {
+		return workspace.ErrMemberOwnsRooms
+	}
+
+	roomsQuery := `
+        DELETE FROM room_memberships rm
+        USING rooms r
+        WHERE r.id = rm.room_id AND r.workspace_id = $1 AND rm.user_id = $2 AND r.deleted_at IS NULL
+    `
+	if _, err := tx.Exec(ctx, roomsQuery, workspaceID, userID); err != nil {
+		return fmt.Errorf("failed to leave workspace rooms: %w", err)
+	}
+
+	cmdTag, err := tx.Exec(ctx, `DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`, workspaceID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove workspace member: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return workspace.ErrMemberNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *WorkspaceRepository) CountAdmins(ctx context.Context, workspaceID string) (int, error) {
+	query := `SELECT COUNT(*) FROM workspace_members WHERE workspace_id = $1 AND role = 'ADMIN'`
+	var count int
+	if err := r.pool.QueryRow(ctx, query, workspaceID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count workspace admins: %w", err)
+	}
+	return count, nil
+}
+
+// ============================================================================
+// Default Room Operations
+// ============================================================================
+
+// ListDefaultRooms returns the workspace's active auto-join rooms, largest first.
+func (r *WorkspaceRepository) ListDefaultRooms(ctx context.Context, workspaceID string) ([]*workspace.DefaultRoom, error) {
+	query := `
+        SELECT id, name, member_count
+        FROM rooms
+        WHERE workspace_id = $1 AND is_default AND type = 'PUBLIC' AND deleted_at IS NULL AND archived_at IS NULL
+        ORDER BY member_count DESC, id
+    `
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list default rooms: %w", err)
+	}
+	defer rows.Close()
+
+	var rooms []*workspace.DefaultRoom
+	for rows.Next() {
+		var dr workspace.DefaultRoom
+		if err := rows.Scan(&dr.ID, &dr.Name, &dr.MemberCount); err != nil {
+			return nil, fmt.Errorf("failed to scan default room: %w", err)
+		}
+		rooms = append(rooms, &dr)
+	}
+	return rooms, rows.Err()
+}
+
+// SetDefaultRoom marks or unmarks a room for auto-join. Only active public
+// rooms can be marked, but any room of the workspace can be unmarked.
+func (r *WorkspaceRepository) SetDefaultRoom(ctx context.Context, workspaceID, roomID string, isDefault bool) error {
+	query := `
+        UPDATE rooms SET is_default = $3, updated_at = NOW()
+        WHERE id = $2 AND workspace_id = $1 AND deleted_at IS NULL
+          AND (NOT $3 OR (type = 'PUBLIC' AND archived_at IS NULL))
+    `
+	cmdTag, err := r.pool.Exec(ctx, query, workspaceID, roomID, isDefault)
+	if err != nil {
+		return fmt.Errorf("failed to set default room: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return workspace.ErrInvalidDefaultRoom
+	}
+	return nil
+}
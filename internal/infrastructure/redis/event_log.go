@@ -0,0 +1,123 @@
+package redis
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/redis/go-redis/v9"
+)
+
+const eventLogKeyPrefix = "stream:"
+
+// EventLog implements the EventLog contract with one Redis Stream per channel.
+// Streams are capped by length and expire once a channel has been idle for the
+// configured retention.
+type EventLog struct {
+	rdb    *redis.Client
+	config *config.EventLogConfig
+}
+
+func NewEventLog(redisCfg *config.RedisConfig, cfg *config.EventLogConfig) (*EventLog, error) {
+	opt, err := redis.ParseURL(redisCfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	rdb := redis.NewClient(opt)
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis for event log: %w", err)
+	}
+
+	return &EventLog{rdb: rdb, config: cfg}, nil
+}
+
+func (l *EventLog) Append(ctx context.Context, channel, payload string) (string, error) {
+	key := eventLogKeyPrefix + channel
+
+	pipe := l.rdb.TxPipeline()
+	add := pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: l.config.MaxLen,
+		Approx: true,
+		Values: map[string]any{"payload": payload},
+	})
+	pipe.Expire(ctx, key, l.config.Retention)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to append to event log: %w", err)
+	}
+	return add.Val(), nil
+}
+
+func (l *EventLog) ReadAfter(ctx context.Context, channel, position string, limit int) ([]*contracts.LoggedEvent, error) {
+	key := eventLogKeyPrefix + channel
+	if _, _, ok := parseStreamID(position); !ok {
+		return nil, contracts.ErrEventLogGap
+	}
+
+	info, err := l.rdb.XInfoStream(ctx, key).Result()
+	if err != nil {
+		// The whole stream expired, so nothing after position can be recovered.
+		if strings.Contains(err.Error(), "no such key") {
+			return nil, contracts.ErrEventLogGap
+		}
+		return nil, fmt.Errorf("failed to inspect event log: %w", err)
+	}
+	if trimmedAfter(position, info) {
+		return nil, contracts.ErrEventLogGap
+	}
+
+	entries, err := l.rdb.XRangeN(ctx, key, "("+position, "+", int64(limit)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event log: %w", err)
+	}
+
+	events := make([]*contracts.LoggedEvent, 0, len(entries))
+	for _, entry := range entries {
+		payload, _ := entry.Values["payload"].(string)
+		events = append(events, &contracts.LoggedEvent{Position: entry.ID, Payload: payload})
+	}
+	return events, nil
+}
+
+// trimmedAfter reports whether entries newer than position have been removed
+// from the stream. Redis 7 tracks the newest deleted ID exactly; older servers
+// fall back to comparing against the first entry still present.
+func trimmedAfter(position string, info *redis.XInfoStream) bool {
+	if info.MaxDeletedEntryID != "" {
+		return compareStreamIDs(position, info.MaxDeletedEntryID) < 0
+	}
+	if info.FirstEntry.ID != "" {
+		return compareStreamIDs(position, info.FirstEntry.ID) < 0
+	}
+	return false
+}
+
+func compareStreamIDs(a, b string) int {
+	aMs, aSeq, _ := parseStreamID(a)
+	bMs, bSeq, _ := parseStreamID(b)
+	if c := cmp.Compare(aMs, bMs); c != 0 {
+		return c
+	}
+	return cmp.Compare(aSeq, bSeq)
+}
+
+func parseStreamID(id string) (ms, seq uint64, ok bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
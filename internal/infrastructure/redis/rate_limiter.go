@@ -0,0 +1,106 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+const rateLimitKeyPrefix = "ratelimit:"
+
+// fixedWindowScript increments a counter and starts its window on the first hit.
+// It also repairs counters left without an expiry so a key can never block forever.
+var fixedWindowScript = redis.NewScript(`
+local current = redis.call("INCR", KEYS[1])
+if current == 1 or redis.call("PTTL", KEYS[1]) < 0 then
+    redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {current, redis.call("PTTL", KEYS[1])}
+`)
+
+// refundScript takes a hit back from a window that is still open. A window
+// that has already expired is left alone rather than recreated without a TTL.
+var refundScript = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+if current > 0 then
+    return redis.call("DECR", KEYS[1])
+end
+return 0
+`)
+
+// RateLimiter implements the RateLimiter contract using Redis keys with expiry.
+type RateLimiter struct {
+	rdb *redis.Client
+}
+
+func NewRateLimiter(cfg *config.RedisConfig) (*RateLimiter, error) {
+	opt, err := redis.ParseURL(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	rdb := redis.NewClient(opt)
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis for rate limiting: %w", err)
+	}
+
+	return &RateLimiter{rdb: rdb}, nil
+}
+
+func (l *RateLimiter) AcquireSlot(ctx context.Context, key string, window time.Duration) (bool, time.Duration, error) {
+	key = rateLimitKeyPrefix + key
+
+	ok, err := l.rdb.SetNX(ctx, key, 1, window).Result()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to acquire rate limit slot: %w", err)
+	}
+	if ok {
+		return true, 0, nil
+	}
+
+	ttl, err := l.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to read rate limit slot ttl: %w", err)
+	}
+	if ttl < 0 {
+		// The slot expired between the two calls.
+		ttl = 0
+	}
+	return false, ttl, nil
+}
+
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	key = rateLimitKeyPrefix + key
+
+	res, err := fixedWindowScript.Run(ctx, l.rdb, []string{key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to evaluate rate limit: %w", err)
+	}
+
+	count, ttl := res[0], time.Duration(res[1])*time.Millisecond
+	if count > int64(limit) {
+		return false, ttl, nil
+	}
+	return true, 0, nil
+}
+
+func (l *RateLimiter) ReleaseSlot(ctx context.Context, key string) error {
+	if err := l.rdb.Del(ctx, rateLimitKeyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to release rate limit slot: %w", err)
+	}
+	return nil
+}
+
+func (l *RateLimiter) Refund(ctx context.Context, key string) error {
+	if err := refundScript.Run(ctx, l.rdb, []string{rateLimitKeyPrefix + key}).Err(); err != nil {
+		return fmt.Errorf("failed to refund rate limit hit: %w", err)
+	}
+	return nil
+}
+
+func (l *RateLimiter) Close() error {
+	return l.rdb.Close()
+}
@@ -0,0 +1,108 @@
+// internal/shared/authz/authz.go
+package authz
+
+import (
+	"slices"
+
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+	"github.com/purushothdl/gochat-backend/pkg/errors"
+)
+
+// Permission is an action a room member may be allowed to take.
+type Permission string
+
+const (
+	SendMessages   Permission = "send_messages"
+	InviteMembers  Permission = "invite_members"
+	KickMembers    Permission = "kick_members" // Also covers banning
+	MuteMembers    Permission = "mute_members"
+	PinMessages    Permission = "pin_messages"
+	DeleteMessages Permission = "delete_messages" // Delete messages sent by other members
+	EditSettings   Permission = "edit_settings"
+	ManageRoles    Permission = "manage_roles"
+	ViewAuditLog   Permission = "view_audit_log"
+	ManageWebhooks Permission = "manage_webhooks"
+)
+
+var (
+	ErrPermissionDenied  = errors.New("PERMISSION_DENIED", "You do not have permission to perform this action in this room", 403)
+	ErrInsufficientRank  = errors.New("INSUFFICIENT_RANK", "You can only manage members whose role is below your own", 403)
+	ErrRoleNotAssignable = errors.New("ROLE_NOT_ASSIGNABLE", "You cannot assign this role", 403)
+)
+
+// rolePermissions are each role's default permissions. Only MEMBER defaults can
+// be changed per room.
+var rolePermissions = map[types.MemberRole][]Permission{
+	types.OwnerRole:     {SendMessages, InviteMembers, KickMembers, MuteMembers, PinMessages, DeleteMessages, EditSettings, ManageRoles, ViewAuditLog, ManageWebhooks},
+	types.AdminRole:     {SendMessages, InviteMembers, KickMembers, MuteMembers, PinMessages, DeleteMessages, EditSettings, ManageRoles, ViewAuditLog, ManageWebhooks},
+	types.ModeratorRole: {SendMessages, InviteMembers, KickMembers, MuteMembers, PinMessages, DeleteMessages},
+	types.RegularRole:   {SendMessages},
+}
+
+// roleRank orders roles from least to most privileged.
+var roleRank = map[types.MemberRole]int{
+	types.RegularRole:   1,
+	types.ModeratorRole: 2,
+	types.AdminRole:     3,
+	types.OwnerRole:     4,
+}
+
+// Overridable lists the permissions a room may grant to or withhold from its MEMBER role.
+var Overridable = []Permission{SendMessages, InviteMembers, PinMessages}
+
+// Can reports whether a member with role may use perm in a room with the given
+// MEMBER overrides.
+func Can(role types.MemberRole, overrides types.PermissionOverrides, perm Permission) bool {
+	if role == types.RegularRole && slices.Contains(Overridable, perm) {
+		if allowed, ok := overrides[string(perm)]; ok {
+			return allowed
+		}
+	}
+	return slices.Contains(rolePermissions[role], perm)
+}
+
+// Require is Can that returns ErrPermissionDenied, naming the missing permission, when the check fails.
+func Require(role types.MemberRole, overrides types.PermissionOverrides, perm Permission) error {
+	if !Can(role, overrides, perm) {
+		return ErrPermissionDenied.WithDetails(map[string]any{"permission": perm})
+	}
+	return nil
+}
+
+// Permissions returns every permission role holds in a room with the given MEMBER overrides.
+func Permissions(role types.MemberRole, overrides types.PermissionOverrides) []Permission {
+	all := rolePermissions[types.OwnerRole]
+	granted := make([]Permission, 0, len(all))
+	for _, perm := range all {
+		if Can(role, overrides, perm) {
+			granted = append(granted, perm)
+		}
+	}
+	return granted
+}
+
+// AtLeast reports whether role is min or a more privileged role.
+func AtLeast(role, min types.MemberRole) bool {
+	return roleRank[role] >= roleRank[min]
+}
+
+// RequireOutrank checks that actor ranks strictly above target, as needed to kick or re-role them.
+func RequireOutrank(actor, target types.MemberRole) error {
+	if roleRank[actor] <= roleRank[target] {
+		return ErrInsufficientRank
+	}
+	return nil
+}
+
+// RequireAssignable checks that actor may hand out role, either by changing a
+// member's role or through an invite link. Nobody can grant a role above their
+// own, and ownership only changes hands through a transfer.
+func RequireAssignable(actor, role types.MemberRole) error {
+	if err := Require(actor, nil, ManageRoles); err != nil {
+		return err
+	}
+	if role == types.OwnerRole || roleRank[role] == 0 || roleRank[role] > roleRank[actor] {
+		return ErrRoleNotAssignable
+	}
+	return nil
+}
@@ -1 +1,99 @@
-package response
\ No newline at end of file
+package response
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CursorPage is the envelope for keyset-paginated lists. NextCursor is passed
+// back as the "cursor" query parameter to fetch the following page.
+type CursorPage[T any] struct {
+	Data       []T    `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// Cursor marks a position in a list ordered by (created_at, id) descending.
+// Lists ordered by another timestamp store it in CreatedAt; lists ordered by a
+// count set Count instead.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+	Count     *int64
+}
+
+// Encode returns the opaque string form of the cursor.
+func (c Cursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	if c.Count != nil {
+		raw += "|" + strconv.FormatInt(*c.Count, 10)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode, rejecting cursors
+// whose ID is not a UUID. An empty string yields nil.
+func DecodeCursor(encoded string) (*Cursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	// Every paginated list is keyed by UUIDs; anything else would only fail in the query.
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	cursor := &Cursor{CreatedAt: createdAt, ID: id.String()}
+	if len(parts) == 3 {
+		count, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		cursor.Count = &count
+	}
+	return cursor, nil
+}
+
+// ParseLimit reads the "limit" query parameter, falling back to def and capping at max.
+func ParseLimit(r *http.Request, def, max int) int {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		return def
+	}
+	if limit > max {
+		return max
+	}
+	return limit
+}
+
+// NewCursorPage builds a page from items fetched with limit+1 rows, trimming
+// the extra row and deriving the next cursor from the last item kept.
+func NewCursorPage[T any](items []T, limit int, cursorOf func(T) Cursor) *CursorPage[T] {
+	page := &CursorPage[T]{Data: items}
+	if len(items) > limit {
+		page.Data = items[:limit]
+		page.HasMore = true
+		page.NextCursor = cursorOf(page.Data[limit-1]).Encode()
+	}
+	if page.Data == nil {
+		page.Data = []T{}
+	}
+	return page
+}
@@ -15,8 +15,9 @@ type APIResponse struct {
 }
 
 type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Details map[string]any `json:"details,omitempty"`
 }
 
 func JSON(w http.ResponseWriter, status int, data interface{}) {
@@ -44,6 +45,7 @@ func Error(w http.ResponseWriter, status int, err error) {
 		apiErr = &APIError{
             Code:    appErr.Code, 
             Message: appErr.Message,
+            Details: appErr.Details,
         }
 		finalStatus = appErr.Status
 	} else {
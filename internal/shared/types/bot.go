@@ -0,0 +1,41 @@
+package types
+
+import "slices"
+
+// BotTokenPrefix starts every bot token, so that the auth middleware can tell
+// them apart from user access tokens.
+const BotTokenPrefix = "gcb_"
+
+// BotScope names what a bot token may be used for.
+type BotScope string
+
+const (
+	ScopeRoomsRead     BotScope = "rooms:read"     // List the bot's rooms and their members
+	ScopeRoomsJoin     BotScope = "rooms:join"     // Join and leave rooms, and accept invitations
+	ScopeMessagesRead  BotScope = "messages:read"  // Read message history
+	ScopeMessagesWrite BotScope = "messages:write" // Send, edit and delete the bot's messages
+	ScopeEventsRead    BotScope = "events:read"    // Receive events over the WebSocket
+	ScopeCommandsWrite BotScope = "commands:write" // Register and manage the bot's slash commands
+)
+
+// BotScopes lists every scope a bot token can be given.
+var BotScopes = []BotScope{
+	ScopeRoomsRead,
+	ScopeRoomsJoin,
+	ScopeMessagesRead,
+	ScopeMessagesWrite,
+	ScopeEventsRead,
+	ScopeCommandsWrite,
+}
+
+// BotIdentity is the bot a request was authenticated as, and what its token allows.
+type BotIdentity struct {
+	BotID   string
+	OwnerID string
+	TokenID string
+	Scopes  []BotScope
+}
+
+func (b *BotIdentity) HasScope(scope BotScope) bool {
+	return slices.Contains(b.Scopes, scope)
+}
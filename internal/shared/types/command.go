@@ -0,0 +1,45 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	commandPattern     = regexp.MustCompile(`(?is)^/([a-z][a-z0-9_-]{0,31})(?:\s+(.*))?$`)
+	commandNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+)
+
+// ParseCommand splits a message starting with a slash command into the
+// command's lower-cased name and the text after it. ok is false when the
+// message is not a command, such as a path like "/usr/bin".
+func ParseCommand(text string) (name, args string, ok bool) {
+	match := commandPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return "", "", false
+	}
+	return strings.ToLower(match[1]), strings.TrimSpace(match[2]), true
+}
+
+// ValidCommandName reports whether name can be registered as a command.
+func ValidCommandName(name string) bool {
+	return commandNamePattern.MatchString(name)
+}
+
+// CommandInvocation is a message that SendMessage routes to the command
+// registry instead of posting.
+type CommandInvocation struct {
+	RoomID          string
+	UserID          string
+	Text            string // The whole message, starting with the slash
+	ClientMessageID string // The sender's id for the message, passed on to any message the command posts for them
+}
+
+// CommandResult tells the invoker what their command did.
+type CommandResult struct {
+	InvocationID string  `json:"invocation_id"`
+	Command      string  `json:"command"`
+	Text         string  `json:"text,omitempty"`       // Ephemeral reply, also sent on the invoker's user channel
+	MessageID    *string `json:"message_id,omitempty"` // Set when the command posted into the room
+	Deferred     bool    `json:"deferred"`             // Handed to a bot or webhook, which replies on its own
+}
@@ -6,4 +6,13 @@ import "time"
 type ReceiptInfo struct {
 	User      *BasicUser `json:"user"`
 	Timestamp time.Time  `json:"timestamp"`
-}
\ No newline at end of file
+}
+
+// MessageInfo contains the minimal message data needed by other domains.
+type MessageInfo struct {
+	ID        string
+	RoomID    string
+	UserID    *string
+	Content   string
+	DeletedAt *time.Time
+}
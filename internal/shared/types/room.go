@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // RoomType is a shared definition for different room types.
 type RoomType string
 
@@ -13,28 +15,101 @@ const (
 type MemberRole string
 
 const (
-	AdminRole   MemberRole = "ADMIN"
-	RegularRole MemberRole = "MEMBER"
+	OwnerRole     MemberRole = "OWNER"
+	AdminRole     MemberRole = "ADMIN"
+	ModeratorRole MemberRole = "MODERATOR"
+	RegularRole   MemberRole = "MEMBER"
 )
 
 // RoomInfo contains the minimal room data needed by other domains.
 type RoomInfo struct {
-	ID              string
-	Type            RoomType
-	IsBroadcastOnly bool
+	ID                 string
+	Name               string
+	Type               RoomType
+	IsBroadcastOnly    bool
+	SlowModeSeconds    int
+	ModerationSettings ModerationSettings
+	MemberPermissions  PermissionOverrides
+	IsArchived         bool // Archived rooms are read-only
 }
 
+// ModerationSettings holds a room's per-hook moderation toggles, keyed by hook name.
+type ModerationSettings map[string]bool
+
+// Enabled reports whether a hook should run in the room. Hooks are on unless explicitly switched off.
+func (m ModerationSettings) Enabled(hook string) bool {
+	enabled, ok := m[hook]
+	return !ok || enabled
+}
+
+// PermissionOverrides holds a room's changes to the MEMBER role's default
+// permissions, keyed by permission name. Permissions absent from the map keep
+// their default.
+type PermissionOverrides map[string]bool
+
 // MembershipInfo contains the minimal membership data needed by other domains.
 type MembershipInfo struct {
-	RoomID string
-	UserID string
-	Role   MemberRole
+	RoomID            string
+	UserID            string
+	Role              MemberRole
+	MemberPermissions PermissionOverrides // The room's MEMBER overrides, needed to authorize members
+	MutedUntil        *time.Time          // Set while the member is muted
+}
+
+// NotificationLevel controls which messages in a room notify a member.
+type NotificationLevel string
+
+const (
+	NotifyAll      NotificationLevel = "ALL"
+	NotifyMentions NotificationLevel = "MENTIONS"
+	NotifyNone     NotificationLevel = "NONE"
+)
+
+// NotificationPrefs are a member's notification settings for one room.
+type NotificationPrefs struct {
+	Level      NotificationLevel
+	MutedUntil *time.Time // Nothing in the room notifies the member before this time
+}
+
+// Allows reports whether a message should notify the member at now, given
+// whether it mentions them. Every notification path should go through it.
+func (p NotificationPrefs) Allows(now time.Time, mentioned bool) bool {
+	if p.MutedUntil != nil && now.Before(*p.MutedUntil) {
+		return false
+	}
+	switch p.Level {
+	case NotifyNone:
+		return false
+	case NotifyMentions:
+		return mentioned
+	default:
+		return true
+	}
+}
+
+// NotificationTarget is a room member who may be notified, with the settings that decide it.
+type NotificationTarget struct {
+	UserID               string
+	Prefs                NotificationPrefs
+	NotificationsEnabled bool // The user's global switch, which overrides every room
+	Hidden               bool // The member has hidden the room from their room list
+}
+
+// RoomPrefs is how a room appears in one member's own room list.
+type RoomPrefs struct {
+	IsPinned   bool
+	IsFavorite bool
+	HiddenAt   *time.Time // Set while the member has hidden the room
+	FolderID   *string
+	Position   *int // Manual order within the member's list; unset rooms sort after ordered ones
 }
 
 type MemberDetail struct {
-	RoomID   string
-	UserID   string
-	Role     MemberRole
-	Name     string 
-	ImageURL string 
+	RoomID     string
+	UserID     string
+	Role       MemberRole
+	Name       string
+	ImageURL   string
+	IsBot      bool
+	MutedUntil *time.Time
 }
@@ -4,20 +4,30 @@ import "time"
 
 // Shared user entity for cross-domain operations
 type User struct {
-    ID         string     `json:"id"`
-    Email      string     `json:"email"`
-    Name       string     `json:"name"`
-    ImageURL   string     `json:"image_url"`
-    CreatedAt  time.Time  `json:"created_at"`
-    UpdatedAt  time.Time  `json:"updated_at"`
-    IsVerified bool       `json:"is_verified"`
-    LastLogin  *time.Time `json:"last_login,omitempty"`
+    ID             string     `json:"id"`
+    Email          string     `json:"email"`
+    Name           string     `json:"name"`
+    ImageURL       string     `json:"image_url"`
+    CreatedAt      time.Time  `json:"created_at"`
+    UpdatedAt      time.Time  `json:"updated_at"`
+    IsVerified     bool       `json:"is_verified"`
+    LastLogin      *time.Time `json:"last_login,omitempty"`
+    IsAdmin        bool       `json:"is_admin"`
+    SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+    IsBot          bool       `json:"is_bot"`
+    BotOwnerID     *string    `json:"bot_owner_id,omitempty"` // Set for bots only
+}
+
+// IsSuspended reports whether the account is barred from signing in at the given time.
+func (u *User) IsSuspended(now time.Time) bool {
+    return u.SuspendedUntil != nil && now.Before(*u.SuspendedUntil)
 }
 
 type BasicUser struct {
     ID       string `json:"id"`
     Name     string `json:"name"`
     ImageURL string `json:"image_url"`
+    IsBot    bool   `json:"is_bot"`
 }
 
 type CreateUserData struct {
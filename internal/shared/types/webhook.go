@@ -0,0 +1,33 @@
+package types
+
+// WebhookEventType names an event that outgoing webhooks can subscribe to.
+type WebhookEventType string
+
+const (
+	WebhookMessageCreated WebhookEventType = "message.created"
+	WebhookMessageDeleted WebhookEventType = "message.deleted"
+	WebhookMemberJoined   WebhookEventType = "member.joined"
+	WebhookMemberLeft     WebhookEventType = "member.left"
+	WebhookMemberRemoved  WebhookEventType = "member.removed"
+)
+
+// WebhookCommandInvoked is delivered only to the webhook whose slash command
+// was run. Webhooks cannot subscribe to it.
+const WebhookCommandInvoked WebhookEventType = "command.invoked"
+
+// WebhookEvents lists every event outgoing webhooks can subscribe to.
+var WebhookEvents = []WebhookEventType{
+	WebhookMessageCreated,
+	WebhookMessageDeleted,
+	WebhookMemberJoined,
+	WebhookMemberLeft,
+	WebhookMemberRemoved,
+}
+
+// WebhookEvent is something that happened in a room, to be delivered to the
+// outgoing webhooks of the room and of its workspace that subscribe to it.
+type WebhookEvent struct {
+	Type   WebhookEventType
+	RoomID string
+	Data   map[string]any
+}
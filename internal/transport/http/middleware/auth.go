@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/purushothdl/gochat-backend/internal/config"
 	"github.com/purushothdl/gochat-backend/internal/shared/response"
@@ -21,6 +22,7 @@ var (
 	userEmailKey = &authContextKey{"userEmail"}
 	userKey      = &authContextKey{"user"}
 	deviceIDKey  = &authContextKey{"deviceID"}
+	botKey       = &authContextKey{"bot"}
 )
 
 // Required by auth middleware to set the current user
@@ -28,15 +30,29 @@ type UserRepository interface {
 	GetByIDShared(ctx context.Context, userID string) (*types.User, error)
 }
 
+// BotAuthenticator resolves bot tokens to the bot and scopes they carry.
+type BotAuthenticator interface {
+	AuthenticateBot(ctx context.Context, token string) (*types.BotIdentity, error)
+}
+
+// botPrincipal is a bot authenticated by RequireAuth, waiting for
+// RequireScope to let it through.
+type botPrincipal struct {
+	identity *types.BotIdentity
+	user     *types.BasicUser
+}
+
 type AuthMiddleware struct {
 	config   *config.Config
 	userRepo UserRepository
+	bots     BotAuthenticator
 }
 
-func NewAuthMiddleware(cfg *config.Config, userRepo UserRepository) *AuthMiddleware {
+func NewAuthMiddleware(cfg *config.Config, userRepo UserRepository, bots BotAuthenticator) *AuthMiddleware {
 	return &AuthMiddleware{
 		config:   cfg,
 		userRepo: userRepo,
+		bots:     bots,
 	}
 }
 
@@ -54,6 +70,11 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		if strings.HasPrefix(tokenParts[1], types.BotTokenPrefix) {
+			m.authenticateBot(w, r, tokenParts[1], next)
+			return
+		}
+
 		claims, err := auth.ValidateAccessToken(tokenParts[1], m.config.JWT.Secret)
 		if err != nil {
 			response.Error(w, http.StatusUnauthorized, errors.ErrUnauthorized)
@@ -72,11 +93,18 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		// Suspended accounts are locked out even while their access tokens are still valid.
+		if userEntity.IsSuspended(time.Now()) {
+			response.Error(w, http.StatusForbidden, errors.ErrAccountSuspended)
+			return
+		}
+
 		// Convert the full User entity to BasicUser before storing in context
 		basicUser := &types.BasicUser{
 			ID:       userEntity.ID,
 			Name:     userEntity.Name,
 			ImageURL: userEntity.ImageURL,
+			IsBot:    userEntity.IsBot,
 		}
 		ctx = context.WithValue(ctx, userKey, basicUser)
 
@@ -84,6 +112,64 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	})
 }
 
+// authenticateBot checks a bot token. The bot is not yet set as the current
+// user: only routes wrapped in RequireScope do that, so bots are refused by
+// every route that has not been opened to them.
+func (m *AuthMiddleware) authenticateBot(w http.ResponseWriter, r *http.Request, token string, next http.Handler) {
+	identity, err := m.bots.AuthenticateBot(r.Context(), token)
+	if err != nil {
+		if _, ok := err.(*errors.AppError); ok {
+			response.Error(w, 0, err)
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, errors.ErrInternalServer)
+		return
+	}
+
+	botUser, err := m.userRepo.GetByIDShared(r.Context(), identity.BotID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, errors.ErrInternalServer)
+		return
+	}
+	if botUser.IsSuspended(time.Now()) {
+		response.Error(w, http.StatusForbidden, errors.ErrAccountSuspended)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), botKey, &botPrincipal{
+		identity: identity,
+		user: &types.BasicUser{
+			ID:       botUser.ID,
+			Name:     botUser.Name,
+			ImageURL: botUser.ImageURL,
+			IsBot:    true,
+		},
+	})
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RequireScope opens a route to bot tokens that carry the scope, making the
+// bot the current user. Requests from people pass through unchanged.
+func (m *AuthMiddleware) RequireScope(scope types.BotScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := r.Context().Value(botKey).(*botPrincipal)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !principal.identity.HasScope(scope) {
+				response.Error(w, http.StatusForbidden, errors.ErrInsufficientScope)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDKey, principal.identity.BotID)
+			ctx = context.WithValue(ctx, userKey, principal.user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
@@ -102,6 +188,7 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 							ID:       userEntity.ID,
 							Name:     userEntity.Name,
 							ImageURL: userEntity.ImageURL,
+							IsBot:    userEntity.IsBot,
 						}
 						ctx = context.WithValue(ctx, userKey, basicUser)
 					}
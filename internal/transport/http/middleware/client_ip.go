@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/purushothdl/gochat-backend/pkg/utils/httputil"
+)
+
+// ClientIP stores the request's client IP in its context so that code below the
+// handlers, such as the audit log, can record it.
+func ClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := httputil.WithClientIP(r.Context(), httputil.GetClientIP(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
@@ -32,6 +33,16 @@ func GetLogger(ctx context.Context) *slog.Logger {
 	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 }
 
+// loggedPath is the path to log for a request: its route pattern once routed,
+// so that tokens carried in the URL, such as webhook and invite link tokens,
+// never reach the logs.
+func loggedPath(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		return rctx.RoutePattern()
+	}
+	return r.URL.Path
+}
+
 // RequestLogger logs the details of each processed HTTP request.
 func RequestLogger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -43,7 +54,7 @@ func RequestLogger(next http.Handler) http.Handler {
 			logger.Info("http request",
 				"status", ww.Status(),
 				"method", r.Method,
-				"path", r.URL.Path,
+				"path", loggedPath(r),
 				"duration_ms", time.Since(start).Milliseconds(),
 				"bytes_written", ww.BytesWritten(),
 				"remote_addr", r.RemoteAddr,
@@ -19,7 +19,7 @@ func Recoverer(logger *slog.Logger) func(http.Handler) http.Handler {
 					logger.Error("panic recovered",
 						"error", err,
 						"request_method", r.Method,
-						"request_path", r.URL.Path,
+						"request_path", loggedPath(r),
 						"stack", string(debug.Stack()),
 					)
 
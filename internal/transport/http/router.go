@@ -8,21 +8,38 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/domain/audit"
 	"github.com/purushothdl/gochat-backend/internal/domain/auth"
+	"github.com/purushothdl/gochat-backend/internal/domain/bot"
+	"github.com/purushothdl/gochat-backend/internal/domain/command"
+	"github.com/purushothdl/gochat-backend/internal/domain/export"
 	"github.com/purushothdl/gochat-backend/internal/domain/health"
 	"github.com/purushothdl/gochat-backend/internal/domain/message"
+	"github.com/purushothdl/gochat-backend/internal/domain/report"
+	"github.com/purushothdl/gochat-backend/internal/domain/retention"
 	"github.com/purushothdl/gochat-backend/internal/domain/room"
 	"github.com/purushothdl/gochat-backend/internal/domain/user"
+	"github.com/purushothdl/gochat-backend/internal/domain/webhook"
+	"github.com/purushothdl/gochat-backend/internal/domain/workspace"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
 	app_middleware "github.com/purushothdl/gochat-backend/internal/transport/http/middleware"
 )
 
 type Router struct {
-	authHandler    *auth.Handler
-	userHandler    *user.Handler
-	healthHandler  *health.Handler
-	roomHandler    *room.Handler
-	messageHandler *message.Handler
-	authMw         *app_middleware.AuthMiddleware
+	authHandler      *auth.Handler
+	userHandler      *user.Handler
+	healthHandler    *health.Handler
+	roomHandler      *room.Handler
+	messageHandler   *message.Handler
+	exportHandler    *export.Handler
+	retentionHandler *retention.Handler
+	reportHandler    *report.Handler
+	auditHandler     *audit.Handler
+	workspaceHandler *workspace.Handler
+	webhookHandler   *webhook.Handler
+	botHandler       *bot.Handler
+	commandHandler   *command.Handler
+	authMw           *app_middleware.AuthMiddleware
 }
 
 func NewRouter(
@@ -31,23 +48,40 @@ func NewRouter(
 	healthHandler *health.Handler,
 	roomHandler *room.Handler,
 	messageHandler *message.Handler,
+	exportHandler *export.Handler,
+	retentionHandler *retention.Handler,
+	reportHandler *report.Handler,
+	auditHandler *audit.Handler,
+	workspaceHandler *workspace.Handler,
+	webhookHandler *webhook.Handler,
+	botHandler *bot.Handler,
+	commandHandler *command.Handler,
 	authMw *app_middleware.AuthMiddleware,
 ) *Router {
 	return &Router{
-		authHandler:    authHandler,
-		userHandler:    userHandler,
-		healthHandler:  healthHandler,
-		roomHandler:    roomHandler,
-		messageHandler: messageHandler,
-		authMw:         authMw,
+		authHandler:      authHandler,
+		userHandler:      userHandler,
+		healthHandler:    healthHandler,
+		roomHandler:      roomHandler,
+		messageHandler:   messageHandler,
+		exportHandler:    exportHandler,
+		retentionHandler: retentionHandler,
+		reportHandler:    reportHandler,
+		auditHandler:     auditHandler,
+		workspaceHandler: workspaceHandler,
+		webhookHandler:   webhookHandler,
+		botHandler:       botHandler,
+		commandHandler:   commandHandler,
+		authMw:           authMw,
 	}
 }
 
-// The order is important: recovery -> cors -> logger -> request logger -> timeout.
+// The order is important: recovery -> cors -> logger -> client IP -> request logger -> timeout.
 func (rt *Router) mountMiddlewares(r *chi.Mux, cfg *config.Config, logger *slog.Logger) {
 	r.Use(app_middleware.Recoverer(logger))
 	r.Use(app_middleware.CORS(&cfg.CORS))
 	r.Use(app_middleware.WithLogger(logger))
+	r.Use(app_middleware.ClientIP)
 	r.Use(app_middleware.RequestLogger)
 	r.Use(middleware.Timeout(60 * time.Second))
 }
@@ -62,6 +96,9 @@ func (rt *Router) SetupRoutes(cfg *config.Config, logger *slog.Logger) *chi.Mux
 	r.Get("/ready", rt.healthHandler.Ready)
 	r.Get("/live", rt.healthHandler.Live)
 
+	// Bot tokens only reach the routes opened to one of their scopes
+	scope := rt.authMw.RequireScope
+
 	// API routes
 	r.Route("/api", func(r chi.Router) {
 		r.Route("/auth", func(r chi.Router) {
@@ -91,46 +128,223 @@ func (rt *Router) SetupRoutes(cfg *config.Config, logger *slog.Logger) *chi.Mux
 			r.Delete("/block/{user_id}", rt.userHandler.UnblockUser) // Unblock a user
 			r.Get("/blocked", rt.userHandler.ListBlockedUsers)       // List blocked users
 		})
+		r.Route("/workspaces", func(r chi.Router) {
+			r.Use(rt.authMw.RequireAuth)
+
+			r.Post("/", rt.workspaceHandler.CreateWorkspace)                // Create a workspace as its first admin
+			r.Get("/", rt.workspaceHandler.ListMyWorkspaces)                // List the authenticated user's workspaces
+			r.Get("/{workspace_id}", rt.workspaceHandler.GetWorkspace)      // Get a workspace the user belongs to
+			r.Patch("/{workspace_id}", rt.workspaceHandler.UpdateWorkspace) // Rename or describe a workspace (admins)
+
+			// Workspace members
+			r.Get("/{workspace_id}/members", rt.workspaceHandler.ListMembers)                     // List and search the workspace's members
+			r.Post("/{workspace_id}/members", rt.workspaceHandler.AddMember)                      // Add a user to the workspace (admins)
+			r.Put("/{workspace_id}/members/{user_id}/role", rt.workspaceHandler.UpdateMemberRole) // Promote or demote a member (admins)
+			r.Delete("/{workspace_id}/members/{user_id}", rt.workspaceHandler.RemoveMember)       // Remove a member, or leave the workspace
+
+			// Rooms new members join automatically
+			r.Get("/{workspace_id}/default-rooms", rt.workspaceHandler.ListDefaultRooms)               // List the workspace's default rooms
+			r.Put("/{workspace_id}/default-rooms/{room_id}", rt.workspaceHandler.AddDefaultRoom)       // Make a public room a default room (admins)
+			r.Delete("/{workspace_id}/default-rooms/{room_id}", rt.workspaceHandler.RemoveDefaultRoom) // Stop auto-joining new members to a room (admins)
+
+			// Outgoing webhooks for every room in the workspace
+			r.Post("/{workspace_id}/outgoing-webhooks", rt.webhookHandler.CreateWorkspaceOutgoingWebhook) // Register an endpoint; its secret is only returned here (admins)
+			r.Get("/{workspace_id}/outgoing-webhooks", rt.webhookHandler.ListWorkspaceOutgoingWebhooks)   // List the workspace's outgoing webhooks (admins)
+		})
+
 		r.Route("/rooms", func(r chi.Router) {
 			r.Use(rt.authMw.RequireAuth)
 
 			// Room management
-			r.Post("/", rt.roomHandler.CreateRoom)           // Create a new room
-			r.Get("/", rt.roomHandler.ListUserRooms)         // List rooms for the authenticated user
-			r.Get("/public", rt.roomHandler.ListPublicRooms) // List all public rooms
+			r.Post("/", rt.roomHandler.CreateRoom)                                     // Create a new room
+			r.With(scope(types.ScopeRoomsRead)).Get("/", rt.roomHandler.ListUserRooms) // List rooms for the authenticated user, optionally filtered by their prefs
+			r.Get("/public", rt.roomHandler.ListPublicRooms)                           // Search and page the public room directory
+
+			// Personal room list organization
+			r.Get("/folders", rt.roomHandler.ListFolders)                 // List the authenticated user's room folders
+			r.Post("/folders", rt.roomHandler.CreateFolder)               // Create a room folder
+			r.Put("/folders/{folder_id}", rt.roomHandler.UpdateFolder)    // Rename or reorder a room folder
+			r.Delete("/folders/{folder_id}", rt.roomHandler.DeleteFolder) // Delete a folder, keeping its rooms
+			r.Put("/{room_id}/prefs", rt.roomHandler.UpdateRoomPrefs)     // Pin, favorite, hide, file or place a room in the user's list
 
 			// Room membership
-			r.Post("/{room_id}/invite", rt.roomHandler.InviteUser)   // Invite user to a room
-			r.Post("/{room_id}/join", rt.roomHandler.JoinPublicRoom) // Join a public room
+			r.Post("/{room_id}/invite", rt.roomHandler.InviteUser)                                     // Invite user to a room
+			r.With(scope(types.ScopeRoomsJoin)).Post("/{room_id}/join", rt.roomHandler.JoinPublicRoom) // Join a public room
+
+			// Room invitations
+			r.Get("/{room_id}/invitations", rt.roomHandler.ListRoomInvitations)                      // List a room's pending invitations
+			r.Post("/{room_id}/invitations/{invitation_id}/resend", rt.roomHandler.ResendInvitation) // Renew an invitation and notify the invitee again
+			r.Delete("/{room_id}/invitations/{invitation_id}", rt.roomHandler.RevokeInvitation)      // Revoke a pending invitation
+
+			// Join requests for discoverable private rooms
+			r.Post("/{room_id}/join-requests", rt.roomHandler.RequestToJoin)                           // Ask to join a discoverable private room
+			r.Get("/{room_id}/join-requests", rt.roomHandler.ListJoinRequests)                         // List a room's pending join requests
+			r.Post("/{room_id}/join-requests/{request_id}/approve", rt.roomHandler.ApproveJoinRequest) // Approve a request, adding the user as a member
+			r.Post("/{room_id}/join-requests/{request_id}/deny", rt.roomHandler.DenyJoinRequest)       // Deny a request
+
+			// Shareable invite links
+			r.Post("/{room_id}/invite-links", rt.roomHandler.CreateInviteLink)             // Create an invite link for a private room
+			r.Get("/{room_id}/invite-links", rt.roomHandler.ListInviteLinks)               // List a room's invite links
+			r.Delete("/{room_id}/invite-links/{link_id}", rt.roomHandler.RevokeInviteLink) // Revoke an invite link
 
 			// Member management
-			r.Get("/{room_id}/members", rt.roomHandler.ListMembers)                // List members of a specific room
-			r.Put("/{room_id}/members/{user_id}", rt.roomHandler.UpdateMemberRole) // Update a member's role
-			r.Delete("/{room_id}/members/{user_id}", rt.roomHandler.RemoveMember)  // Remove a member from a room
-			r.Delete("/{room_id}/members/me", rt.roomHandler.LeaveRoom)            // Authenticated user leaves a room
+			r.With(scope(types.ScopeRoomsRead)).Get("/{room_id}/members", rt.roomHandler.ListMembers)     // List members of a specific room
+			r.Put("/{room_id}/members/{user_id}", rt.roomHandler.UpdateMemberRole)                        // Update a member's role
+			r.Delete("/{room_id}/members/{user_id}", rt.roomHandler.RemoveMember)                         // Remove a member from a room
+			r.With(scope(types.ScopeRoomsJoin)).Delete("/{room_id}/members/me", rt.roomHandler.LeaveRoom) // Authenticated user leaves a room
+			r.Put("/{room_id}/members/{user_id}/mute", rt.roomHandler.MuteMember)                         // Mute a member for a while
+			r.Delete("/{room_id}/members/{user_id}/mute", rt.roomHandler.UnmuteMember)                    // Lift a member's mute early
+
+			// Notification preferences
+			r.Put("/{room_id}/notifications", rt.roomHandler.UpdateNotifications) // Set the authenticated user's notification settings for a room
+
+			// Bans
+			r.Post("/{room_id}/bans", rt.roomHandler.BanMember)               // Ban a user, removing them if they are a member
+			r.Get("/{room_id}/bans", rt.roomHandler.ListBans)                 // List the room's active bans
+			r.Delete("/{room_id}/bans/{user_id}", rt.roomHandler.UnbanMember) // Lift a ban
 
 			// Room settings
-			r.Put("/{room_id}/settings", rt.roomHandler.UpdateRoomSettings) // Update room settings
+			r.Put("/{room_id}/settings", rt.roomHandler.UpdateRoomSettings) // Update room settings and details
+			r.Put("/{room_id}/avatar", rt.roomHandler.UploadAvatar)         // Upload a room avatar for asynchronous processing
+
+			// Archiving and deletion
+			r.Post("/{room_id}/archive", rt.roomHandler.ArchiveRoom)     // Make a room read-only
+			r.Delete("/{room_id}/archive", rt.roomHandler.UnarchiveRoom) // Make an archived room writable again
+			r.Delete("/{room_id}", rt.roomHandler.DeleteRoom)            // Soft-delete a room; it is purged after the restore window
+			r.Post("/{room_id}/restore", rt.roomHandler.RestoreRoom)     // Restore a deleted room before it is purged
 
 			// Message operations within a room
-			r.Post("/{room_id}/messages", rt.messageHandler.SendMessage) // Send a message to a specific room
-			r.Get("/{room_id}/messages", rt.messageHandler.GetMessages)  // Get message history for a room
+			r.With(scope(types.ScopeMessagesWrite)).Post("/{room_id}/messages", rt.messageHandler.SendMessage) // Send a message to a specific room
+			r.With(scope(types.ScopeMessagesRead)).Get("/{room_id}/messages", rt.messageHandler.GetMessages)   // Get message history for a room
+
+			// Room history exports
+			r.Post("/{room_id}/exports", rt.exportHandler.CreateExport)         // Request an asynchronous history export
+			r.Get("/{room_id}/exports", rt.exportHandler.ListExports)           // List recent exports for a room
+			r.Get("/{room_id}/exports/{export_id}", rt.exportHandler.GetExport) // Get export status, progress and download link
+
+			// Message retention
+			r.Get("/{room_id}/retention", rt.retentionHandler.GetPolicy)            // Get the room's retention policy
+			r.Put("/{room_id}/retention", rt.retentionHandler.UpdatePolicy)         // Set or clear the room's retention policy
+			r.Get("/{room_id}/retention/preview", rt.retentionHandler.PreviewPurge) // Dry run: report what the next purge would remove
+
+			// Audit log
+			r.Get("/{room_id}/audit", rt.auditHandler.ListRoomAudit) // Page through the room's audit log (admins)
+
+			// Incoming webhooks
+			r.Post("/{room_id}/webhooks", rt.webhookHandler.CreateIncomingWebhook)                // Create a webhook; its token is only returned here
+			r.Get("/{room_id}/webhooks", rt.webhookHandler.ListIncomingWebhooks)                  // List the room's webhooks
+			r.Patch("/{room_id}/webhooks/{webhook_id}", rt.webhookHandler.UpdateIncomingWebhook)  // Change a webhook's default name or avatar
+			r.Delete("/{room_id}/webhooks/{webhook_id}", rt.webhookHandler.DeleteIncomingWebhook) // Delete a webhook, disabling its URL
+
+			// Outgoing webhooks
+			r.Post("/{room_id}/outgoing-webhooks", rt.webhookHandler.CreateRoomOutgoingWebhook) // Register an endpoint; its secret is only returned here
+			r.Get("/{room_id}/outgoing-webhooks", rt.webhookHandler.ListRoomOutgoingWebhooks)   // List the room's own outgoing webhooks
+
+			// Slash commands
+			r.With(scope(types.ScopeRoomsRead)).Get("/{room_id}/commands", rt.commandHandler.ListRoomCommands)        // List the commands members can run, with their arguments
+			r.With(scope(types.ScopeCommandsWrite)).Post("/{room_id}/commands", rt.commandHandler.RegisterBotCommand) // Register a command the calling bot answers
+		})
+
+		r.Route("/outgoing-webhooks", func(r chi.Router) {
+			r.Use(rt.authMw.RequireAuth)
+
+			r.Get("/{webhook_id}", rt.webhookHandler.GetOutgoingWebhook)                  // Get an outgoing webhook
+			r.Patch("/{webhook_id}", rt.webhookHandler.UpdateOutgoingWebhook)             // Change its URL or events, or pause and resume it
+			r.Delete("/{webhook_id}", rt.webhookHandler.DeleteOutgoingWebhook)            // Delete it along with its delivery log
+			r.Post("/{webhook_id}/secret", rt.webhookHandler.RotateOutgoingWebhookSecret) // Replace its signing secret
+			r.Post("/{webhook_id}/ping", rt.webhookHandler.PingOutgoingWebhook)           // Send a ping delivery to check the receiver
+
+			// Delivery log
+			r.Get("/{webhook_id}/deliveries", rt.webhookHandler.ListDeliveries)                             // Page through deliveries; status=FAILED lists dead letters
+			r.Get("/{webhook_id}/deliveries/{delivery_id}", rt.webhookHandler.GetDelivery)                  // Get a delivery with its payload and attempts
+			r.Post("/{webhook_id}/deliveries/{delivery_id}/redeliver", rt.webhookHandler.RedeliverDelivery) // Send a finished delivery again
+
+			// Slash commands
+			r.Post("/{webhook_id}/commands", rt.commandHandler.RegisterWebhookCommand) // Register a command the webhook answers
+			r.Get("/{webhook_id}/commands", rt.commandHandler.ListWebhookCommands)     // List the webhook's commands
+		})
+
+		r.Route("/commands", func(r chi.Router) {
+			r.Use(rt.authMw.RequireAuth, scope(types.ScopeCommandsWrite))
+
+			r.Patch("/{command_id}", rt.commandHandler.UpdateCommand)  // Change a command's name, description or arguments
+			r.Delete("/{command_id}", rt.commandHandler.DeleteCommand) // Unregister a command
+		})
+
+		r.Route("/command-responses", func(r chi.Router) {
+			r.Post("/{token}", rt.commandHandler.Respond) // Answer a command invocation (token-authenticated)
+		})
+
+		r.Route("/bots", func(r chi.Router) {
+			r.Use(rt.authMw.RequireAuth)
+
+			r.Post("/", rt.botHandler.CreateBot)           // Create a bot owned by the authenticated user in one of their workspaces
+			r.Get("/", rt.botHandler.ListBots)             // List the authenticated user's bots
+			r.Get("/{bot_id}", rt.botHandler.GetBot)       // Get one of the user's bots
+			r.Patch("/{bot_id}", rt.botHandler.UpdateBot)  // Change a bot's name or image
+			r.Delete("/{bot_id}", rt.botHandler.DeleteBot) // Delete a bot, revoking its tokens and memberships
+
+			// Bot tokens
+			r.Post("/{bot_id}/tokens", rt.botHandler.CreateToken)              // Issue a scoped token; it is only returned here
+			r.Get("/{bot_id}/tokens", rt.botHandler.ListTokens)                // List a bot's tokens
+			r.Delete("/{bot_id}/tokens/{token_id}", rt.botHandler.RevokeToken) // Revoke a token
 		})
 
 		r.Route("/messages", func(r chi.Router) {
 			r.Use(rt.authMw.RequireAuth)
 
 			// Individual message operations
-			r.Put("/{message_id}", rt.messageHandler.EditMessage)                 // Edit a specific message
-			r.Delete("/{message_id}", rt.messageHandler.DeleteMessage)            // Delete a specific message
-			r.Get("/{message_id}/receipts", rt.messageHandler.GetMessageReceipts) // Get read receipts for a specific message
+			r.With(scope(types.ScopeMessagesWrite)).Put("/{message_id}", rt.messageHandler.EditMessage)      // Edit a specific message
+			r.With(scope(types.ScopeMessagesWrite)).Delete("/{message_id}", rt.messageHandler.DeleteMessage) // Delete a specific message
+			r.Get("/{message_id}/receipts", rt.messageHandler.GetMessageReceipts)                            // Get read receipts for a specific message
+			r.Post("/{message_id}/report", rt.reportHandler.ReportMessage)                                   // Report a message to moderators
+		})
+
+		r.Route("/users", func(r chi.Router) {
+			r.Use(rt.authMw.RequireAuth)
+
+			r.Post("/{user_id}/report", rt.reportHandler.ReportUser) // Report a user to moderators
+		})
+
+		r.Route("/invitations", func(r chi.Router) {
+			r.Use(rt.authMw.RequireAuth)
+
+			r.With(scope(types.ScopeRoomsJoin)).Get("/", rt.roomHandler.ListMyInvitations)                       // List the authenticated user's pending invitations
+			r.With(scope(types.ScopeRoomsJoin)).Post("/{invitation_id}/accept", rt.roomHandler.AcceptInvitation) // Accept an invitation and join the room
+			r.Post("/{invitation_id}/decline", rt.roomHandler.DeclineInvitation)                                 // Decline an invitation
+		})
+
+		r.Route("/join-requests", func(r chi.Router) {
+			r.Use(rt.authMw.RequireAuth)
+
+			r.Get("/", rt.roomHandler.ListMyJoinRequests)               // List the authenticated user's pending join requests
+			r.Delete("/{request_id}", rt.roomHandler.CancelJoinRequest) // Withdraw a pending join request
+		})
+
+		r.Route("/invites", func(r chi.Router) {
+			r.Get("/{token}", rt.roomHandler.PreviewInviteLink)                                                                 // Preview the room behind an invite link (public)
+			r.With(rt.authMw.RequireAuth, scope(types.ScopeRoomsJoin)).Post("/{token}/accept", rt.roomHandler.AcceptInviteLink) // Join a room through an invite link
+		})
+
+		r.Route("/hooks", func(r chi.Router) {
+			r.Post("/{token}", rt.webhookHandler.ExecuteIncomingWebhook) // Post a message through an incoming webhook (token-authenticated)
 		})
 
 		r.Route("/receipts", func(r chi.Router) {
 			r.Use(rt.authMw.RequireAuth)
 
 			// Message read receipt operations
-			r.Post("/bulk_seen", rt.messageHandler.MarkMessagesSeen) // Mark multiple messages as seen
+			r.With(scope(types.ScopeMessagesRead)).Post("/bulk_seen", rt.messageHandler.MarkMessagesSeen) // Mark multiple messages as seen
+		})
+
+		r.Route("/moderation", func(r chi.Router) {
+			r.Use(rt.authMw.RequireAuth)
+
+			// Moderation queue for room admins and system admins
+			r.Get("/reports", rt.reportHandler.ListReports)                        // List reports, filtered by status and room
+			r.Get("/reports/{report_id}", rt.reportHandler.GetReport)              // Get a single report
+			r.Post("/reports/{report_id}/assign", rt.reportHandler.AssignReport)   // Assign a report to a moderator
+			r.Post("/reports/{report_id}/resolve", rt.reportHandler.ResolveReport) // Resolve a report, optionally taking action
 		})
 
 	})
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"maps"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -23,9 +26,62 @@ type Client struct {
 	send   chan []byte
 	userID string
 	logger *slog.Logger
-	rooms  map[string]bool
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	// rooms holds the channels the client subscribed to. Subscription goroutines
+	// add to it while the hub removes from it, so it is only used under roomsMu.
+	roomsMu sync.Mutex
+	rooms   map[string]bool
+
+	// closedRooms holds rooms the client lost access to while subscribed; their
+	// channels are no longer delivered until the client subscribes again.
+	closedMu    sync.Mutex
+	closedRooms map[string]bool
+}
+
+func (c *Client) addChannel(channelName string) {
+	c.roomsMu.Lock()
+	defer c.roomsMu.Unlock()
+	c.rooms[channelName] = true
+}
+
+// removeRoomChannels forgets the client's channels of a room and returns them.
+func (c *Client) removeRoomChannels(roomID string) []string {
+	c.roomsMu.Lock()
+	defer c.roomsMu.Unlock()
+	var removed []string
+	for channelName := range c.rooms {
+		if channelRoomID(channelName) == roomID {
+			delete(c.rooms, channelName)
+			removed = append(removed, channelName)
+		}
+	}
+	return removed
+}
+
+func (c *Client) channels() []string {
+	c.roomsMu.Lock()
+	defer c.roomsMu.Unlock()
+	return slices.Collect(maps.Keys(c.rooms))
+}
+
+func (c *Client) closeRoom(roomID string) {
+	c.closedMu.Lock()
+	defer c.closedMu.Unlock()
+	c.closedRooms[roomID] = true
+}
+
+func (c *Client) reopenRoom(roomID string) {
+	c.closedMu.Lock()
+	defer c.closedMu.Unlock()
+	delete(c.closedRooms, roomID)
+}
+
+func (c *Client) isRoomClosed(roomID string) bool {
+	c.closedMu.Lock()
+	defer c.closedMu.Unlock()
+	return c.closedRooms[roomID]
 }
 
 // readPump parses messages from the client and sends them to the hub.
@@ -70,6 +126,7 @@ func (c *Client) handleNewMessage(message []byte) {
 		c.hub.subscribe <- &SubscriptionRequest{
 			Client:       c,
 			ChannelNames: payload.Channels,
+			ResumeFrom:   payload.ResumeFrom,
 		}
 	}
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/websocket"
 	"github.com/purushothdl/gochat-backend/internal/config"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
 	"github.com/purushothdl/gochat-backend/pkg/auth"
 )
 
@@ -18,16 +20,23 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// BotAuthenticator resolves bot tokens, so that bots can receive events too.
+type BotAuthenticator interface {
+	AuthenticateBot(ctx context.Context, token string) (*types.BotIdentity, error)
+}
+
 // Handler holds dependencies for serving WebSocket connections.
 type Handler struct {
 	hub    *Hub
+	bots   BotAuthenticator
 	config *config.Config
 	logger *slog.Logger
 }
 
-func NewHandler(hub *Hub, cfg *config.Config, logger *slog.Logger) *Handler {
+func NewHandler(hub *Hub, bots BotAuthenticator, cfg *config.Config, logger *slog.Logger) *Handler {
 	return &Handler{
 		hub:    hub,
+		bots:   bots,
 		config: cfg,
 		logger: logger,
 	}
@@ -41,16 +50,32 @@ func (h *Handler) ServeWs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	claims, err := auth.ValidateAccessToken(accessToken, h.config.JWT.Secret)
-	if err != nil {
-		h.logger.Error("websocket auth failed: invalid token", "error", err)
-		http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
-		return
+	var userID string
+	if strings.HasPrefix(accessToken, types.BotTokenPrefix) {
+		identity, err := h.bots.AuthenticateBot(r.Context(), accessToken)
+		if err != nil {
+			h.logger.Error("websocket auth failed: invalid bot token", "error", err)
+			http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
+			return
+		}
+		if !identity.HasScope(types.ScopeEventsRead) {
+			http.Error(w, "Forbidden: Token lacks the events:read scope", http.StatusForbidden)
+			return
+		}
+		userID = identity.BotID
+	} else {
+		claims, err := auth.ValidateAccessToken(accessToken, h.config.JWT.Secret)
+		if err != nil {
+			h.logger.Error("websocket auth failed: invalid token", "error", err)
+			http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
+			return
+		}
+		userID = claims.UserID
 	}
 
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		h.logger.Error("failed to upgrade connection", "error", err, "user_id", claims.UserID)
+		h.logger.Error("failed to upgrade connection", "error", err, "user_id", userID)
 		return
 	}
 
@@ -59,11 +84,13 @@ func (h *Handler) ServeWs(w http.ResponseWriter, r *http.Request) {
 		hub:    h.hub,
 		conn:   conn,
 		send:   make(chan []byte, 256),
-		userID: claims.UserID,
-		logger: h.logger.With("user_id", claims.UserID),
+		userID: userID,
+		logger: h.logger.With("user_id", userID),
 		rooms:  make(map[string]bool),
 		ctx:    ctx,
 		cancel: cancel,
+
+		closedRooms: make(map[string]bool),
 	}
 
 	// Register the client with the hub and start its pumps.
@@ -79,5 +106,10 @@ func (h *Handler) getAccessToken(r *http.Request) string {
 	if token := r.URL.Query().Get("token"); token != "" {
 		return token
 	}
+	// Bots, which connect from servers rather than browsers, can send their
+	// token in the Authorization header instead.
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
 	return ""
 }
\ No newline at end of file
@@ -2,42 +2,72 @@ package websocket
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
 )
 
-// SubscriptionRequest pairs a client with the channel names they want to join.
+// RoomAccess looks up what decides whether a user may follow a room's channels.
+type RoomAccess interface {
+	GetRoomInfo(ctx context.Context, roomID string) (*types.RoomInfo, error)
+	GetMembershipInfo(ctx context.Context, roomID, userID string) (*types.MembershipInfo, error)
+	IsBanned(ctx context.Context, roomID, userID string) (bool, error)
+}
+
+// SubscriptionRequest pairs a client with the channel names they want to join
+// and, optionally, the last seq they saw on each of them.
 type SubscriptionRequest struct {
 	Client       *Client
 	ChannelNames []string
+	ResumeFrom   map[string]string
+}
+
+// roomClosure asks the hub to drop a client's subscriptions to a room it lost access to.
+type roomClosure struct {
+	client *Client
+	roomID string
 }
 
 // Hub maintains the set of active clients and orchestrates subscriptions.
 type Hub struct {
-	clients    map[*Client]bool
-	register   chan *Client
-	unregister chan *Client
-	subscribe  chan *SubscriptionRequest
-	logger     *slog.Logger
-	pubsub     contracts.PubSub
-	presence   contracts.PresenceManager
+	clients     map[*Client]bool
+	register    chan *Client
+	unregister  chan *Client
+	subscribe   chan *SubscriptionRequest
+	closeRoom   chan *roomClosure
+	logger      *slog.Logger
+	pubsub      contracts.PubSub
+	presence    contracts.PresenceManager
+	eventLog    contracts.EventLog
+	rooms       RoomAccess
+	replayLimit int
 }
 
 func NewHub(
 	logger *slog.Logger,
 	pubsub contracts.PubSub,
 	presence contracts.PresenceManager,
+	eventLog contracts.EventLog,
+	rooms RoomAccess,
+	replayLimit int,
 ) *Hub {
 	return &Hub{
-		clients:    make(map[*Client]bool),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		subscribe:  make(chan *SubscriptionRequest),
-		logger:     logger,
-		pubsub:     pubsub,
-		presence:   presence,
+		clients:     make(map[*Client]bool),
+		register:    make(chan *Client),
+		unregister:  make(chan *Client),
+		subscribe:   make(chan *SubscriptionRequest),
+		closeRoom:   make(chan *roomClosure),
+		logger:      logger,
+		pubsub:      pubsub,
+		presence:    presence,
+		eventLog:    eventLog,
+		rooms:       rooms,
+		replayLimit: replayLimit,
 	}
 }
 
@@ -54,6 +84,9 @@ func (h *Hub) Run() {
 
 		case req := <-h.subscribe:
 			go h.startRedisSubscription(req)
+
+		case closure := <-h.closeRoom:
+			h.dropRoom(closure.client, closure.roomID)
 		}
 	}
 }
@@ -62,12 +95,18 @@ func (h *Hub) Run() {
 func (h *Hub) startRedisSubscription(req *SubscriptionRequest) {
 	client := req.Client
 
+	// Room channels are only followed, replayed and reopened after a closure
+	// once access to the room has been checked again.
+	req.ChannelNames = h.allowedChannels(client, req.ChannelNames)
 	if len(req.ChannelNames) == 0 {
 		return
 	}
 
 	for _, channelName := range req.ChannelNames {
-		client.rooms[channelName] = true
+		client.addChannel(channelName)
+		if roomID := channelRoomID(channelName); roomID != "" {
+			client.reopenRoom(roomID)
+		}
 		if id, ok := parseRoomID(channelName); ok {
 			h.presence.AddToRoom(client.ctx, id, client.userID)
 		}
@@ -82,6 +121,10 @@ func (h *Hub) startRedisSubscription(req *SubscriptionRequest) {
 
 	h.logger.Info("client subscribed to redis channels", "user_id", client.userID, "channels", req.ChannelNames)
 
+	// Live messages are already being buffered at this point, so replaying now
+	// leaves no window in which an event could fall between history and live.
+	replayed := h.replayMissed(req)
+
 	// This loop forwards messages from Redis to the client's send channel.
 	for {
 		select {
@@ -89,7 +132,19 @@ func (h *Hub) startRedisSubscription(req *SubscriptionRequest) {
 			if msg == nil {
 				return
 			}
+			if len(replayed) > 0 && replayed[eventID(msg.Payload)] {
+				continue
+			}
+			roomID := channelRoomID(msg.Channel)
+			if roomID != "" && client.isRoomClosed(roomID) {
+				continue
+			}
 			client.send <- []byte(msg.Payload)
+			if roomID != "" && closesRoom(msg.Payload, client.userID) {
+				// Filter the room out right away; the hub tidies up presence.
+				client.closeRoom(roomID)
+				h.closeRoom <- &roomClosure{client: client, roomID: roomID}
+			}
 		case <-client.ctx.Done():
 			// The client's context was cancelled, so we exit this goroutine.
 			return
@@ -97,11 +152,112 @@ func (h *Hub) startRedisSubscription(req *SubscriptionRequest) {
 	}
 }
 
+// replayMissed sends every logged event after each requested resume point and
+// returns the IDs it sent so live copies of them can be skipped. When history
+// cannot cover the gap, the client is told to resync that channel instead.
+func (h *Hub) replayMissed(req *SubscriptionRequest) map[string]bool {
+	client := req.Client
+	replayed := make(map[string]bool)
+
+	for channelName, seq := range req.ResumeFrom {
+		if !slices.Contains(req.ChannelNames, channelName) {
+			continue
+		}
+
+		events, err := h.eventLog.ReadAfter(client.ctx, channelName, seq, h.replayLimit+1)
+		if err != nil || len(events) > h.replayLimit {
+			if err != nil && !errors.Is(err, contracts.ErrEventLogGap) {
+				h.logger.Error("failed to read event log", "error", err, "user_id", client.userID, "channel", channelName)
+			}
+			h.sendResyncRequired(client, channelName)
+			continue
+		}
+
+		for _, logged := range events {
+			payload := WithSeq(logged.Payload, channelName, logged.Position)
+			id := eventID(payload)
+			if id != "" {
+				if replayed[id] {
+					continue
+				}
+				replayed[id] = true
+			}
+			client.send <- []byte(payload)
+		}
+		h.logger.Info("replayed missed events", "user_id", client.userID, "channel", channelName, "count", len(events))
+	}
+	return replayed
+}
+
+// allowedChannels returns the channels the client may follow, telling it about
+// each one it was denied.
+func (h *Hub) allowedChannels(client *Client, channelNames []string) []string {
+	access := make(map[string]bool)
+	allowed := make([]string, 0, len(channelNames))
+	for _, channelName := range channelNames {
+		roomID := channelRoomID(channelName)
+		if roomID == "" {
+			allowed = append(allowed, channelName)
+			continue
+		}
+		ok, checked := access[roomID]
+		if !checked {
+			ok = h.canAccessRoom(client, roomID)
+			access[roomID] = ok
+		}
+		if !ok {
+			h.sendClientEvent(client, EventSubscriptionDenied, SubscriptionDeniedPayload{Channel: channelName}, channelName)
+			continue
+		}
+		allowed = append(allowed, channelName)
+	}
+	return allowed
+}
+
+// canAccessRoom reports whether the client may follow a room: it must be a
+// member who is not banned, and the room must be neither archived nor deleted.
+// Lookup failures deny access.
+func (h *Hub) canAccessRoom(client *Client, roomID string) bool {
+	room, err := h.rooms.GetRoomInfo(client.ctx, roomID)
+	if err != nil || room.IsArchived {
+		return false
+	}
+	if _, err := h.rooms.GetMembershipInfo(client.ctx, roomID, client.userID); err != nil {
+		return false
+	}
+	banned, err := h.rooms.IsBanned(client.ctx, roomID, client.userID)
+	if err != nil {
+		h.logger.Error("failed to check room ban", "error", err, "user_id", client.userID, "room_id", roomID)
+		return false
+	}
+	return !banned
+}
+
+func (h *Hub) sendResyncRequired(client *Client, channelName string) {
+	h.sendClientEvent(client, EventResyncRequired, ResyncRequiredPayload{Channel: channelName}, channelName)
+}
+
+// sendClientEvent sends an event about channelName to this client alone.
+func (h *Hub) sendClientEvent(client *Client, eventType EventType, payload any, channelName string) {
+	event, err := NewEvent(eventType, payload)
+	if err != nil {
+		h.logger.Error("failed to build client event", "error", err, "type", eventType)
+		return
+	}
+	event.Channel = channelName
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		h.logger.Error("failed to marshal client event", "error", err, "type", eventType)
+		return
+	}
+	client.send <- eventBytes
+}
+
 // cleanupClient handles unregistering a client and cleaning their presence.
 func (h *Hub) cleanupClient(client *Client) {
 	if _, ok := h.clients[client]; ok {
 		client.cancel() 
-		for channelName := range client.rooms {
+		for _, channelName := range client.channels() {
 			if id, ok := parseRoomID(channelName); ok {
 				h.presence.RemoveFromRoom(context.Background(), id, client.userID)
 			}
@@ -113,6 +269,63 @@ func (h *Hub) cleanupClient(client *Client) {
 	}
 }
 
+// dropRoom forgets a client's subscriptions to a room it lost access to and
+// removes the client from the room's presence.
+func (h *Hub) dropRoom(client *Client, roomID string) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	for _, channelName := range client.removeRoomChannels(roomID) {
+		if id, ok := parseRoomID(channelName); ok {
+			h.presence.RemoveFromRoom(context.Background(), id, client.userID)
+		}
+	}
+	h.logger.Info("dropped subscriptions to closed room", "user_id", client.userID, "room_id", roomID)
+}
+
+// eventID extracts the ID of a serialized event, or "" if it has none.
+func eventID(payload string) string {
+	var event struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return ""
+	}
+	return event.ID
+}
+
+// closesRoom reports whether a serialized event ends userID's access to the
+// room it was sent for: the room was archived or deleted, or the user was banned.
+func closesRoom(payload, userID string) bool {
+	var event struct {
+		Type    EventType `json:"type"`
+		Payload struct {
+			UserID string `json:"user_id"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return false
+	}
+	switch event.Type {
+	case EventRoomArchived, EventRoomDeleted:
+		return true
+	case EventMemberBanned:
+		return event.Payload.UserID == userID
+	}
+	return false
+}
+
+// channelRoomID returns the room a channel belongs to, covering both the room
+// channel and its sub-channels such as room:{id}:messages, or "" for other channels.
+func channelRoomID(channelName string) string {
+	rest, ok := strings.CutPrefix(channelName, "room:")
+	if !ok {
+		return ""
+	}
+	roomID, _, _ := strings.Cut(rest, ":")
+	return roomID
+}
+
 func parseRoomID(channelName string) (string, bool) {
 	prefix := "room:"
 	if strings.HasPrefix(channelName, prefix) {
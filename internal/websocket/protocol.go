@@ -1,6 +1,13 @@
 package websocket
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purushothdl/gochat-backend/internal/contracts"
+	"github.com/purushothdl/gochat-backend/internal/shared/types"
+)
 
 type EventType string
 
@@ -8,19 +15,88 @@ const (
 	EventSubscribe EventType = "SUBSCRIBE"
 	EventUnsubscribe EventType = "UNSUBSCRIBE"
 	EventProfileUpdated EventType = "PROFILE_UPDATED"
+	EventMessageCreated EventType = "MESSAGE_CREATED"
+	EventResyncRequired EventType = "RESYNC_REQUIRED"
+	EventSubscriptionDenied EventType = "SUBSCRIPTION_DENIED"
+	EventInviteReceived EventType = "INVITE_RECEIVED"
+	EventRoomUpdated EventType = "ROOM_UPDATED"
+	EventRoomArchived EventType = "ROOM_ARCHIVED"
+	EventRoomUnarchived EventType = "ROOM_UNARCHIVED"
+	EventRoomDeleted EventType = "ROOM_DELETED"
+	EventMemberBanned EventType = "MEMBER_BANNED"
+	EventMemberUnbanned EventType = "MEMBER_UNBANNED"
+	EventMemberMuted EventType = "MEMBER_MUTED"
+	EventMemberUnmuted EventType = "MEMBER_UNMUTED"
+	EventJoinRequest EventType = "JOIN_REQUEST"
+	EventJoinRequestResolved EventType = "JOIN_REQUEST_RESOLVED"
+	EventMentioned EventType = "MENTIONED"
+	EventRoomPrefsChanged EventType = "ROOM_PREFS_CHANGED"
+	EventCommandInvoked EventType = "COMMAND_INVOKED"
+	EventCommandResponse EventType = "COMMAND_RESPONSE"
 
 	// TODO: Add other events like USER_TYPING, MESSAGE_READ etc.
 )
 
 // Event is the generic structure for all messages sent over the WebSocket.
+// Server-sent events carry a unique ID; delivery is at-least-once, so clients
+// should ignore an ID they have already handled. Seq is the event's position in
+// Channel's history and is what a client passes back as resume_from.
 type Event struct {
+	ID      string          `json:"id,omitempty"`
+	Channel string          `json:"channel,omitempty"`
+	Seq     string          `json:"seq,omitempty"`
 	Type    EventType       `json:"type"`
 	Payload json.RawMessage `json:"payload"`
 }
 
-// SubscribePayload is the specific payload for a SUBSCRIBE event.
+// NewEvent builds a server-sent event with a fresh ID around the given payload.
+func NewEvent(eventType EventType, payload any) (*Event, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		ID:      uuid.NewString(),
+		Type:    eventType,
+		Payload: payloadBytes,
+	}, nil
+}
+
+// WithSeq stamps a serialized event with its channel and its position in that
+// channel's history. Payloads that are not events are returned unchanged.
+func WithSeq(payload, channel, seq string) string {
+	var event Event
+	if err := json.Unmarshal([]byte(payload), &event); err != nil || event.Type == "" {
+		return payload
+	}
+	event.Channel = channel
+	event.Seq = seq
+	stamped, err := json.Marshal(event)
+	if err != nil {
+		return payload
+	}
+	return string(stamped)
+}
+
+// ToOutbox wraps the event for the transactional outbox, to be relayed to channel.
+func (e *Event) ToOutbox(channel string) (*contracts.OutboxEvent, error) {
+	eventBytes, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return &contracts.OutboxEvent{
+		ID:      e.ID,
+		Channel: channel,
+		Payload: string(eventBytes),
+	}, nil
+}
+
+// SubscribePayload is the specific payload for a SUBSCRIBE event. ResumeFrom
+// maps a channel to the seq of the last event the client saw on it; events
+// after that point are replayed before live delivery starts.
 type SubscribePayload struct {
-	Channels []string `json:"channels"` 
+	Channels   []string          `json:"channels"`
+	ResumeFrom map[string]string `json:"resume_from,omitempty"`
 }
 
 // UnsubscribePayload is the specific payload for an UNSUBSCRIBE event.
@@ -28,7 +104,160 @@ type UnsubscribePayload struct {
 	RoomIDs []string `json:"room_ids"`
 }
 
+// ResyncRequiredPayload tells the client that events on Channel could not be
+// replayed and that it should refetch the channel's state over the REST API.
+type ResyncRequiredPayload struct {
+	Channel string `json:"channel"`
+}
+
+// SubscriptionDeniedPayload tells the client it may not follow Channel, as it
+// is not a member of the room, is banned from it, or the room is archived or gone.
+type SubscriptionDeniedPayload struct {
+	Channel string `json:"channel"`
+}
+
 // ProfileUpdatedPayload is the payload for the PROFILE_UPDATED event.
 type ProfileUpdatedPayload struct {
 	NewImageURL string `json:"new_image_url"`
-}
\ No newline at end of file
+}
+
+// InviteReceivedPayload is the payload for the INVITE_RECEIVED event, sent on the invitee's user channel.
+type InviteReceivedPayload struct {
+	InvitationID string    `json:"invitation_id"`
+	RoomID       string    `json:"room_id"`
+	RoomName     string    `json:"room_name"`
+	InviterID    string    `json:"inviter_id"`
+	InviterName  string    `json:"inviter_name"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// RoomUpdatedPayload is the payload for the ROOM_UPDATED event. Only the fields
+// that changed are set.
+type RoomUpdatedPayload struct {
+	RoomID      string  `json:"room_id"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Topic       *string `json:"topic,omitempty"`
+	AvatarURL   *string `json:"avatar_url,omitempty"`
+}
+
+// RoomStatePayload is the payload for the ROOM_ARCHIVED, ROOM_UNARCHIVED and
+// ROOM_DELETED events. PurgeAfter is set on deletion and is when the room can
+// no longer be restored.
+type RoomStatePayload struct {
+	RoomID     string     `json:"room_id"`
+	ActorID    string     `json:"actor_id"`
+	PurgeAfter *time.Time `json:"purge_after,omitempty"`
+}
+
+// MemberSanctionPayload is the payload for the MEMBER_BANNED, MEMBER_UNBANNED,
+// MEMBER_MUTED and MEMBER_UNMUTED events. ActorID is empty when a sanction
+// lapsed on its own; ExpiresAt is unset for permanent bans and lifted sanctions.
+type MemberSanctionPayload struct {
+	RoomID    string     `json:"room_id"`
+	UserID    string     `json:"user_id"`
+	ActorID   string     `json:"actor_id,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// JoinRequestPayload is the payload for the JOIN_REQUEST event, sent on the user
+// channel of each member who can approve it.
+type JoinRequestPayload struct {
+	RequestID string `json:"request_id"`
+	RoomID    string `json:"room_id"`
+	RoomName  string `json:"room_name"`
+	UserID    string `json:"user_id"`
+	UserName  string `json:"user_name"`
+	Message   string `json:"message"`
+}
+
+// JoinRequestResolvedPayload is the payload for the JOIN_REQUEST_RESOLVED event,
+// sent to the requester and to the members who could have approved the request.
+// Status is APPROVED, DENIED or CANCELED; ActorID is empty for cancellations.
+type JoinRequestResolvedPayload struct {
+	RequestID string `json:"request_id"`
+	RoomID    string `json:"room_id"`
+	UserID    string `json:"user_id"`
+	Status    string `json:"status"`
+	ActorID   string `json:"actor_id,omitempty"`
+}
+
+// MentionedPayload is the payload for the MENTIONED event, sent on the
+// mentioned user's channel when their notification settings allow it.
+type MentionedPayload struct {
+	MessageID string    `json:"message_id"`
+	RoomID    string    `json:"room_id"`
+	RoomName  string    `json:"room_name"`
+	SenderID  string    `json:"sender_id"`
+	Excerpt   string    `json:"excerpt"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RoomPrefsChangedPayload is the payload for the ROOM_PREFS_CHANGED event, sent on
+// the user's own channel so their other devices stay in sync. Exactly one of
+// Room and Folder is set.
+type RoomPrefsChangedPayload struct {
+	Room   *RoomPrefsPayload `json:"room,omitempty"`
+	Folder *FolderPayload    `json:"folder,omitempty"`
+}
+
+// RoomPrefsPayload is one room's state in the user's room list.
+type RoomPrefsPayload struct {
+	RoomID     string     `json:"room_id"`
+	IsPinned   bool       `json:"is_pinned"`
+	IsFavorite bool       `json:"is_favorite"`
+	HiddenAt   *time.Time `json:"hidden_at"`
+	FolderID   *string    `json:"folder_id"`
+	Position   *int       `json:"position"`
+}
+
+// NewRoomPrefsPayload builds the payload for a change to one room's prefs.
+func NewRoomPrefsPayload(roomID string, prefs types.RoomPrefs) RoomPrefsChangedPayload {
+	return RoomPrefsChangedPayload{Room: &RoomPrefsPayload{
+		RoomID:     roomID,
+		IsPinned:   prefs.IsPinned,
+		IsFavorite: prefs.IsFavorite,
+		HiddenAt:   prefs.HiddenAt,
+		FolderID:   prefs.FolderID,
+		Position:   prefs.Position,
+	}}
+}
+
+// FolderPayload is a created, changed or, with Deleted set, removed folder.
+// Rooms in a deleted folder move out of it.
+type FolderPayload struct {
+	FolderID string `json:"folder_id"`
+	Name     string `json:"name,omitempty"`
+	Position int    `json:"position"`
+	Deleted  bool   `json:"deleted,omitempty"`
+}
+
+// CommandInvokedPayload is the payload for the COMMAND_INVOKED event, sent on
+// a bot's user channel when one of its slash commands is run. The bot replies
+// by posting to /api/command-responses/{response_token} before ExpiresAt.
+type CommandInvokedPayload struct {
+	InvocationID  string         `json:"invocation_id"`
+	CommandID     string         `json:"command_id"`
+	Command       string         `json:"command"`
+	RoomID        string         `json:"room_id"`
+	UserID        string         `json:"user_id"`
+	Text          string         `json:"text"` // Everything after the command's name
+	Args          map[string]any `json:"args"`
+	ResponseToken string         `json:"response_token"`
+	ExpiresAt     time.Time      `json:"expires_at"`
+}
+
+// CommandResponsePayload is the payload for the COMMAND_RESPONSE event, an
+// ephemeral reply to a slash command sent only on the invoker's user channel.
+type CommandResponsePayload struct {
+	InvocationID string `json:"invocation_id"`
+	Command      string `json:"command"`
+	RoomID       string `json:"room_id"`
+	Text         string `json:"text"`
+}
+
+// RoomChannel is the channel for events about a room itself, such as changes to its details.
+func RoomChannel(roomID string) string {
+	return "room:" + roomID
+}
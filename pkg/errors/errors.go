@@ -6,9 +6,10 @@ import (
 )
 
 type AppError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Status  int    `json:"-"`
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Status  int            `json:"-"`
+	Details map[string]any `json:"details,omitempty"` // Optional machine-readable context, e.g. a retry delay
 }
 
 func (e *AppError) Error() string {
@@ -17,14 +18,16 @@ func (e *AppError) Error() string {
 
 // Common errors
 var (
-	ErrNotFound          = &AppError{"NOT_FOUND", "Resource not found", http.StatusNotFound}
-	ErrUnauthorized      = &AppError{"UNAUTHORIZED", "Unauthorized access", http.StatusUnauthorized}
-	ErrForbidden         = &AppError{"FORBIDDEN", "Forbidden access", http.StatusForbidden}
-	ErrBadRequest        = &AppError{"BAD_REQUEST", "Bad request", http.StatusBadRequest}
-	ErrInternalServer    = &AppError{"INTERNAL_SERVER", "Internal server error", http.StatusInternalServerError}
-	ErrExternalServer    = &AppError{"EXTERNAL_SERVER", "External server error", http.StatusBadGateway}
-	ErrValidationFailed  = &AppError{"VALIDATION_FAILED", "Validation failed", http.StatusBadRequest}
-	ErrS3OperationFailed = &AppError{"S3_OPERATION_FAILED", "S3 operation failed", http.StatusInternalServerError} // New error for S3 operations
+	ErrNotFound          = &AppError{"NOT_FOUND", "Resource not found", http.StatusNotFound, nil}
+	ErrUnauthorized      = &AppError{"UNAUTHORIZED", "Unauthorized access", http.StatusUnauthorized, nil}
+	ErrForbidden         = &AppError{"FORBIDDEN", "Forbidden access", http.StatusForbidden, nil}
+	ErrAccountSuspended  = &AppError{"ACCOUNT_SUSPENDED", "This account is suspended", http.StatusForbidden, nil}
+	ErrInsufficientScope = &AppError{"INSUFFICIENT_SCOPE", "The token's scopes do not allow this action", http.StatusForbidden, nil}
+	ErrBadRequest        = &AppError{"BAD_REQUEST", "Bad request", http.StatusBadRequest, nil}
+	ErrInternalServer    = &AppError{"INTERNAL_SERVER", "Internal server error", http.StatusInternalServerError, nil}
+	ErrExternalServer    = &AppError{"EXTERNAL_SERVER", "External server error", http.StatusBadGateway, nil}
+	ErrValidationFailed  = &AppError{"VALIDATION_FAILED", "Validation failed", http.StatusBadRequest, nil}
+	ErrS3OperationFailed = &AppError{"S3_OPERATION_FAILED", "S3 operation failed", http.StatusInternalServerError, nil} // New error for S3 operations
 )
 
 func New(code, message string, status int) *AppError {
@@ -35,6 +38,13 @@ func New(code, message string, status int) *AppError {
 	}
 }
 
+// WithDetails returns a copy of the error carrying the given details.
+func (e *AppError) WithDetails(details map[string]any) *AppError {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
 func Wrap(err error, message string) error {
 	return fmt.Errorf("%s: %w", message, err)
 }
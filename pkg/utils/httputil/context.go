@@ -0,0 +1,17 @@
+package httputil
+
+import "context"
+
+type clientIPContextKey struct{}
+
+// WithClientIP returns a copy of ctx carrying the client IP of the current request.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, ip)
+}
+
+// ClientIPFromContext returns the client IP stored by WithClientIP, or an empty
+// string when ctx did not come from an HTTP request.
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPContextKey{}).(string)
+	return ip
+}